@@ -0,0 +1,184 @@
+package config
+
+import (
+	"context"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+// defaultLoaders are a slice of functions that will read external configuration
+// sources for configuration values. These values are read by the cybrConfigResolvers
+// using interfaces to extract specific information from the external configuration.
+var defaultLoaders = []loader{
+	loadEnvConfig,
+	loadSharedConfigIgnoreNotExist,
+}
+
+// defaultCYBRConfigResolvers are a slice of functions that will resolve external
+// configuration values into cybr.Config values from a slice of config sources.
+// These resolvers are executed in the order they are defined, and may depend
+// on values set by earlier resolvers.
+var defaultCYBRConfigResolvers = []cybrConfigResolver{
+	// Sets the logger to be used. Could be user provided logger, and client
+	// logging mode.
+	resolveLogger,
+	resolveClientLogMode,
+
+	// Sets the HTTP client and configuration to use for making requests using
+	// the HTTP transport.
+	resolveHTTPClient,
+	resolveCustomCABundle,
+
+	// Sets the tenant the API Clients should use for making requests to.
+	resolveTenantName,
+	resolveTenantID,
+
+	// Sets the custom endpoint resolver API clients will resolve endpoints
+	// with before falling back to their default resolver.
+	resolveEndpointResolverWithOptions,
+
+	// Sets the additional set of middleware stack mutators that will custom
+	// API client request pipeline middleware.
+	resolveAPIOptions,
+
+	// Resolves the retry behavior API clients will use when constructing
+	// a Retryer.
+	resolveRetryer,
+
+	// Sets the defaults mode API clients will be configured with, and
+	// applies the mode's configuration to the HTTP client and retry mode.
+	// Resolved before credentials so that credential providers use the
+	// configured HTTP client.
+	resolveDefaultsMode,
+	resolveDefaultsModeRuntimeEnvironment,
+	resolveDefaultsModeConfiguration,
+
+	// Sets the resolved credentials the API clients will use for
+	// authentication. Provides the SDK's default credential chain.
+	//
+	// Should probably be the last step in the resolve chain to ensure that all
+	// other configurations are resolved first in case downstream credentials
+	// implementations depend on or can be configured with earlier resolved
+	// configuration options.
+	resolveCredentials,
+
+	// Sets the application identifier appended to the User-Agent header.
+	resolveAppID,
+
+	// Sets the base endpoint override API clients should use.
+	resolveBaseEndpoint,
+}
+
+// A Config represents a generic configuration value or set of values. This type
+// will be used by the cybrConfigResolvers to extract
+//
+// General the Config type will use type assertion against the Provider interfaces
+// to extract specific data from the Config.
+type Config interface{}
+
+// A loader is used to load external configuration data and returns it as
+// a generic Config type.
+//
+// The loader should return an error if it fails to load the external configuration
+// or the configuration data is malformed, or required components missing.
+type loader func(context.Context, configs) (Config, error)
+
+// An cybrConfigResolver will extract configuration data from the configs slice
+// using the provider interfaces to extract specific functionality. The extracted
+// configuration values will be written to the cybr.Config value.
+//
+// The resolver should return an error if it it fails to extract the data, the
+// data is malformed, or incomplete.
+type cybrConfigResolver func(ctx context.Context, cfg *cybr.Config, configs configs) error
+
+// configs is a slice of Config values. These values will be used by the
+// cybrConfigResolvers to extract external configuration values to populate the
+// cybr.Config value.
+//
+// General the Config type will use type assertion against the Provider interfaces
+// to extract specific data from the Config.
+type configs []Config
+
+// AppendFromLoaders iterates over the slice of loaders passed in calling each
+// loader function in order. The external config value returned by the loader
+// will be added to the returned configs slice.
+//
+// If a loader returns an error this method will stop iterating and return
+// that error.
+func (cs configs) AppendFromLoaders(ctx context.Context, loaders []loader) (configs, error) {
+	for _, fn := range loaders {
+		cfg, err := fn(ctx, cs)
+		if err != nil {
+			return nil, err
+		}
+
+		cs = append(cs, cfg)
+	}
+
+	return cs, nil
+}
+
+// ResolveCYBRConfig returns a cybr configuration populated with values by calling
+// the resolvers slice passed in. Each resolver is called in order. Any resolver
+// may overwrite the cybr Configuration value of a previous resolver.
+//
+// If an resolver returns an error this method will return that error, and stop
+// iterating over the resolvers.
+func (cs configs) ResolveCYBRConfig(ctx context.Context, resolvers []cybrConfigResolver) (cybr.Config, error) {
+	var cfg cybr.Config
+
+	for _, fn := range resolvers {
+		if err := fn(ctx, &cfg, cs); err != nil {
+			return cybr.Config{}, err
+		}
+	}
+
+	var sources []interface{}
+	for _, s := range cs {
+		sources = append(sources, s)
+	}
+	cfg.ConfigSources = sources
+
+	return cfg, nil
+}
+
+// LoadDefaultConfig reads the SDK's default external configurations, and
+// populates a cybr.Config with the values from the external configurations.
+//
+// An optional variadic set of functional options can be provided as input to
+// modify the LoadOptions. The LoadOptions are prepended to the configs slice,
+// and take precedence over all other configuration sources.
+//
+//	cfg, err := config.LoadDefaultConfig(context.TODO(),
+//	   config.WithTenantName("example"),
+//	)
+//	if err != nil {
+//	   panic(fmt.Sprintf("failed loading config, %v", err))
+//	}
+//
+// The default configuration sources are:
+// * Environment Variables
+// * Shared Configuration and Shared Credentials files.
+func LoadDefaultConfig(ctx context.Context, optFns ...func(*LoadOptions) error) (cfg cybr.Config, err error) {
+	var options LoadOptions
+	for _, optFn := range optFns {
+		if err := optFn(&options); err != nil {
+			return cybr.Config{}, err
+		}
+	}
+
+	// assign Load Options to configs
+	var cfgCpy = configs{options}
+
+	cfgCpy, err = cfgCpy.AppendFromLoaders(ctx, defaultLoaders)
+	if err != nil {
+		return cybr.Config{}, err
+	}
+
+	cfg, err = cfgCpy.ResolveCYBRConfig(ctx, defaultCYBRConfigResolvers)
+	if err != nil {
+		return cybr.Config{}, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,317 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/strick-j/cybr-sdk-go/credentials"
+	"github.com/strick-j/cybr-sdk-go/credentials/oauthcreds"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrhttp "github.com/strick-j/cybr-sdk-go/cybr/transport/http"
+)
+
+func cmpDiff(e, a interface{}) string {
+	return cmp.Diff(e, a)
+}
+
+func TestLoadDefaultConfig(t *testing.T) {
+	restoreEnv := clearEnv()
+	defer restoreEnv()
+
+	os.Setenv("CYBR_TENANT_NAME", "env-tenant")
+	os.Setenv("CYBR_TENANT_ID", "ENV1234")
+	os.Setenv("CYBR_BEARER_TOKEN", "env-token")
+	os.Setenv("CYBR_MAX_ATTEMPTS", "4")
+	os.Setenv("CYBR_RETRY_MODE", "standard")
+	os.Setenv("CYBR_APP_ID", "env-app")
+	os.Setenv("CYBR_ENDPOINT_URL", "https://env.example.com")
+
+	cfg, err := LoadDefaultConfig(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "env-tenant", cfg.TenantName; e != a {
+		t.Errorf("expect %v tenant name, got %v", e, a)
+	}
+	if e, a := "ENV1234", cfg.TenantID; e != a {
+		t.Errorf("expect %v tenant ID, got %v", e, a)
+	}
+	if e, a := 4, cfg.RetryMaxAttempts; e != a {
+		t.Errorf("expect %v max attempts, got %v", e, a)
+	}
+	if e, a := cybr.RetryModeStandard, cfg.RetryMode; e != a {
+		t.Errorf("expect %v retry mode, got %v", e, a)
+	}
+	if e, a := "env-app", cfg.AppID; e != a {
+		t.Errorf("expect %v app ID, got %v", e, a)
+	}
+	if e, a := "https://env.example.com", cybr.ToString(cfg.BaseEndpoint); e != a {
+		t.Errorf("expect %v base endpoint, got %v", e, a)
+	}
+
+	if _, ok := cfg.HTTPClient.(*cybrhttp.BuildableClient); !ok {
+		t.Errorf("expect %T HTTP client, got %T", (*cybrhttp.BuildableClient)(nil), cfg.HTTPClient)
+	}
+	if cfg.Logger == nil {
+		t.Errorf("expect logger to be set")
+	}
+
+	if _, ok := cfg.Credentials.(*cybr.CredentialsCache); !ok {
+		t.Fatalf("expect %T credentials, got %T", (*cybr.CredentialsCache)(nil), cfg.Credentials)
+	}
+	creds, err := cfg.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "env-token", creds.BearerToken; e != a {
+		t.Errorf("expect %v bearer token, got %v", e, a)
+	}
+	if e, a := CredentialsSourceName, creds.Source; e != a {
+		t.Errorf("expect %v source, got %v", e, a)
+	}
+
+	if e, a := 3, len(cfg.ConfigSources); e != a {
+		t.Errorf("expect %v config sources, got %v", e, a)
+	}
+}
+
+func TestLoadDefaultConfig_LoadOptionsPrecedence(t *testing.T) {
+	restoreEnv := clearEnv()
+	defer restoreEnv()
+
+	os.Setenv("CYBR_TENANT_NAME", "env-tenant")
+	os.Setenv("CYBR_BEARER_TOKEN", "env-token")
+	os.Setenv("CYBR_APP_ID", "env-app")
+
+	logger := logging.Nop{}
+	httpClient := cybrhttp.NewBuildableClient()
+
+	cfg, err := LoadDefaultConfig(context.Background(),
+		WithTenantName("option-tenant"),
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider("option-token")),
+		WithAppID("option-app"),
+		WithRetryer(func() cybr.Retryer { return cybr.NopRetryer{} }),
+		WithRetryMaxAttempts(10),
+		WithLogger(logger),
+		WithClientLogMode(cybr.LogRequest|cybr.LogRetries),
+		WithHTTPClient(httpClient),
+		WithEndpointResolverWithOptions(cybr.EndpointResolverWithOptionsFunc(
+			func(service string, options ...interface{}) (cybr.Endpoint, error) {
+				return cybr.Endpoint{URL: "https://option.example.com"}, nil
+			})),
+	)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "option-tenant", cfg.TenantName; e != a {
+		t.Errorf("expect %v tenant name, got %v", e, a)
+	}
+	if e, a := "option-app", cfg.AppID; e != a {
+		t.Errorf("expect %v app ID, got %v", e, a)
+	}
+	if cfg.Retryer == nil {
+		t.Errorf("expect retryer to be set")
+	}
+	if e, a := 0, cfg.RetryMaxAttempts; e != a {
+		t.Errorf("expect max attempts to be ignored with custom retryer, got %v", a)
+	}
+	if cfg.Logger != logger {
+		t.Errorf("expect logger to be the provided logger")
+	}
+	if e, a := cybr.LogRequest|cybr.LogRetries, cfg.ClientLogMode; e != a {
+		t.Errorf("expect %v client log mode, got %v", e, a)
+	}
+	if cfg.HTTPClient != httpClient {
+		t.Errorf("expect HTTP client to be the provided client")
+	}
+	if cfg.EndpointResolverWithOptions == nil {
+		t.Errorf("expect endpoint resolver to be set")
+	} else if endpoint, _ := cfg.EndpointResolverWithOptions.ResolveEndpoint("DPA"); endpoint.URL != "https://option.example.com" {
+		t.Errorf("expect endpoint resolver to be the provided resolver, got %v", endpoint.URL)
+	}
+
+	creds, err := cfg.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "option-token", creds.BearerToken; e != a {
+		t.Errorf("expect %v bearer token, got %v", e, a)
+	}
+}
+
+func TestLoadDefaultConfig_AnonymousCredentials(t *testing.T) {
+	restoreEnv := clearEnv()
+	defer restoreEnv()
+
+	cfg, err := LoadDefaultConfig(context.Background(),
+		WithCredentialsProvider(cybr.AnonymousCredentials{}),
+	)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if _, ok := cfg.Credentials.(cybr.AnonymousCredentials); !ok {
+		t.Errorf("expect anonymous credentials to not be cached, got %T", cfg.Credentials)
+	}
+}
+
+func TestLoadDefaultConfig_NoCredentials(t *testing.T) {
+	restoreEnv := clearEnv()
+	defer restoreEnv()
+
+	cfg, err := LoadDefaultConfig(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if cfg.Credentials != nil {
+		t.Errorf("expect no credentials, got %T", cfg.Credentials)
+	}
+}
+
+func TestLoadDefaultConfig_OAuthClientCredentials(t *testing.T) {
+	restoreEnv := clearEnv()
+	defer restoreEnv()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "/oauth2/platformtoken", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if e, a := "service-user@example.com", r.PostForm.Get("client_id"); e != a {
+			t.Errorf("expect %v client ID, got %v", e, a)
+		}
+		w.Write([]byte(`{"access_token":"oauth-token","token_type":"Bearer","expires_in":900}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("CYBR_IDENTITY_URL", server.URL)
+	os.Setenv("CYBR_CLIENT_ID", "service-user@example.com")
+	os.Setenv("CYBR_CLIENT_SECRET", "client-secret")
+
+	cfg, err := LoadDefaultConfig(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	creds, err := cfg.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "oauth-token", creds.BearerToken; e != a {
+		t.Errorf("expect %v bearer token, got %v", e, a)
+	}
+	if e, a := oauthcreds.ProviderName, creds.Source; e != a {
+		t.Errorf("expect %v source, got %v", e, a)
+	}
+	if !creds.CanExpire {
+		t.Errorf("expect credentials to expire")
+	}
+}
+
+func TestLoadDefaultConfig_OAuthClientCredentialsNoIdentityURL(t *testing.T) {
+	restoreEnv := clearEnv()
+	defer restoreEnv()
+
+	os.Setenv("CYBR_CLIENT_ID", "service-user@example.com")
+	os.Setenv("CYBR_CLIENT_SECRET", "client-secret")
+
+	if _, err := LoadDefaultConfig(context.Background()); err == nil {
+		t.Fatalf("expect error, got none")
+	}
+}
+
+func TestLoadDefaultConfig_CustomCABundle(t *testing.T) {
+	restoreEnv := clearEnv()
+	defer restoreEnv()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	filename := filepath.Join(t.TempDir(), "bundle.pem")
+	pemCerts := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(filename, pemCerts, 0600); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	cases := map[string]struct {
+		Env     map[string]string
+		Options []func(*LoadOptions) error
+	}{
+		"env": {
+			Env: map[string]string{"CYBR_CA_BUNDLE": filename},
+		},
+		"load option": {
+			Options: []func(*LoadOptions) error{WithCustomCABundle(bytes.NewReader(pemCerts))},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			restoreEnv := clearEnv()
+			defer restoreEnv()
+			for k, v := range c.Env {
+				os.Setenv(k, v)
+			}
+
+			cfg, err := LoadDefaultConfig(context.Background(), c.Options...)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			resp, err := cfg.HTTPClient.Do(req)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestLoadDefaultConfig_CustomCABundleErrors(t *testing.T) {
+	cases := map[string]struct {
+		Env     map[string]string
+		Options []func(*LoadOptions) error
+	}{
+		"missing file": {
+			Env: map[string]string{"CYBR_CA_BUNDLE": filepath.Join(t.TempDir(), "missing.pem")},
+		},
+		"invalid PEM": {
+			Options: []func(*LoadOptions) error{WithCustomCABundle(strings.NewReader("not a certificate"))},
+		},
+		"not buildable client": {
+			Options: []func(*LoadOptions) error{
+				WithCustomCABundle(strings.NewReader("not a certificate")),
+				WithHTTPClient(&http.Client{}),
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			restoreEnv := clearEnv()
+			defer restoreEnv()
+			for k, v := range c.Env {
+				os.Setenv(k, v)
+			}
+
+			if _, err := LoadDefaultConfig(context.Background(), c.Options...); err == nil {
+				t.Fatalf("expect error, got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"context"
+	"os"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/discovery"
+)
+
+// Environment variables inspected to determine the region the SDK is
+// executing in when using cybr.DefaultsModeAuto.
+const (
+	awsRegionEnvVar        = "AWS_REGION"
+	awsDefaultRegionEnvVar = "AWS_DEFAULT_REGION"
+)
+
+// ciEnvVars are environment variables set by common CI systems.
+var ciEnvVars = []string{
+	"CI",
+	"BUILD_ID",
+	"BUILDKITE",
+	"CODEBUILD_BUILD_ID",
+	"GITHUB_ACTIONS",
+	"GITLAB_CI",
+	"JENKINS_URL",
+	"TEAMCITY_VERSION",
+	"TF_BUILD",
+}
+
+// DefaultsModeOptions is the set of options that are used to configure the
+// SDK's defaults mode.
+type DefaultsModeOptions struct {
+	// The SDK configuration defaults mode. Defaults to legacy if not specified.
+	//
+	// Supported modes are: auto, cross-region, in-region, legacy, mobile,
+	// standard
+	Mode cybr.DefaultsMode
+
+	// The platform discovery client used to resolve the tenant's region when
+	// the mode is set to cybr.DefaultsModeAuto. If not specified the SDK will
+	// construct a client if the tenant's region needs to be resolved.
+	DiscoveryClient *discovery.Client
+}
+
+// resolveExecutionEnvironment returns the identifier of the environment the
+// SDK is executing in, determined from environment heuristics. Only CI
+// systems are detected, as they are the only environment that resolves to a
+// distinct defaults mode. Returns empty if the environment can not be
+// determined.
+func resolveExecutionEnvironment() cybr.ExecutionEnvironmentID {
+	for _, k := range ciEnvVars {
+		if len(os.Getenv(k)) > 0 {
+			return cybr.ExecutionEnvironmentCI
+		}
+	}
+
+	return ""
+}
+
+// resolveExecutionRegion returns the region the SDK is executing in, if set
+// in the environment by the hosting platform. CyberArk tenants are hosted in
+// AWS regions, so the region is compared with the tenant's region.
+func resolveExecutionRegion() string {
+	var region string
+	setStringFromEnvVal(&region, []string{awsRegionEnvVar, awsDefaultRegionEnvVar})
+	return region
+}
+
+// resolveTenantRegion returns the tenant's region resolved by the platform
+// discovery API. Returns empty if the region could not be resolved.
+func resolveTenantRegion(ctx context.Context, cfg *cybr.Config, client *discovery.Client) string {
+	if client == nil {
+		client = discovery.New(func(o *discovery.Options) {
+			o.HTTPClient = cfg.HTTPClient
+		})
+	}
+
+	result, err := client.Discover(ctx, cfg.TenantName)
+	if err != nil {
+		return ""
+	}
+	return result.Region
+}
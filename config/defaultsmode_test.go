@@ -0,0 +1,221 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/discovery"
+	cybrhttp "github.com/strick-j/cybr-sdk-go/cybr/transport/http"
+)
+
+// clearExecutionEnv clears the environment variables inspected to determine
+// the execution environment for the test.
+func clearExecutionEnv(t *testing.T) {
+	t.Helper()
+
+	for _, k := range append([]string{awsRegionEnvVar, awsDefaultRegionEnvVar}, ciEnvVars...) {
+		t.Setenv(k, "")
+	}
+}
+
+func TestResolveExecutionEnvironment(t *testing.T) {
+	cases := map[string]struct {
+		Env    map[string]string
+		Expect cybr.ExecutionEnvironmentID
+	}{
+		"unknown": {},
+		"ci": {
+			Env:    map[string]string{"GITHUB_ACTIONS": "true"},
+			Expect: cybr.ExecutionEnvironmentCI,
+		},
+		"jenkins": {
+			Env:    map[string]string{"JENKINS_URL": "https://jenkins.example.com"},
+			Expect: cybr.ExecutionEnvironmentCI,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			clearExecutionEnv(t)
+			for k, v := range c.Env {
+				t.Setenv(k, v)
+			}
+
+			if e, a := c.Expect, resolveExecutionEnvironment(); e != a {
+				t.Errorf("expect %q environment, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestLoadDefaultConfig_DefaultsMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "/api/v2/services/subdomain/example", r.URL.Path; e != a {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"identity_administration":{"api":"https://aa1234.id.cyberark.cloud","region":"us-east-1"}}`)
+	}))
+	defer server.Close()
+
+	discoveryClient := discovery.New(func(o *discovery.Options) {
+		o.Endpoint = server.URL
+	})
+
+	cases := map[string]struct {
+		Env                  map[string]string
+		Options              []func(*LoadOptions) error
+		ExpectEnvironment    cybr.RuntimeEnvironment
+		ExpectConnectTimeout time.Duration
+		ExpectTLSTimeout     time.Duration
+		ExpectRetryMode      cybr.RetryMode
+		ExpectConfiguredMode cybr.DefaultsMode
+	}{
+		"legacy": {
+			ExpectConnectTimeout: cybrhttp.DefaultDialConnectTimeout,
+			ExpectTLSTimeout:     cybrhttp.DefaultHTTPTransportTLSHandleshakeTimeout,
+			ExpectConfiguredMode: cybr.DefaultsModeLegacy,
+		},
+		"standard": {
+			Env:                  map[string]string{"CYBR_DEFAULTS_MODE": "standard"},
+			ExpectConnectTimeout: 3100 * time.Millisecond,
+			ExpectTLSTimeout:     3100 * time.Millisecond,
+			ExpectRetryMode:      cybr.RetryModeStandard,
+			ExpectConfiguredMode: cybr.DefaultsModeStandard,
+		},
+		"explicit retry mode": {
+			Env: map[string]string{
+				"CYBR_DEFAULTS_MODE": "standard",
+				"CYBR_RETRY_MODE":    "adaptive",
+			},
+			ExpectConnectTimeout: 3100 * time.Millisecond,
+			ExpectTLSTimeout:     3100 * time.Millisecond,
+			ExpectRetryMode:      cybr.RetryModeAdaptive,
+			ExpectConfiguredMode: cybr.DefaultsModeStandard,
+		},
+		"auto in-region": {
+			Env: map[string]string{
+				awsRegionEnvVar: "us-east-1",
+			},
+			Options: []func(*LoadOptions) error{
+				WithTenantName("example"),
+				WithDefaultsMode(cybr.DefaultsModeAuto, func(o *DefaultsModeOptions) {
+					o.DiscoveryClient = discoveryClient
+				}),
+			},
+			ExpectEnvironment: cybr.RuntimeEnvironment{
+				Region:       "us-east-1",
+				TenantRegion: "us-east-1",
+			},
+			ExpectConnectTimeout: 1100 * time.Millisecond,
+			ExpectTLSTimeout:     1100 * time.Millisecond,
+			ExpectRetryMode:      cybr.RetryModeStandard,
+			ExpectConfiguredMode: cybr.DefaultsModeAuto,
+		},
+		"auto cross-region": {
+			Env: map[string]string{
+				awsDefaultRegionEnvVar: "eu-west-1",
+			},
+			Options: []func(*LoadOptions) error{
+				WithTenantName("example"),
+				WithDefaultsMode(cybr.DefaultsModeAuto, func(o *DefaultsModeOptions) {
+					o.DiscoveryClient = discoveryClient
+				}),
+			},
+			ExpectEnvironment: cybr.RuntimeEnvironment{
+				Region:       "eu-west-1",
+				TenantRegion: "us-east-1",
+			},
+			ExpectConnectTimeout: 3100 * time.Millisecond,
+			ExpectTLSTimeout:     3100 * time.Millisecond,
+			ExpectRetryMode:      cybr.RetryModeStandard,
+			ExpectConfiguredMode: cybr.DefaultsModeAuto,
+		},
+		"auto discovery failure": {
+			Env: map[string]string{
+				awsRegionEnvVar: "us-east-1",
+			},
+			Options: []func(*LoadOptions) error{
+				WithTenantName("unknown"),
+				WithDefaultsMode(cybr.DefaultsModeAuto, func(o *DefaultsModeOptions) {
+					o.DiscoveryClient = discoveryClient
+				}),
+			},
+			ExpectEnvironment: cybr.RuntimeEnvironment{
+				Region: "us-east-1",
+			},
+			ExpectConnectTimeout: 3100 * time.Millisecond,
+			ExpectTLSTimeout:     3100 * time.Millisecond,
+			ExpectRetryMode:      cybr.RetryModeStandard,
+			ExpectConfiguredMode: cybr.DefaultsModeAuto,
+		},
+		"auto ci": {
+			Env: map[string]string{
+				"CI": "true",
+			},
+			Options: []func(*LoadOptions) error{
+				WithDefaultsMode(cybr.DefaultsModeAuto),
+			},
+			ExpectEnvironment: cybr.RuntimeEnvironment{
+				EnvironmentIdentifier: cybr.ExecutionEnvironmentCI,
+			},
+			ExpectConnectTimeout: 3100 * time.Millisecond,
+			ExpectTLSTimeout:     3100 * time.Millisecond,
+			ExpectRetryMode:      cybr.RetryModeStandard,
+			ExpectConfiguredMode: cybr.DefaultsModeAuto,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			restoreEnv := clearEnv()
+			defer restoreEnv()
+			clearExecutionEnv(t)
+			for k, v := range c.Env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := LoadDefaultConfig(context.Background(), c.Options...)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := c.ExpectConfiguredMode, cfg.DefaultsMode; e != a {
+				t.Errorf("expect %v defaults mode, got %v", e, a)
+			}
+			if e, a := c.ExpectEnvironment, cfg.RuntimeEnvironment; e != a {
+				t.Errorf("expect %v runtime environment, got %v", e, a)
+			}
+			if e, a := c.ExpectRetryMode, cfg.RetryMode; e != a {
+				t.Errorf("expect %v retry mode, got %v", e, a)
+			}
+
+			client, ok := cfg.HTTPClient.(*cybrhttp.BuildableClient)
+			if !ok {
+				t.Fatalf("expect %T HTTP client, got %T", client, cfg.HTTPClient)
+			}
+			if e, a := c.ExpectConnectTimeout, client.GetDialer().Timeout; e != a {
+				t.Errorf("expect %v connect timeout, got %v", e, a)
+			}
+			if e, a := c.ExpectTLSTimeout, client.GetTransport().TLSHandshakeTimeout; e != a {
+				t.Errorf("expect %v TLS handshake timeout, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestLoadDefaultConfig_DefaultsModeInvalid(t *testing.T) {
+	restoreEnv := clearEnv()
+	defer restoreEnv()
+	os.Setenv("CYBR_DEFAULTS_MODE", "unknown")
+
+	if _, err := LoadDefaultConfig(context.Background()); err == nil {
+		t.Fatalf("expect error, got none")
+	}
+}
@@ -0,0 +1,102 @@
+// Package config provides utilities for loading configuration from multiple
+// sources that can be used to configure the SDK's API clients, and utilities.
+//
+// The config package will load configuration from environment variables, the
+// shared config and credentials files, and the functional options provided to
+// LoadDefaultConfig. Values provided as functional options take precedence
+// over values sourced from the environment, which take precedence over values
+// in the shared config and credentials files.
+//
+// Use the LoadDefaultConfig to load configuration from all the SDK's supported
+// sources, and resolve credentials using the SDK's default credential chain.
+//
+// LoadDefaultConfig allows for a variadic list of functional options that
+// modify the LoadOptions used to load the configuration. The LoadOptions
+// implement the same provider interfaces as the environment config source,
+// and will take precedence over it.
+//
+//	cfg, err := config.LoadDefaultConfig(context.TODO(),
+//		config.WithTenantName("example"),
+//	)
+//	if err != nil {
+//		log.Fatalf("failed to load configuration, %v", err)
+//	}
+//
+// # Environment Variables
+//
+// The following environment variables are read by the SDK when loading the
+// default configuration.
+//
+//	CYBR_TENANT_NAME=example
+//	CYBR_TENANT_ID=ABC1234
+//	CYBR_BEARER_TOKEN=eyJhbGciOi...
+//	CYBR_CLIENT_ID=service-user@example.com
+//	CYBR_CLIENT_SECRET=client-secret
+//	CYBR_OAUTH_APP_ID=my-oauth-app
+//	CYBR_MAX_ATTEMPTS=3
+//	CYBR_RETRY_MODE=standard
+//	CYBR_APP_ID=my-application
+//	CYBR_ENDPOINT_URL=https://example.dpa.cyberark.cloud
+//	CYBR_IDENTITY_URL=https://abc1234.id.cyberark.cloud
+//	CYBR_DEFAULTS_MODE=standard
+//	CYBR_CA_BUNDLE=/path/to/bundle.pem
+//	CYBR_PROFILE=dev
+//	CYBR_CONFIG_FILE=$HOME/.cybr/config
+//	CYBR_SHARED_CREDENTIALS_FILE=$HOME/.cybr/credentials
+//
+// # Shared Config and Credentials Files
+//
+// The shared config file, ~/.cybr/config, and shared credentials file,
+// ~/.cybr/credentials, use an INI format with a section per named profile.
+// Profiles other than "default" must be prefixed with "profile " in the
+// config file, and must not be prefixed in the credentials file. The profile
+// is selected with CYBR_PROFILE, or WithSharedConfigProfile, and defaults to
+// "default".
+//
+//	# ~/.cybr/config
+//	[default]
+//	tenant_name = example
+//
+//	[profile dev]
+//	tenant_name   = example-dev
+//	tenant_id     = ABC1234
+//	identity_url  = https://abc1234.id.cyberark.cloud
+//	retry_mode    = standard
+//	max_attempts  = 3
+//	defaults_mode = standard
+//	app_id        = my-application
+//	ca_bundle     = /path/to/bundle.pem
+//
+//	# ~/.cybr/credentials
+//	[dev]
+//	bearer_token = eyJhbGciOi...
+//
+//	[service]
+//	client_id     = service-user@example.com
+//	client_secret = client-secret
+//
+// # Credentials
+//
+// Credentials are resolved from the environment, then the shared config
+// profile. For each source a bearer token is used as is, otherwise a service
+// user's client ID and secret are exchanged with the Identity tenant, at
+// identity_url, for bearer tokens using the oauthcreds provider.
+//
+// # Defaults Mode
+//
+// The defaults mode, set with WithDefaultsMode, CYBR_DEFAULTS_MODE, or
+// defaults_mode, selects the connect and TLS handshake timeouts applied to a
+// BuildableClient HTTP client, and the retry mode used when one is not
+// configured. The "auto" mode detects the execution environment, and compares
+// the region it runs in with the tenant's region found by platform discovery,
+// to select the "in-region", "cross-region", or "standard" mode. The "legacy"
+// mode, the default, leaves the configuration unmodified.
+//
+// # Custom CA Bundle
+//
+// The PEM encoded certificates of a custom CA bundle, set with
+// WithCustomCABundle, CYBR_CA_BUNDLE, or ca_bundle, are trusted by the HTTP
+// client in addition to the system's root certificates. The HTTP client must
+// be a BuildableClient for the CA bundle to be added. Use the BuildableClient
+// TLS options directly for client certificates, or public key pinning.
+package config
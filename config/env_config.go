@@ -0,0 +1,341 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+// CredentialsSourceName provides a name of the provider when config is
+// loaded from environment.
+const CredentialsSourceName = "EnvConfigCredentials"
+
+// Environment variables that will be read for configuration values.
+const (
+	cybrTenantNameEnvVar  = "CYBR_TENANT_NAME"
+	cybrTenantIDEnvVar    = "CYBR_TENANT_ID"
+	cybrIdentityURLEnvVar = "CYBR_IDENTITY_URL"
+
+	cybrProfileEnvVar               = "CYBR_PROFILE"
+	cybrConfigFileEnvVar            = "CYBR_CONFIG_FILE"
+	cybrSharedCredentialsFileEnvVar = "CYBR_SHARED_CREDENTIALS_FILE"
+
+	cybrBearerTokenEnvVar = "CYBR_BEARER_TOKEN"
+
+	cybrClientIDEnvVar     = "CYBR_CLIENT_ID"
+	cybrClientSecretEnvVar = "CYBR_CLIENT_SECRET"
+	cybrOAuthAppIDEnvVar   = "CYBR_OAUTH_APP_ID"
+
+	cybrRetryMaxAttempts = "CYBR_MAX_ATTEMPTS"
+	cybrRetryMode        = "CYBR_RETRY_MODE"
+
+	cybrDefaultsModeEnvVar = "CYBR_DEFAULTS_MODE"
+
+	cybrAppIDEnvVar = "CYBR_APP_ID"
+
+	cybrEndpointURLEnvVar = "CYBR_ENDPOINT_URL"
+
+	cybrCustomCABundleEnvVar = "CYBR_CA_BUNDLE"
+)
+
+// EnvConfig is a collection of environment values the SDK will read
+// setup config from. All environment values are optional. But some values
+// such as credentials require multiple values to be complete or the values
+// will be ignored.
+type EnvConfig struct {
+	// Environment configuration values. If set the tenant name will be used
+	// for API clients.
+	//
+	//	CYBR_TENANT_NAME=example
+	TenantName string
+
+	// Environment configuration values. If set the Identity tenant ID will be
+	// used for API clients.
+	//
+	//	CYBR_TENANT_ID=ABC1234
+	TenantID string
+
+	// Environment configuration values. If set the Identity tenant URL will
+	// be used to authenticate against the tenant.
+	//
+	//	CYBR_IDENTITY_URL=https://abc1234.id.cyberark.cloud
+	IdentityURL string
+
+	// Profile name the SDK should load use when loading shared configuration from the
+	// shared configuration files. If not provided "default" will be used as the
+	// profile name.
+	//
+	//	CYBR_PROFILE=my_profile
+	SharedConfigProfile string
+
+	// Shared config file location to load the shared configuration values from.
+	// If not provided the SDK will default to ~/.cybr/config.
+	//
+	//	CYBR_CONFIG_FILE=$HOME/my_shared_config
+	SharedConfigFile string
+
+	// Shared credentials file location to load the shared credentials from.
+	// If not provided the SDK will default to ~/.cybr/credentials.
+	//
+	//	CYBR_SHARED_CREDENTIALS_FILE=$HOME/my_shared_credentials
+	SharedCredentialsFile string
+
+	// Environment configuration values. If set the bearer token will be used
+	// as static credentials for API clients.
+	//
+	//	CYBR_BEARER_TOKEN=eyJhbGciOi...
+	Credentials cybr.Credentials
+
+	// Environment configuration values. If set, and a bearer token is not,
+	// the service user's client ID and secret will be exchanged with the
+	// Identity tenant for bearer tokens. Requires the Identity tenant URL.
+	//
+	//	CYBR_CLIENT_ID=service-user@example.com
+	//	CYBR_CLIENT_SECRET=client-secret
+	ClientID     string
+	ClientSecret string
+
+	// Environment configuration value. If set the client credentials will be
+	// exchanged with the custom OAuth application's token endpoint instead
+	// of the platform token endpoint.
+	//
+	//	CYBR_OAUTH_APP_ID=my-oauth-app
+	OAuthAppID string
+
+	// Specifies the maximum number attempts an API client will call an
+	// operation that fails with a retryable error.
+	//
+	//	CYBR_MAX_ATTEMPTS=3
+	RetryMaxAttempts int
+
+	// Specifies the retry model the API client will be created with.
+	//
+	//	CYBR_RETRY_MODE=standard
+	RetryMode cybr.RetryMode
+
+	// Sets the default behavior of the SDK.
+	//
+	//	CYBR_DEFAULTS_MODE=standard
+	DefaultsMode cybr.DefaultsMode
+
+	// Application identifier appended to the User-Agent header.
+	//
+	//	CYBR_APP_ID=my-application
+	AppID string
+
+	// Value to contain the base endpoint override API clients will use.
+	//
+	//	CYBR_ENDPOINT_URL=https://example.dpa.cyberark.cloud
+	BaseEndpoint string
+
+	// Path to a custom CA bundle PEM file the SDK will use to verify the
+	// TLS connections to the service.
+	//
+	//	CYBR_CA_BUNDLE=/path/to/bundle.pem
+	CustomCABundle string
+}
+
+// loadEnvConfig reads configuration values from the OS's environment variables.
+// Returning the a Config typed EnvConfig to satisfy the ConfigLoader func type.
+func loadEnvConfig(ctx context.Context, cfgs configs) (Config, error) {
+	return NewEnvConfig()
+}
+
+// NewEnvConfig retrieves the SDK's environment configuration.
+// See `EnvConfig` for the values that will be retrieved.
+func NewEnvConfig() (EnvConfig, error) {
+	var cfg EnvConfig
+
+	setStringFromEnvVal(&cfg.TenantName, []string{cybrTenantNameEnvVar})
+	setStringFromEnvVal(&cfg.TenantID, []string{cybrTenantIDEnvVar})
+	setStringFromEnvVal(&cfg.IdentityURL, []string{cybrIdentityURLEnvVar})
+
+	setStringFromEnvVal(&cfg.SharedConfigProfile, []string{cybrProfileEnvVar})
+	setStringFromEnvVal(&cfg.SharedConfigFile, []string{cybrConfigFileEnvVar})
+	setStringFromEnvVal(&cfg.SharedCredentialsFile, []string{cybrSharedCredentialsFileEnvVar})
+
+	setStringFromEnvVal(&cfg.Credentials.BearerToken, []string{cybrBearerTokenEnvVar})
+	if cfg.Credentials.HasKeys() {
+		cfg.Credentials.Source = CredentialsSourceName
+	}
+
+	setStringFromEnvVal(&cfg.ClientID, []string{cybrClientIDEnvVar})
+	setStringFromEnvVal(&cfg.ClientSecret, []string{cybrClientSecretEnvVar})
+	setStringFromEnvVal(&cfg.OAuthAppID, []string{cybrOAuthAppIDEnvVar})
+
+	if err := setIntFromEnvVal(&cfg.RetryMaxAttempts, []string{cybrRetryMaxAttempts}); err != nil {
+		return cfg, err
+	}
+	if err := setRetryModeFromEnvVal(&cfg.RetryMode, []string{cybrRetryMode}); err != nil {
+		return cfg, err
+	}
+
+	if err := setDefaultsModeFromEnvVal(&cfg.DefaultsMode, []string{cybrDefaultsModeEnvVar}); err != nil {
+		return cfg, err
+	}
+
+	setStringFromEnvVal(&cfg.AppID, []string{cybrAppIDEnvVar})
+	setStringFromEnvVal(&cfg.BaseEndpoint, []string{cybrEndpointURLEnvVar})
+
+	setStringFromEnvVal(&cfg.CustomCABundle, []string{cybrCustomCABundleEnvVar})
+
+	return cfg, nil
+}
+
+// getTenantName returns the tenant name from the environment.
+func (c EnvConfig) getTenantName(ctx context.Context) (string, bool, error) {
+	if len(c.TenantName) == 0 {
+		return "", false, nil
+	}
+	return c.TenantName, true, nil
+}
+
+// getTenantID returns the Identity tenant ID from the environment.
+func (c EnvConfig) getTenantID(ctx context.Context) (string, bool, error) {
+	if len(c.TenantID) == 0 {
+		return "", false, nil
+	}
+	return c.TenantID, true, nil
+}
+
+// getIdentityURL returns the Identity tenant URL from the environment.
+func (c EnvConfig) getIdentityURL(ctx context.Context) (string, bool, error) {
+	return c.IdentityURL, len(c.IdentityURL) > 0, nil
+}
+
+// getSharedConfigProfile returns the shared config profile if set.
+func (c EnvConfig) getSharedConfigProfile(ctx context.Context) (string, bool, error) {
+	if len(c.SharedConfigProfile) == 0 {
+		return "", false, nil
+	}
+
+	return c.SharedConfigProfile, true, nil
+}
+
+// getSharedConfigFiles returns a slice of filenames set in the environment.
+//
+// Will return the filenames in the order of:
+// * Shared Config
+func (c EnvConfig) getSharedConfigFiles(context.Context) ([]string, bool, error) {
+	var files []string
+	if v := c.SharedConfigFile; len(v) > 0 {
+		files = append(files, v)
+	}
+
+	if len(files) == 0 {
+		return nil, false, nil
+	}
+	return files, true, nil
+}
+
+// getSharedCredentialsFiles returns a slice of filenames set in the environment.
+//
+// Will return the filenames in the order of:
+// * Shared Credentials
+func (c EnvConfig) getSharedCredentialsFiles(context.Context) ([]string, bool, error) {
+	var files []string
+	if v := c.SharedCredentialsFile; len(v) > 0 {
+		files = append(files, v)
+	}
+	if len(files) == 0 {
+		return nil, false, nil
+	}
+	return files, true, nil
+}
+
+// getDefaultsMode returns the defaults mode from the environment.
+func (c EnvConfig) getDefaultsMode(ctx context.Context) (cybr.DefaultsMode, bool, error) {
+	if len(c.DefaultsMode) == 0 {
+		return "", false, nil
+	}
+	return c.DefaultsMode, true, nil
+}
+
+// getRetryMaxAttempts returns the maximum number of attempts from the
+// environment.
+func (c EnvConfig) getRetryMaxAttempts(ctx context.Context) (int, bool, error) {
+	return c.RetryMaxAttempts, c.RetryMaxAttempts > 0, nil
+}
+
+// getRetryMode returns the retry mode from the environment.
+func (c EnvConfig) getRetryMode(ctx context.Context) (cybr.RetryMode, bool, error) {
+	return c.RetryMode, len(c.RetryMode) > 0, nil
+}
+
+// getAppID returns the application identifier from the environment.
+func (c EnvConfig) getAppID(ctx context.Context) (string, bool, error) {
+	return c.AppID, len(c.AppID) > 0, nil
+}
+
+// getBaseEndpoint returns the base endpoint override from the environment.
+func (c EnvConfig) getBaseEndpoint(ctx context.Context) (string, bool, error) {
+	return c.BaseEndpoint, len(c.BaseEndpoint) > 0, nil
+}
+
+// getCustomCABundle returns the custom CA bundle's PEM bytes if the file
+// was specified in the environment.
+func (c EnvConfig) getCustomCABundle(ctx context.Context) (io.Reader, bool, error) {
+	if len(c.CustomCABundle) == 0 {
+		return nil, false, nil
+	}
+
+	b, err := os.ReadFile(c.CustomCABundle)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s custom CA bundle, %w", cybrCustomCABundleEnvVar, err)
+	}
+	return bytes.NewReader(b), true, nil
+}
+
+func setStringFromEnvVal(dst *string, keys []string) {
+	for _, k := range keys {
+		if v := os.Getenv(k); len(v) > 0 {
+			*dst = v
+			break
+		}
+	}
+}
+
+func setIntFromEnvVal(dst *int, keys []string) error {
+	for _, k := range keys {
+		if v := os.Getenv(k); len(v) > 0 {
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for env var, %s=%s, need int", k, v)
+			}
+			*dst = int(i)
+			break
+		}
+	}
+
+	return nil
+}
+
+func setRetryModeFromEnvVal(dst *cybr.RetryMode, keys []string) (err error) {
+	for _, k := range keys {
+		if v := os.Getenv(k); len(v) > 0 {
+			*dst, err = cybr.ParseRetryMode(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for env var, %s=%s, %w", k, v, err)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func setDefaultsModeFromEnvVal(dst *cybr.DefaultsMode, keys []string) error {
+	for _, k := range keys {
+		if value := os.Getenv(k); len(value) > 0 {
+			if ok := dst.SetFromString(value); !ok {
+				return fmt.Errorf("invalid %s value: %s", k, value)
+			}
+			break
+		}
+	}
+	return nil
+}
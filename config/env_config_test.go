@@ -0,0 +1,175 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+// clearEnv unsets all of the CYBR_ prefixed environment variables, returning
+// a function that restores the original environment. The default shared
+// config files are replaced with files that do not exist.
+func clearEnv() func() {
+	orig := os.Environ()
+	for _, kv := range orig {
+		if k, _, _ := strings.Cut(kv, "="); strings.HasPrefix(k, "CYBR_") {
+			os.Unsetenv(k)
+		}
+	}
+
+	origConfigFiles, origCredentialsFiles := DefaultSharedConfigFiles, DefaultSharedCredentialsFiles
+	DefaultSharedConfigFiles = []string{filepath.Join("testdata", "not_exist_config")}
+	DefaultSharedCredentialsFiles = []string{filepath.Join("testdata", "not_exist_credentials")}
+
+	return func() {
+		DefaultSharedConfigFiles, DefaultSharedCredentialsFiles = origConfigFiles, origCredentialsFiles
+
+		for _, kv := range os.Environ() {
+			if k, _, _ := strings.Cut(kv, "="); strings.HasPrefix(k, "CYBR_") {
+				os.Unsetenv(k)
+			}
+		}
+		for _, kv := range orig {
+			if k, v, _ := strings.Cut(kv, "="); strings.HasPrefix(k, "CYBR_") {
+				os.Setenv(k, v)
+			}
+		}
+	}
+}
+
+func TestNewEnvConfig(t *testing.T) {
+	cases := []struct {
+		Env     map[string]string
+		Config  EnvConfig
+		WantErr bool
+	}{
+		0: {
+			Env:    map[string]string{},
+			Config: EnvConfig{},
+		},
+		1: {
+			Env: map[string]string{
+				"CYBR_TENANT_NAME": "example",
+				"CYBR_TENANT_ID":   "ABC1234",
+			},
+			Config: EnvConfig{
+				TenantName: "example",
+				TenantID:   "ABC1234",
+			},
+		},
+		2: {
+			Env: map[string]string{
+				"CYBR_BEARER_TOKEN": "token",
+			},
+			Config: EnvConfig{
+				Credentials: cybr.Credentials{
+					BearerToken: "token",
+					Source:      CredentialsSourceName,
+				},
+			},
+		},
+		3: {
+			Env: map[string]string{
+				"CYBR_MAX_ATTEMPTS": "5",
+				"CYBR_RETRY_MODE":   "adaptive",
+			},
+			Config: EnvConfig{
+				RetryMaxAttempts: 5,
+				RetryMode:        cybr.RetryModeAdaptive,
+			},
+		},
+		4: {
+			Env: map[string]string{
+				"CYBR_MAX_ATTEMPTS": "invalid",
+			},
+			WantErr: true,
+		},
+		5: {
+			Env: map[string]string{
+				"CYBR_RETRY_MODE": "invalid",
+			},
+			WantErr: true,
+		},
+		6: {
+			Env: map[string]string{
+				"CYBR_PROFILE":                 "dev",
+				"CYBR_CONFIG_FILE":             "/path/to/config",
+				"CYBR_SHARED_CREDENTIALS_FILE": "/path/to/credentials",
+				"CYBR_IDENTITY_URL":            "https://abc1234.id.cyberark.cloud",
+				"CYBR_DEFAULTS_MODE":           "standard",
+			},
+			Config: EnvConfig{
+				SharedConfigProfile:   "dev",
+				SharedConfigFile:      "/path/to/config",
+				SharedCredentialsFile: "/path/to/credentials",
+				IdentityURL:           "https://abc1234.id.cyberark.cloud",
+				DefaultsMode:          cybr.DefaultsModeStandard,
+			},
+		},
+		7: {
+			Env: map[string]string{
+				"CYBR_DEFAULTS_MODE": "invalid",
+			},
+			WantErr: true,
+		},
+		8: {
+			Env: map[string]string{
+				"CYBR_APP_ID":       "my-application",
+				"CYBR_ENDPOINT_URL": "https://example.dpa.cyberark.cloud",
+			},
+			Config: EnvConfig{
+				AppID:        "my-application",
+				BaseEndpoint: "https://example.dpa.cyberark.cloud",
+			},
+		},
+		9: {
+			Env: map[string]string{
+				"CYBR_CLIENT_ID":     "service-user@example.com",
+				"CYBR_CLIENT_SECRET": "client-secret",
+				"CYBR_OAUTH_APP_ID":  "my-oauth-app",
+			},
+			Config: EnvConfig{
+				ClientID:     "service-user@example.com",
+				ClientSecret: "client-secret",
+				OAuthAppID:   "my-oauth-app",
+			},
+		},
+		10: {
+			Env: map[string]string{
+				"CYBR_CA_BUNDLE": "/path/to/bundle.pem",
+			},
+			Config: EnvConfig{
+				CustomCABundle: "/path/to/bundle.pem",
+			},
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			restoreEnv := clearEnv()
+			defer restoreEnv()
+
+			for k, v := range c.Env {
+				os.Setenv(k, v)
+			}
+
+			cfg, err := NewEnvConfig()
+			if (err != nil) != c.WantErr {
+				t.Fatalf("WantErr=%v, got err=%v", c.WantErr, err)
+			}
+			if c.WantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(c.Config, cfg) {
+				t.Errorf("expect config to match.\n%s",
+					cmpDiff(c.Config, cfg))
+			}
+		})
+	}
+}
@@ -0,0 +1,529 @@
+package config
+
+import (
+	"context"
+	"io"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/discovery"
+)
+
+// LoadOptionsFunc is a type alias for LoadOptions functional option
+type LoadOptionsFunc func(*LoadOptions) error
+
+// LoadOptions are discrete set of options that are valid for loading the
+// configuration
+type LoadOptions struct {
+
+	// TenantName is the tenant to send requests to.
+	TenantName string
+
+	// TenantID is the Identity tenant ID of the tenant requests are sent to.
+	TenantID string
+
+	// Credentials object to use when signing requests.
+	Credentials cybr.CredentialsProvider
+
+	// CredentialsCacheOptions is a function for setting the
+	// cybr.CredentialsCacheOptions
+	CredentialsCacheOptions func(*cybr.CredentialsCacheOptions)
+
+	// HTTPClient the SDK's API clients will use to invoke HTTP requests.
+	HTTPClient cybr.HTTPClient
+
+	// Specifies the maximum number attempts an API client will call an
+	// operation that fails with a retryable error.
+	//
+	// This value will only be used if Retryer option is nil.
+	RetryMaxAttempts int
+
+	// Specifies the retry model the API client will be created with.
+	//
+	// This value will only be used if Retryer option is nil.
+	RetryMode cybr.RetryMode
+
+	// Retryer is a function that provides a Retryer implementation. A Retryer
+	// guides how HTTP requests should be retried in case of recoverable
+	// failures.
+	//
+	// If not nil, RetryMaxAttempts, and RetryMode will be ignored.
+	Retryer func() cybr.Retryer
+
+	// APIOptions provides the set of middleware mutations modify how the API
+	// client requests will be handled. This is useful for adding additional
+	// tracing data to a request, or changing behavior of the SDK's client.
+	APIOptions []func(*middleware.Stack) error
+
+	// Logger writer interface to write logging messages to.
+	Logger logging.Logger
+
+	// ClientLogMode is used to configure the events that will be sent to the
+	// configured logger. This can be used to configure the logging of signing,
+	// retries, request, and responses of the SDK clients.
+	//
+	// See the ClientLogMode type documentation for the complete set of logging
+	// modes and available configuration.
+	ClientLogMode *cybr.ClientLogMode
+
+	// AppID is the user-defined application identifier appended to the
+	// User-Agent header of every request.
+	AppID string
+
+	// BaseEndpoint is the base endpoint override API clients will use.
+	BaseEndpoint string
+
+	// EndpointResolverWithOptions that can be used to provide or override an
+	// endpoint for the given service and the service client's endpoint
+	// options.
+	EndpointResolverWithOptions cybr.EndpointResolverWithOptions
+
+	// CustomCABundle is the CA bundle PEM bytes reader the HTTP client will
+	// trust in addition to the system's root certificates.
+	CustomCABundle io.Reader
+
+	// SharedConfigProfile is the profile to be used when loading the SharedConfig
+	SharedConfigProfile string
+
+	// SharedConfigFiles is the slice of custom shared config files to use when
+	// loading the SharedConfig. A non-default profile used within config file
+	// must have name defined with prefix 'profile '. eg [profile xyz]
+	// indicates a profile with name 'xyz'. To read more on the format of the
+	// config file, see the package documentation.
+	//
+	// If duplicate profiles are provided within the same, or across multiple
+	// shared config files, the next parsed profile will override only the
+	// properties that conflict with the previously defined profile. Note that
+	// if duplicate profiles are provided within the SharedCredentialsFiles and
+	// SharedConfigFiles, the properties defined in shared credentials file
+	// take precedence.
+	SharedConfigFiles []string
+
+	// SharedCredentialsFile is the shared credentials file to be used when
+	// loading the SharedConfig. The profile name used within credentials file
+	// must not prefix 'profile '. eg [xyz] indicates a profile with name 'xyz'.
+	// Profile declared as [profile xyz] will be ignored.
+	//
+	// If duplicate profiles are provided with a same, or across multiple
+	// shared credentials files, the next parsed profile will override only
+	// properties that conflict with the previously defined profile. Note that
+	// if duplicate profiles are provided within the SharedCredentialsFiles and
+	// SharedConfigFiles, the properties defined in shared credentials file
+	// take precedence.
+	SharedCredentialsFiles []string
+
+	// DefaultsModeOptions is the set of options that are used to configure
+	// the SDK's defaults mode.
+	DefaultsModeOptions DefaultsModeOptions
+}
+
+// getTenantName returns TenantName from config's LoadOptions
+func (o LoadOptions) getTenantName(ctx context.Context) (string, bool, error) {
+	if len(o.TenantName) == 0 {
+		return "", false, nil
+	}
+
+	return o.TenantName, true, nil
+}
+
+// WithTenantName is a helper function to construct functional options
+// that sets TenantName on config's LoadOptions. Setting the tenant name to
+// an empty string, will result in the tenant name value being ignored.
+// If multiple WithTenantName calls are made, the last call overrides
+// the previous call values.
+func WithTenantName(v string) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.TenantName = v
+		return nil
+	}
+}
+
+// getTenantID returns TenantID from config's LoadOptions
+func (o LoadOptions) getTenantID(ctx context.Context) (string, bool, error) {
+	if len(o.TenantID) == 0 {
+		return "", false, nil
+	}
+
+	return o.TenantID, true, nil
+}
+
+// WithTenantID is a helper function to construct functional options
+// that sets TenantID on config's LoadOptions. Setting the tenant ID to
+// an empty string, will result in the tenant ID value being ignored.
+// If multiple WithTenantID calls are made, the last call overrides
+// the previous call values.
+func WithTenantID(v string) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.TenantID = v
+		return nil
+	}
+}
+
+// getCredentialsProvider returns the credentials value
+func (o LoadOptions) getCredentialsProvider(ctx context.Context) (cybr.CredentialsProvider, bool, error) {
+	if o.Credentials == nil {
+		return nil, false, nil
+	}
+
+	return o.Credentials, true, nil
+}
+
+// WithCredentialsProvider is a helper function to construct functional options
+// that sets Credential provider value on config's LoadOptions. If credentials
+// provider is set to nil, the credentials provider value will be ignored.
+// If multiple WithCredentialsProvider calls are made, the last call overrides
+// the previous call values.
+func WithCredentialsProvider(v cybr.CredentialsProvider) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.Credentials = v
+		return nil
+	}
+}
+
+// getCredentialsCacheOptionsProvider returns the wrapped function to set cybr.CredentialsCacheOptions
+func (o LoadOptions) getCredentialsCacheOptions(ctx context.Context) (func(*cybr.CredentialsCacheOptions), bool, error) {
+	if o.CredentialsCacheOptions == nil {
+		return nil, false, nil
+	}
+
+	return o.CredentialsCacheOptions, true, nil
+}
+
+// WithCredentialsCacheOptions is a helper function to construct functional
+// options that sets a function to modify the cybr.CredentialsCacheOptions the
+// cybr.CredentialsCache will be configured with, if the CredentialsCache is used
+// by the configuration loader.
+//
+// If multiple WithCredentialsCacheOptions calls are made, the last call
+// overrides the previous call values.
+func WithCredentialsCacheOptions(v func(*cybr.CredentialsCacheOptions)) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.CredentialsCacheOptions = v
+		return nil
+	}
+}
+
+// getHTTPClient returns HTTPClient from config's LoadOptions
+func (o LoadOptions) getHTTPClient(ctx context.Context) (cybr.HTTPClient, bool, error) {
+	if o.HTTPClient == nil {
+		return nil, false, nil
+	}
+
+	return o.HTTPClient, true, nil
+}
+
+// WithHTTPClient is a helper function to construct functional options
+// that sets HTTPClient on LoadOptions. If HTTPClient is set to nil,
+// the HTTPClient value will be ignored.
+// If multiple WithHTTPClient calls are made, the last call overrides
+// the previous call values.
+func WithHTTPClient(v cybr.HTTPClient) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.HTTPClient = v
+		return nil
+	}
+}
+
+// getCustomCABundle returns CustomCABundle from config's LoadOptions
+func (o LoadOptions) getCustomCABundle(ctx context.Context) (io.Reader, bool, error) {
+	if o.CustomCABundle == nil {
+		return nil, false, nil
+	}
+
+	return o.CustomCABundle, true, nil
+}
+
+// WithCustomCABundle is a helper function to construct functional options
+// that sets CustomCABundle on config's LoadOptions. Setting the custom CA
+// bundle to nil will result in the custom CA bundle value being ignored.
+// If multiple WithCustomCABundle calls are made, the last call overrides the
+// previous call values.
+func WithCustomCABundle(v io.Reader) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.CustomCABundle = v
+		return nil
+	}
+}
+
+// getRetryMaxAttempts returns RetryMaxAttempts from config's LoadOptions
+func (o LoadOptions) getRetryMaxAttempts(ctx context.Context) (int, bool, error) {
+	if o.RetryMaxAttempts == 0 {
+		return 0, false, nil
+	}
+
+	return o.RetryMaxAttempts, true, nil
+}
+
+// WithRetryMaxAttempts is a helper function to construct functional options that sets
+// RetryMaxAttempts on LoadOptions. If RetryMaxAttempts is unset, the RetryMaxAttempts value is
+// ignored. If multiple WithRetryMaxAttempts calls are made, the last call overrides
+// the previous call values.
+//
+// Will be ignored of LoadOptions.Retryer or WithRetryer are used.
+func WithRetryMaxAttempts(v int) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.RetryMaxAttempts = v
+		return nil
+	}
+}
+
+// getRetryMode returns RetryMode from config's LoadOptions
+func (o LoadOptions) getRetryMode(ctx context.Context) (cybr.RetryMode, bool, error) {
+	if o.RetryMode == "" {
+		return "", false, nil
+	}
+
+	return o.RetryMode, true, nil
+}
+
+// WithRetryMode is a helper function to construct functional options that sets
+// RetryMode on LoadOptions. If RetryMode is unset, the RetryMode value is
+// ignored. If multiple WithRetryMode calls are made, the last call overrides
+// the previous call values.
+//
+// Will be ignored of LoadOptions.Retryer or WithRetryer are used.
+func WithRetryMode(v cybr.RetryMode) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.RetryMode = v
+		return nil
+	}
+}
+
+// getRetryer returns Retryer from config's LoadOptions
+func (o LoadOptions) getRetryer(ctx context.Context) (func() cybr.Retryer, bool, error) {
+	if o.Retryer == nil {
+		return nil, false, nil
+	}
+
+	return o.Retryer, true, nil
+}
+
+// WithRetryer is a helper function to construct functional options
+// that sets Retryer on LoadOptions. If Retryer is set to nil, the
+// Retryer value is ignored. If multiple WithRetryer calls are
+// made, the last call overrides the previous call values.
+func WithRetryer(v func() cybr.Retryer) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.Retryer = v
+		return nil
+	}
+}
+
+// getEndpointResolverWithOptions returns EndpointResolverWithOptions from
+// config's LoadOptions
+func (o LoadOptions) getEndpointResolverWithOptions(ctx context.Context) (cybr.EndpointResolverWithOptions, bool, error) {
+	if o.EndpointResolverWithOptions == nil {
+		return nil, false, nil
+	}
+
+	return o.EndpointResolverWithOptions, true, nil
+}
+
+// WithEndpointResolverWithOptions is a helper function to construct
+// functional options that sets the EndpointResolverWithOptions on
+// LoadOptions. If the EndpointResolverWithOptions is set to nil, the
+// EndpointResolverWithOptions value is ignored. If multiple
+// WithEndpointResolverWithOptions calls are made, the last call overrides
+// the previous call values.
+func WithEndpointResolverWithOptions(v cybr.EndpointResolverWithOptions) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.EndpointResolverWithOptions = v
+		return nil
+	}
+}
+
+// getAPIOptions returns APIOptions from config's LoadOptions
+func (o LoadOptions) getAPIOptions(ctx context.Context) ([]func(*middleware.Stack) error, bool, error) {
+	if o.APIOptions == nil {
+		return nil, false, nil
+	}
+
+	return o.APIOptions, true, nil
+}
+
+// WithAPIOptions is a helper function to construct functional options
+// that sets APIOptions on LoadOptions. If APIOptions is set to nil, the
+// APIOptions value is ignored. If multiple WithAPIOptions calls are
+// made, the last call overrides the previous call values.
+func WithAPIOptions(v []func(*middleware.Stack) error) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		if v == nil {
+			return nil
+		}
+
+		o.APIOptions = append(o.APIOptions, v...)
+		return nil
+	}
+}
+
+// getLogger returns Logger from config's LoadOptions
+func (o LoadOptions) getLogger(ctx context.Context) (logging.Logger, bool, error) {
+	if o.Logger == nil {
+		return nil, false, nil
+	}
+
+	return o.Logger, true, nil
+}
+
+// WithLogger is a helper function to construct functional options
+// that sets Logger on LoadOptions. If Logger is set to nil, the
+// Logger value will be ignored. If multiple WithLogger calls are made,
+// the last call overrides the previous call values.
+func WithLogger(v logging.Logger) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.Logger = v
+		return nil
+	}
+}
+
+// getClientLogMode returns ClientLogMode from config's LoadOptions
+func (o LoadOptions) getClientLogMode(ctx context.Context) (cybr.ClientLogMode, bool, error) {
+	if o.ClientLogMode == nil {
+		return 0, false, nil
+	}
+
+	return *o.ClientLogMode, true, nil
+}
+
+// WithClientLogMode is a helper function to construct functional options
+// that sets client log mode on LoadOptions. If client log mode is set to nil,
+// the client log mode value will be ignored. If multiple WithClientLogMode
+// calls are made, the last call overrides the previous call values.
+func WithClientLogMode(v cybr.ClientLogMode) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.ClientLogMode = &v
+		return nil
+	}
+}
+
+// getAppID returns AppID from config's LoadOptions
+func (o LoadOptions) getAppID(ctx context.Context) (string, bool, error) {
+	return o.AppID, len(o.AppID) > 0, nil
+}
+
+// WithAppID is a helper function to construct functional options
+// that sets AppID on LoadOptions. If AppID is set to an empty string, the
+// AppID value will be ignored. If multiple WithAppID calls are made, the
+// last call overrides the previous call values.
+func WithAppID(v string) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.AppID = v
+		return nil
+	}
+}
+
+// getBaseEndpoint returns BaseEndpoint from config's LoadOptions
+func (o LoadOptions) getBaseEndpoint(ctx context.Context) (string, bool, error) {
+	return o.BaseEndpoint, len(o.BaseEndpoint) > 0, nil
+}
+
+// WithBaseEndpoint is a helper function to construct functional options that
+// sets BaseEndpoint on config's LoadOptions. Empty values have no effect, and
+// subsequent calls to this API override previous ones.
+//
+// This is an in-code setting, therefore, any value set using this hook takes
+// precedence over and will override ALL environment and shared config
+// directives that set endpoint URLs.
+func WithBaseEndpoint(v string) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.BaseEndpoint = v
+		return nil
+	}
+}
+
+// getSharedConfigProfile returns SharedConfigProfile from config's LoadOptions
+func (o LoadOptions) getSharedConfigProfile(ctx context.Context) (string, bool, error) {
+	return o.SharedConfigProfile, len(o.SharedConfigProfile) != 0, nil
+}
+
+// WithSharedConfigProfile is a helper function to construct functional options
+// that sets SharedConfigProfile on config's LoadOptions. Setting the shared
+// config profile to an empty string, will result in the shared config profile
+// value being ignored.
+// If multiple WithSharedConfigProfile calls are made, the last call overrides
+// the previous call values.
+func WithSharedConfigProfile(v string) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.SharedConfigProfile = v
+		return nil
+	}
+}
+
+// getSharedConfigFiles returns SharedConfigFiles set on config's LoadOptions
+func (o LoadOptions) getSharedConfigFiles(ctx context.Context) ([]string, bool, error) {
+	if o.SharedConfigFiles == nil {
+		return nil, false, nil
+	}
+
+	return o.SharedConfigFiles, true, nil
+}
+
+// WithSharedConfigFiles is a helper function to construct functional options
+// that sets slice of SharedConfigFiles on config's LoadOptions.
+// Setting the shared config files to an nil string slice, will result in the
+// shared config files value being ignored.
+// If multiple WithSharedConfigFiles calls are made, the last call overrides
+// the previous call values.
+func WithSharedConfigFiles(v []string) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.SharedConfigFiles = v
+		return nil
+	}
+}
+
+// getSharedCredentialsFiles returns SharedCredentialsFiles set on config's LoadOptions
+func (o LoadOptions) getSharedCredentialsFiles(ctx context.Context) ([]string, bool, error) {
+	if o.SharedCredentialsFiles == nil {
+		return nil, false, nil
+	}
+
+	return o.SharedCredentialsFiles, true, nil
+}
+
+// WithSharedCredentialsFiles is a helper function to construct functional options
+// that sets slice of SharedCredentialsFiles on config's LoadOptions.
+// Setting the shared credentials files to an nil string slice, will result in the
+// shared credentials files value being ignored.
+// If multiple WithSharedCredentialsFiles calls are made, the last call overrides
+// the previous call values.
+func WithSharedCredentialsFiles(v []string) LoadOptionsFunc {
+	return func(o *LoadOptions) error {
+		o.SharedCredentialsFiles = v
+		return nil
+	}
+}
+
+// getDefaultsMode returns the DefaultsMode from config's LoadOptions
+func (o LoadOptions) getDefaultsMode(ctx context.Context) (cybr.DefaultsMode, bool, error) {
+	if len(o.DefaultsModeOptions.Mode) == 0 {
+		return "", false, nil
+	}
+	return o.DefaultsModeOptions.Mode, true, nil
+}
+
+// getDefaultsModeDiscoveryClient returns the platform discovery client from
+// config's LoadOptions DefaultsModeOptions
+func (o LoadOptions) getDefaultsModeDiscoveryClient(ctx context.Context) (*discovery.Client, bool, error) {
+	if o.DefaultsModeOptions.DiscoveryClient == nil {
+		return nil, false, nil
+	}
+	return o.DefaultsModeOptions.DiscoveryClient, true, nil
+}
+
+// WithDefaultsMode sets the SDK defaults configuration mode to the value provided.
+//
+// Zero or more functional options can be provided to provide configuration options for performing
+// environment discovery when using cybr.DefaultsModeAuto.
+func WithDefaultsMode(mode cybr.DefaultsMode, optFns ...func(options *DefaultsModeOptions)) LoadOptionsFunc {
+	do := DefaultsModeOptions{
+		Mode: mode,
+	}
+	for _, fn := range optFns {
+		fn(&do)
+	}
+	return func(options *LoadOptions) error {
+		options.DefaultsModeOptions = do
+		return nil
+	}
+}
@@ -0,0 +1,446 @@
+package config
+
+import (
+	"context"
+	"io"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/discovery"
+)
+
+// tenantNameProvider provides access to the tenant name external configuration
+type tenantNameProvider interface {
+	getTenantName(ctx context.Context) (string, bool, error)
+}
+
+// getTenantName searches the configs slice for a tenantNameProvider and
+// returns the value if found. Returns an error if a provider fails before a
+// value is found.
+func getTenantName(ctx context.Context, configs configs) (value string, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(tenantNameProvider); ok {
+			value, found, err = p.getTenantName(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// tenantIDProvider provides access to the Identity tenant ID external
+// configuration
+type tenantIDProvider interface {
+	getTenantID(ctx context.Context) (string, bool, error)
+}
+
+// getTenantID searches the configs slice for a tenantIDProvider and returns
+// the value if found. Returns an error if a provider fails before a value is
+// found.
+func getTenantID(ctx context.Context, configs configs) (value string, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(tenantIDProvider); ok {
+			value, found, err = p.getTenantID(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// credentialsProviderProvider provides access to the credentials external
+// configuration value.
+type credentialsProviderProvider interface {
+	getCredentialsProvider(ctx context.Context) (cybr.CredentialsProvider, bool, error)
+}
+
+// getCredentialsProvider searches the configs for a credentialsProviderProvider
+// and returns the value if found. Returns an error if a provider fails before a
+// value is found.
+func getCredentialsProvider(ctx context.Context, configs configs) (p cybr.CredentialsProvider, found bool, err error) {
+	for _, cfg := range configs {
+		if provider, ok := cfg.(credentialsProviderProvider); ok {
+			p, found, err = provider.getCredentialsProvider(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// credentialsCacheOptionsProvider is an interface for retrieving a function for setting
+// the cybr.CredentialsCacheOptions.
+type credentialsCacheOptionsProvider interface {
+	getCredentialsCacheOptions(ctx context.Context) (func(*cybr.CredentialsCacheOptions), bool, error)
+}
+
+// getCredentialsCacheOptionsProvider is an interface for retrieving a function for setting
+// the cybr.CredentialsCacheOptions.
+func getCredentialsCacheOptionsProvider(ctx context.Context, configs configs) (
+	f func(*cybr.CredentialsCacheOptions), found bool, err error,
+) {
+	for _, config := range configs {
+		if p, ok := config.(credentialsCacheOptionsProvider); ok {
+			f, found, err = p.getCredentialsCacheOptions(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// httpClientProvider provides access to the HTTPClient external configuration
+// value.
+type httpClientProvider interface {
+	getHTTPClient(ctx context.Context) (cybr.HTTPClient, bool, error)
+}
+
+// getHTTPClient searches the configs slice for a httpClientProvider and
+// returns the value if found. Returns an error if a provider fails before a
+// value is found.
+func getHTTPClient(ctx context.Context, configs configs) (client cybr.HTTPClient, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(httpClientProvider); ok {
+			client, found, err = p.getHTTPClient(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// customCABundleProvider provides access to the custom CA bundle PEM bytes.
+type customCABundleProvider interface {
+	getCustomCABundle(ctx context.Context) (io.Reader, bool, error)
+}
+
+// getCustomCABundle searches the configs slice for a customCABundleProvider
+// and returns the value if found. Returns an error if a provider fails before
+// a value is found.
+func getCustomCABundle(ctx context.Context, configs configs) (value io.Reader, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(customCABundleProvider); ok {
+			value, found, err = p.getCustomCABundle(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// retryMaxAttemptsProvider provides access to the retry max attempts external
+// configuration value.
+type retryMaxAttemptsProvider interface {
+	getRetryMaxAttempts(context.Context) (int, bool, error)
+}
+
+// getRetryMaxAttempts searches the configs slice for a
+// retryMaxAttemptsProvider and returns the value if found. Returns an error if
+// a provider fails before a value is found.
+func getRetryMaxAttempts(ctx context.Context, configs configs) (v int, found bool, err error) {
+	for _, c := range configs {
+		if p, ok := c.(retryMaxAttemptsProvider); ok {
+			v, found, err = p.getRetryMaxAttempts(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return v, found, err
+}
+
+// retryModeProvider provides access to the retry mode external configuration
+// value.
+type retryModeProvider interface {
+	getRetryMode(context.Context) (cybr.RetryMode, bool, error)
+}
+
+// getRetryMode searches the configs slice for a retryModeProvider and returns
+// the value if found. Returns an error if a provider fails before a value is
+// found.
+func getRetryMode(ctx context.Context, configs configs) (v cybr.RetryMode, found bool, err error) {
+	for _, c := range configs {
+		if p, ok := c.(retryModeProvider); ok {
+			v, found, err = p.getRetryMode(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return v, found, err
+}
+
+// retryProvider is an configuration provider for custom Retryer.
+type retryProvider interface {
+	getRetryer(ctx context.Context) (func() cybr.Retryer, bool, error)
+}
+
+// getRetryer searches the configs slice for a retryProvider and returns the
+// value if found. Returns an error if a provider fails before a value is
+// found.
+func getRetryer(ctx context.Context, configs configs) (v func() cybr.Retryer, found bool, err error) {
+	for _, c := range configs {
+		if p, ok := c.(retryProvider); ok {
+			v, found, err = p.getRetryer(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return v, found, err
+}
+
+// endpointResolverWithOptionsProvider is an interface for retrieving an
+// EndpointResolverWithOptions from a configuration source.
+type endpointResolverWithOptionsProvider interface {
+	getEndpointResolverWithOptions(ctx context.Context) (cybr.EndpointResolverWithOptions, bool, error)
+}
+
+// getEndpointResolverWithOptions searches the configs slice for an
+// endpointResolverWithOptionsProvider and returns the value if found.
+func getEndpointResolverWithOptions(ctx context.Context, configs configs) (resolver cybr.EndpointResolverWithOptions, found bool, err error) {
+	for _, config := range configs {
+		if p, ok := config.(endpointResolverWithOptionsProvider); ok {
+			resolver, found, err = p.getEndpointResolverWithOptions(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// apiOptionsProvider is an interface for retrieving APIOptions.
+type apiOptionsProvider interface {
+	getAPIOptions(ctx context.Context) ([]func(*middleware.Stack) error, bool, error)
+}
+
+// getAPIOptions searches the slice of configs and returns the APIOptions set on configs.
+func getAPIOptions(ctx context.Context, configs configs) (apiOptions []func(*middleware.Stack) error, found bool, err error) {
+	for _, config := range configs {
+		if p, ok := config.(apiOptionsProvider); ok {
+			// retrieve APIOptions from configs and set it on cfg
+			apiOptions, found, err = p.getAPIOptions(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// loggerProvider is an interface for retrieving a logging.Logger from a configuration source.
+type loggerProvider interface {
+	getLogger(ctx context.Context) (logging.Logger, bool, error)
+}
+
+// getLogger searches the provided config sources for a logging.Logger that can be used
+// to configure the cybr.Config.Logger value.
+func getLogger(ctx context.Context, configs configs) (l logging.Logger, found bool, err error) {
+	for _, c := range configs {
+		if p, ok := c.(loggerProvider); ok {
+			l, found, err = p.getLogger(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// clientLogModeProvider is an interface for retrieving the cybr.ClientLogMode
+// from a configuration source.
+type clientLogModeProvider interface {
+	getClientLogMode(context.Context) (cybr.ClientLogMode, bool, error)
+}
+
+// getClientLogMode searches the provided config sources for a
+// cybr.ClientLogMode that can be used to configure the
+// cybr.Config.ClientLogMode value.
+func getClientLogMode(ctx context.Context, configs configs) (m cybr.ClientLogMode, found bool, err error) {
+	for _, c := range configs {
+		if p, ok := c.(clientLogModeProvider); ok {
+			m, found, err = p.getClientLogMode(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// appIDProvider provides access to the application identifier external
+// configuration value.
+type appIDProvider interface {
+	getAppID(context.Context) (string, bool, error)
+}
+
+// getAppID searches the configs slice for an appIDProvider and returns the
+// value if found.
+func getAppID(ctx context.Context, configs configs) (value string, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(appIDProvider); ok {
+			value, found, err = p.getAppID(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// baseEndpointProvider provides access to the base endpoint override
+// external configuration value.
+type baseEndpointProvider interface {
+	getBaseEndpoint(ctx context.Context) (string, bool, error)
+}
+
+// getBaseEndpoint searches the configs slice for a baseEndpointProvider and
+// returns the value if found.
+func getBaseEndpoint(ctx context.Context, configs configs) (value string, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(baseEndpointProvider); ok {
+			value, found, err = p.getBaseEndpoint(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// sharedConfigProfileProvider provides access to the shared config profile
+// name external configuration value.
+type sharedConfigProfileProvider interface {
+	getSharedConfigProfile(ctx context.Context) (string, bool, error)
+}
+
+// getSharedConfigProfile searches the configs for a sharedConfigProfileProvider
+// and returns the value if found. Returns an error if a provider fails before a
+// value is found.
+func getSharedConfigProfile(ctx context.Context, configs configs) (value string, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(sharedConfigProfileProvider); ok {
+			value, found, err = p.getSharedConfigProfile(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// sharedConfigFilesProvider provides access to the shared config filesnames
+// external configuration value.
+type sharedConfigFilesProvider interface {
+	getSharedConfigFiles(ctx context.Context) ([]string, bool, error)
+}
+
+// getSharedConfigFiles searches the configs for a sharedConfigFilesProvider
+// and returns the value if found. Returns an error if a provider fails before a
+// value is found.
+func getSharedConfigFiles(ctx context.Context, configs configs) (value []string, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(sharedConfigFilesProvider); ok {
+			value, found, err = p.getSharedConfigFiles(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// sharedCredentialsFilesProvider provides access to the shared credentials filesnames
+// external configuration value.
+type sharedCredentialsFilesProvider interface {
+	getSharedCredentialsFiles(ctx context.Context) ([]string, bool, error)
+}
+
+// getSharedCredentialsFiles searches the configs for a sharedCredentialsFilesProvider
+// and returns the value if found. Returns an error if a provider fails before a
+// value is found.
+func getSharedCredentialsFiles(ctx context.Context, configs configs) (value []string, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(sharedCredentialsFilesProvider); ok {
+			value, found, err = p.getSharedCredentialsFiles(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// defaultsModeProvider provides access to the defaults mode external
+// configuration value.
+type defaultsModeProvider interface {
+	getDefaultsMode(ctx context.Context) (cybr.DefaultsMode, bool, error)
+}
+
+// getDefaultsMode searches the configs for a defaultsModeProvider and returns
+// the value if found. Returns an error if a provider fails before a value is
+// found.
+func getDefaultsMode(ctx context.Context, configs configs) (value cybr.DefaultsMode, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(defaultsModeProvider); ok {
+			value, found, err = p.getDefaultsMode(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return value, found, err
+}
+
+// defaultsModeDiscoveryClientProvider provides access to the platform
+// discovery client used to resolve the tenant's region for
+// cybr.DefaultsModeAuto.
+type defaultsModeDiscoveryClientProvider interface {
+	getDefaultsModeDiscoveryClient(context.Context) (*discovery.Client, bool, error)
+}
+
+// getDefaultsModeDiscoveryClient searches the configs for a
+// defaultsModeDiscoveryClientProvider and returns the value if found.
+// Returns an error if a provider fails before a value is found.
+func getDefaultsModeDiscoveryClient(ctx context.Context, configs configs) (value *discovery.Client, found bool, err error) {
+	for _, c := range configs {
+		if p, ok := c.(defaultsModeDiscoveryClientProvider); ok {
+			value, found, err = p.getDefaultsModeDiscoveryClient(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
+
+// identityURLProvider provides access to the Identity tenant URL external
+// configuration value.
+type identityURLProvider interface {
+	getIdentityURL(ctx context.Context) (string, bool, error)
+}
+
+// getIdentityURL searches the configs for an identityURLProvider and returns
+// the value if found. Returns an error if a provider fails before a value is
+// found.
+func getIdentityURL(ctx context.Context, configs configs) (value string, found bool, err error) {
+	for _, cfg := range configs {
+		if p, ok := cfg.(identityURLProvider); ok {
+			value, found, err = p.getIdentityURL(ctx)
+			if err != nil || found {
+				break
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,337 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/defaults"
+	cybrhttp "github.com/strick-j/cybr-sdk-go/cybr/transport/http"
+)
+
+// resolveHTTPClient extracts the HTTP client from the configs slice, and
+// assigns it to the cybr.Config. If no HTTP client is found a new
+// BuildableClient will be used.
+func resolveHTTPClient(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	c, found, err := getHTTPClient(ctx, configs)
+	if err != nil {
+		return err
+	}
+	if !found {
+		c = cybrhttp.NewBuildableClient()
+	}
+
+	cfg.HTTPClient = c
+	return nil
+}
+
+// resolveCustomCABundle extracts the first instance of a custom CA bundle
+// PEM bytes reader from the configs slice, and adds the certificates to the
+// HTTP client's trusted root certificates. The HTTP client must be a
+// BuildableClient for the CA bundle to be added.
+//
+// Config providers used:
+// * customCABundleProvider
+func resolveCustomCABundle(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	pemCerts, found, err := getCustomCABundle(ctx, configs)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = cybrhttp.NewBuildableClient()
+	}
+
+	client, ok := cfg.HTTPClient.(*cybrhttp.BuildableClient)
+	if !ok {
+		return fmt.Errorf("unable to add custom RootCAs HTTPClient, has no WithCABundle, %T", cfg.HTTPClient)
+	}
+
+	b, err := io.ReadAll(pemCerts)
+	if err != nil {
+		return fmt.Errorf("failed to read custom CA bundle, %w", err)
+	}
+
+	client, err = client.WithCABundle(b)
+	if err != nil {
+		return err
+	}
+
+	cfg.HTTPClient = client
+	return nil
+}
+
+// resolveTenantName extracts the tenant name from the configs slice.
+//
+// Config providers used:
+// * tenantNameProvider
+func resolveTenantName(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	v, found, err := getTenantName(ctx, configs)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	cfg.TenantName = v
+	return nil
+}
+
+// resolveTenantID extracts the Identity tenant ID from the configs slice.
+//
+// Config providers used:
+// * tenantIDProvider
+func resolveTenantID(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	v, found, err := getTenantID(ctx, configs)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	cfg.TenantID = v
+	return nil
+}
+
+// resolveEndpointResolverWithOptions extracts the EndpointResolverWithOptions
+// from the configs slice.
+//
+// Config providers used:
+// * endpointResolverWithOptionsProvider
+func resolveEndpointResolverWithOptions(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	resolver, found, err := getEndpointResolverWithOptions(ctx, configs)
+	if err != nil {
+		return err
+	}
+	if found {
+		cfg.EndpointResolverWithOptions = resolver
+	}
+
+	return nil
+}
+
+// resolveAPIOptions extracts the APIOptions from the configs slice.
+//
+// Config providers used:
+// * apiOptionsProvider
+func resolveAPIOptions(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	o, _, err := getAPIOptions(ctx, configs)
+	if err != nil {
+		return err
+	}
+
+	cfg.APIOptions = o
+
+	return nil
+}
+
+// resolveRetryer extracts the retryer and retry configuration from the
+// configs slice.
+//
+// Config providers used:
+// * retryProvider
+// * retryMaxAttemptsProvider
+// * retryModeProvider
+func resolveRetryer(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	retryer, found, err := getRetryer(ctx, configs)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		cfg.Retryer = retryer
+		return nil
+	}
+
+	// Only load the retry options if a custom retryer has not be specified.
+	if err = resolveRetryMaxAttempts(ctx, cfg, configs); err != nil {
+		return err
+	}
+	return resolveRetryMode(ctx, cfg, configs)
+}
+
+func resolveRetryMaxAttempts(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	maxAttempts, found, err := getRetryMaxAttempts(ctx, configs)
+	if err != nil || !found {
+		return err
+	}
+	cfg.RetryMaxAttempts = maxAttempts
+
+	return nil
+}
+
+func resolveRetryMode(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	retryMode, found, err := getRetryMode(ctx, configs)
+	if err != nil || !found {
+		return err
+	}
+	cfg.RetryMode = retryMode
+
+	return nil
+}
+
+// resolveLogger extracts the logger from the configs slice. If no logger is
+// found a standard logger writing to standard error is used.
+//
+// Config providers used:
+// * loggerProvider
+func resolveLogger(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	logger, found, err := getLogger(ctx, configs)
+	if err != nil {
+		return err
+	}
+	if !found {
+		logger = logging.NewStandardLogger(os.Stderr)
+	}
+
+	cfg.Logger = logger
+
+	return nil
+}
+
+// resolveClientLogMode extracts the client logging mode from the configs
+// slice.
+//
+// Config providers used:
+// * clientLogModeProvider
+func resolveClientLogMode(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	mode, found, err := getClientLogMode(ctx, configs)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	cfg.ClientLogMode = mode
+
+	return nil
+}
+
+// resolveDefaultsMode extracts the defaults mode from the configs slice.
+//
+// Config providers used:
+// * defaultsModeProvider
+func resolveDefaultsMode(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	mode, found, err := getDefaultsMode(ctx, configs)
+	if err != nil {
+		return err
+	}
+	if !found {
+		mode = cybr.DefaultsModeLegacy
+	}
+
+	cfg.DefaultsMode = mode
+	return nil
+}
+
+// resolveDefaultsModeRuntimeEnvironment determines the environment the SDK
+// is executing in when the defaults mode is cybr.DefaultsModeAuto. If the
+// region the SDK is executing in is known, the tenant's region is resolved
+// with the platform discovery API.
+//
+// Config providers used:
+// * defaultsModeDiscoveryClientProvider
+func resolveDefaultsModeRuntimeEnvironment(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	if cfg.DefaultsMode != cybr.DefaultsModeAuto {
+		return nil
+	}
+
+	cfg.RuntimeEnvironment = cybr.RuntimeEnvironment{
+		EnvironmentIdentifier: resolveExecutionEnvironment(),
+		Region:                resolveExecutionRegion(),
+	}
+
+	if len(cfg.RuntimeEnvironment.Region) == 0 || len(cfg.TenantName) == 0 {
+		return nil
+	}
+
+	client, _, err := getDefaultsModeDiscoveryClient(ctx, configs)
+	if err != nil {
+		return err
+	}
+	cfg.RuntimeEnvironment.TenantRegion = resolveTenantRegion(ctx, cfg, client)
+
+	return nil
+}
+
+// resolveDefaultsModeConfiguration applies the configuration of the
+// resolved defaults mode. The mode's connect and TLS handshake timeouts are
+// applied to the HTTP client if it is a BuildableClient, and the mode's retry
+// mode is used if a retry mode was not configured. The legacy mode leaves the
+// configuration unmodified.
+func resolveDefaultsModeConfiguration(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	mode := cfg.DefaultsMode
+	if mode == cybr.DefaultsModeAuto {
+		mode = defaults.ResolveDefaultsModeAuto(cfg.RuntimeEnvironment)
+	}
+	if mode == cybr.DefaultsModeLegacy {
+		return nil
+	}
+
+	modeConfig, err := defaults.GetModeConfiguration(mode)
+	if err != nil {
+		return err
+	}
+
+	if client, ok := cfg.HTTPClient.(*cybrhttp.BuildableClient); ok {
+		if v, ok := modeConfig.GetConnectTimeout(); ok {
+			client = client.WithDialerOptions(func(dialer *net.Dialer) {
+				dialer.Timeout = v
+			})
+		}
+		if v, ok := modeConfig.GetTLSNegotiationTimeout(); ok {
+			client = client.WithTransportOptions(func(tr *http.Transport) {
+				tr.TLSHandshakeTimeout = v
+			})
+		}
+		cfg.HTTPClient = client
+	}
+
+	if cfg.Retryer == nil && len(cfg.RetryMode) == 0 {
+		cfg.RetryMode = modeConfig.RetryMode
+	}
+
+	return nil
+}
+
+// resolveAppID extracts the application identifier from the configs slice.
+//
+// Config providers used:
+// * appIDProvider
+func resolveAppID(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	ID, _, err := getAppID(ctx, configs)
+	if err != nil {
+		return err
+	}
+
+	cfg.AppID = ID
+	return nil
+}
+
+// resolveBaseEndpoint extracts the base endpoint override from the configs
+// slice.
+//
+// Config providers used:
+// * baseEndpointProvider
+func resolveBaseEndpoint(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	v, found, err := getBaseEndpoint(ctx, configs)
+	if err != nil {
+		return err
+	}
+	if found {
+		cfg.BaseEndpoint = cybr.String(v)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strick-j/cybr-sdk-go/credentials"
+	"github.com/strick-j/cybr-sdk-go/credentials/oauthcreds"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+// resolveCredentials extracts a credential provider from slice of config
+// sources.
+//
+// If an explicit credential provider is not found the resolver will fallback
+// to resolving credentials by extracting a credential provider from EnvConfig
+// and SharedConfig.
+func resolveCredentials(ctx context.Context, cfg *cybr.Config, configs configs) error {
+	found, err := resolveCredentialProvider(ctx, cfg, configs)
+	if found || err != nil {
+		return err
+	}
+
+	return resolveCredentialChain(ctx, cfg, configs)
+}
+
+// resolveCredentialProvider extracts the first instance of Credentials from the
+// config slices.
+//
+// The resolved CredentialProvider will be wrapped in a cache to ensure the
+// credentials are only refreshed when needed. This also protects the
+// credential provider to be used concurrently.
+//
+// Config providers used:
+// * credentialsProviderProvider
+func resolveCredentialProvider(ctx context.Context, cfg *cybr.Config, configs configs) (bool, error) {
+	credProvider, found, err := getCredentialsProvider(ctx, configs)
+	if !found || err != nil {
+		return false, err
+	}
+
+	cfg.Credentials, err = wrapWithCredentialsCache(ctx, configs, credProvider)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// resolveCredentialChain resolves a credential provider chain using EnvConfig
+// and SharedConfig as a source of credentials. Credentials in the environment
+// take precedence over the shared config profile. Within each source a bearer
+// token takes precedence over OAuth client credentials. If no credentials are
+// found the Config's Credentials are left unset, and requests will not be
+// signed.
+func resolveCredentialChain(ctx context.Context, cfg *cybr.Config, configs configs) (err error) {
+	envConfig, sharedConfig, other := getCYBRConfigSources(configs)
+
+	switch {
+	case envConfig.Credentials.HasKeys():
+		cfg.Credentials = credentials.StaticCredentialsProvider{Value: envConfig.Credentials}
+	case len(envConfig.ClientID) > 0 && len(envConfig.ClientSecret) > 0:
+		cfg.Credentials, err = resolveOAuthCredentials(ctx, cfg, configs,
+			envConfig.ClientID, envConfig.ClientSecret, envConfig.OAuthAppID)
+	case sharedConfig.Credentials.HasKeys():
+		cfg.Credentials = credentials.StaticCredentialsProvider{Value: sharedConfig.Credentials}
+	case len(sharedConfig.ClientID) > 0 && len(sharedConfig.ClientSecret) > 0:
+		cfg.Credentials, err = resolveOAuthCredentials(ctx, cfg, configs,
+			sharedConfig.ClientID, sharedConfig.ClientSecret, sharedConfig.OAuthAppID)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Wrap the resolved provider in a cache so the SDK will cache credentials.
+	cfg.Credentials, err = wrapWithCredentialsCache(ctx, other, cfg.Credentials)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// resolveOAuthCredentials returns an OAuth client credentials provider that
+// exchanges the client ID and secret with the resolved Identity tenant URL.
+// The provider will use the Config's HTTP client to make requests.
+func resolveOAuthCredentials(
+	ctx context.Context, cfg *cybr.Config, configs configs,
+	clientID, clientSecret, oauthAppID string,
+) (cybr.CredentialsProvider, error) {
+	identityURL, found, err := getIdentityURL(ctx, configs)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("identity URL is required to use OAuth client credentials")
+	}
+
+	return oauthcreds.New(identityURL, clientID, clientSecret, func(o *oauthcreds.Options) {
+		o.AppID = oauthAppID
+		if cfg.HTTPClient != nil {
+			o.HTTPClient = cfg.HTTPClient
+		}
+	}), nil
+}
+
+// wrapWithCredentialsCache will wrap provider with an cybr.CredentialsCache
+// with the provided options if the provider is not already a
+// cybr.CredentialsCache, or is the cybr.AnonymousCredentials sentinel.
+func wrapWithCredentialsCache(
+	ctx context.Context,
+	cfgs configs,
+	provider cybr.CredentialsProvider,
+	optFns ...func(options *cybr.CredentialsCacheOptions),
+) (cybr.CredentialsProvider, error) {
+	_, ok := provider.(*cybr.CredentialsCache)
+	if ok {
+		return provider, nil
+	}
+	if cybr.IsCredentialsProvider(provider, (*cybr.AnonymousCredentials)(nil)) {
+		return provider, nil
+	}
+
+	credCacheOptions, optionsFound, err := getCredentialsCacheOptionsProvider(ctx, cfgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// force allocation of a new slice if the additional options are
+	// needed, to prevent overwriting the passed in slice of options.
+	optFns = optFns[:len(optFns):len(optFns)]
+	if optionsFound {
+		optFns = append(optFns, credCacheOptions)
+	}
+
+	return cybr.NewCredentialsCache(provider, optFns...), nil
+}
+
+// getCYBRConfigSources returns the EnvConfig and SharedConfig from the
+// configs slice, along with the remaining config sources.
+func getCYBRConfigSources(cfgs configs) (EnvConfig, SharedConfig, configs) {
+	var (
+		envConfig    *EnvConfig
+		sharedConfig *SharedConfig
+		other        configs
+	)
+
+	for i := range cfgs {
+		switch c := cfgs[i].(type) {
+		case EnvConfig:
+			if envConfig == nil {
+				envConfig = &c
+			}
+		case *EnvConfig:
+			if envConfig == nil {
+				envConfig = c
+			}
+		case SharedConfig:
+			if sharedConfig == nil {
+				sharedConfig = &c
+			}
+		case *SharedConfig:
+			if sharedConfig == nil {
+				sharedConfig = c
+			}
+		default:
+			other = append(other, c)
+		}
+	}
+
+	if envConfig == nil {
+		envConfig = &EnvConfig{}
+	}
+	if sharedConfig == nil {
+		sharedConfig = &SharedConfig{}
+	}
+
+	return *envConfig, *sharedConfig, other
+}
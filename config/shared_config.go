@@ -0,0 +1,571 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/internal/ini"
+)
+
+const (
+	// Prefix to use for filtering profiles. The profile prefix should only
+	// exist in the shared config file, not the credentials file.
+	profilePrefix = `profile `
+)
+
+// Shared config file keys.
+const (
+	tenantNameKey  = `tenant_name`
+	tenantIDKey    = `tenant_id`
+	identityURLKey = `identity_url`
+
+	// Static credentials key
+	bearerTokenKey = `bearer_token`
+
+	// OAuth client credentials keys
+	clientIDKey     = `client_id`
+	clientSecretKey = `client_secret`
+	oauthAppIDKey   = `oauth_app_id`
+
+	retryMaxAttemptsKey = `max_attempts`
+	retryModeKey        = `retry_mode`
+
+	defaultsModeKey = `defaults_mode`
+
+	appIDKey = `app_id`
+
+	caBundleKey = `ca_bundle`
+)
+
+// defaultSharedConfigProfile allows for swapping the default profile for testing
+var defaultSharedConfigProfile = DefaultSharedConfigProfile
+
+// DefaultSharedCredentialsFilename returns the SDK's default file path
+// for the shared credentials file.
+//
+// Builds the shared config file path based on the OS's platform.
+//
+//   - Linux/Unix: $HOME/.cybr/credentials
+//   - Windows: %USERPROFILE%\.cybr\credentials
+func DefaultSharedCredentialsFilename() string {
+	return filepath.Join(userHomeDir(), ".cybr", "credentials")
+}
+
+// DefaultSharedConfigFilename returns the SDK's default file path for
+// the shared config file.
+//
+// Builds the shared config file path based on the OS's platform.
+//
+//   - Linux/Unix: $HOME/.cybr/config
+//   - Windows: %USERPROFILE%\.cybr\config
+func DefaultSharedConfigFilename() string {
+	return filepath.Join(userHomeDir(), ".cybr", "config")
+}
+
+// DefaultSharedConfigFiles is a slice of the default shared config files that
+// the will be used in order to load the SharedConfig.
+var DefaultSharedConfigFiles = []string{
+	DefaultSharedConfigFilename(),
+}
+
+// DefaultSharedCredentialsFiles is a slice of the default shared credentials
+// files that the will be used in order to load the SharedConfig.
+var DefaultSharedCredentialsFiles = []string{
+	DefaultSharedCredentialsFilename(),
+}
+
+// DefaultSharedConfigProfile is the default profile to be used when
+// loading configuration from the config files if another profile name
+// is not provided.
+const DefaultSharedConfigProfile = `default`
+
+// SharedConfig represents the configuration fields of the SDK config files.
+type SharedConfig struct {
+	Profile string
+
+	// Tenant name the SDK's API clients will make requests to.
+	//
+	//	tenant_name = example
+	TenantName string
+
+	// Identity tenant ID of the tenant the SDK's API clients will make
+	// requests to.
+	//
+	//	tenant_id = ABC1234
+	TenantID string
+
+	// Identity tenant URL used for authentication against the tenant.
+	//
+	//	identity_url = https://abc1234.id.cyberark.cloud
+	IdentityURL string
+
+	// Credentials values from the config file. Both the config and
+	// credentials files may contain a bearer token for the profile. The value
+	// from the credentials file takes precedence.
+	//
+	//	bearer_token = eyJhbGciOi...
+	Credentials cybr.Credentials
+
+	// Service user client ID and secret that will be exchanged with the
+	// Identity tenant for bearer tokens if a bearer token is not set.
+	// Requires identity_url to be set for the profile.
+	//
+	//	client_id     = service-user@example.com
+	//	client_secret = client-secret
+	ClientID     string
+	ClientSecret string
+
+	// ID of the custom OAuth application the client credentials will be
+	// exchanged with instead of the platform token endpoint.
+	//
+	//	oauth_app_id = my-oauth-app
+	OAuthAppID string
+
+	// Specifies the maximum number attempts an API client will call an
+	// operation that fails with a retryable error.
+	//
+	//	max_attempts = 3
+	RetryMaxAttempts int
+
+	// Specifies the retry model the API client will be created with.
+	//
+	//	retry_mode = standard
+	RetryMode cybr.RetryMode
+
+	// Sets the default behavior of the SDK.
+	//
+	//	defaults_mode = standard
+	DefaultsMode cybr.DefaultsMode
+
+	// Application identifier appended to the User-Agent header.
+	//
+	//	app_id = my-application
+	AppID string
+
+	// Path to a custom CA bundle PEM file the SDK will use to verify the
+	// TLS connections to the service.
+	//
+	//	ca_bundle = /path/to/bundle.pem
+	CustomCABundle string
+}
+
+// getTenantName returns the tenant name for the profile if set.
+func (c SharedConfig) getTenantName(ctx context.Context) (string, bool, error) {
+	if len(c.TenantName) == 0 {
+		return "", false, nil
+	}
+	return c.TenantName, true, nil
+}
+
+// getTenantID returns the Identity tenant ID for the profile if set.
+func (c SharedConfig) getTenantID(ctx context.Context) (string, bool, error) {
+	if len(c.TenantID) == 0 {
+		return "", false, nil
+	}
+	return c.TenantID, true, nil
+}
+
+// getIdentityURL returns the Identity tenant URL for the profile if set.
+func (c SharedConfig) getIdentityURL(ctx context.Context) (string, bool, error) {
+	return c.IdentityURL, len(c.IdentityURL) > 0, nil
+}
+
+// getRetryMaxAttempts returns the maximum number of attempts for the profile
+// if set.
+func (c SharedConfig) getRetryMaxAttempts(ctx context.Context) (int, bool, error) {
+	return c.RetryMaxAttempts, c.RetryMaxAttempts > 0, nil
+}
+
+// getRetryMode returns the retry mode for the profile if set.
+func (c SharedConfig) getRetryMode(ctx context.Context) (cybr.RetryMode, bool, error) {
+	return c.RetryMode, len(c.RetryMode) > 0, nil
+}
+
+// getDefaultsMode returns the defaults mode for the profile if set.
+func (c SharedConfig) getDefaultsMode(ctx context.Context) (cybr.DefaultsMode, bool, error) {
+	if len(c.DefaultsMode) == 0 {
+		return "", false, nil
+	}
+	return c.DefaultsMode, true, nil
+}
+
+// getAppID returns the application identifier for the profile if set.
+func (c SharedConfig) getAppID(ctx context.Context) (string, bool, error) {
+	return c.AppID, len(c.AppID) > 0, nil
+}
+
+// getCustomCABundle returns the custom CA bundle's PEM bytes if the file
+// was specified for the profile.
+func (c SharedConfig) getCustomCABundle(ctx context.Context) (io.Reader, bool, error) {
+	if len(c.CustomCABundle) == 0 {
+		return nil, false, nil
+	}
+
+	b, err := os.ReadFile(c.CustomCABundle)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s custom CA bundle, %w", caBundleKey, err)
+	}
+	return bytes.NewReader(b), true, nil
+}
+
+// loadSharedConfigIgnoreNotExist is an alias for loadSharedConfig with the
+// addition of ignoring when none of the files exist or when the profile
+// is not found in any of the files. A profile that was explicitly selected,
+// e.g. with CYBR_PROFILE, must exist.
+func loadSharedConfigIgnoreNotExist(ctx context.Context, configs configs) (Config, error) {
+	cfg, err := loadSharedConfig(ctx, configs)
+	if err != nil {
+		if _, ok := err.(SharedConfigProfileNotExistError); ok {
+			if _, explicit, _ := getSharedConfigProfile(ctx, configs); !explicit {
+				return SharedConfig{}, nil
+			}
+		}
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// loadSharedConfig uses the configs passed in to load the SharedConfig from file
+// The file names and profile name are sourced from the configs.
+//
+// If profile name is not provided DefaultSharedConfigProfile (default) will
+// be used.
+//
+// If shared config filenames are not provided DefaultSharedConfigFiles will
+// be used.
+//
+// Config providers used:
+// * sharedConfigProfileProvider
+// * sharedConfigFilesProvider
+// * sharedCredentialsFilesProvider
+func loadSharedConfig(ctx context.Context, configs configs) (Config, error) {
+	var profile string
+	var configFiles []string
+	var credentialsFiles []string
+	var ok bool
+	var err error
+
+	profile, ok, err = getSharedConfigProfile(ctx, configs)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		profile = defaultSharedConfigProfile
+	}
+
+	configFiles, _, err = getSharedConfigFiles(ctx, configs)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialsFiles, _, err = getSharedCredentialsFiles(ctx, configs)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadSharedConfigProfile(ctx, profile, func(o *LoadSharedConfigOptions) {
+		o.CredentialsFiles = credentialsFiles
+		o.ConfigFiles = configFiles
+	})
+}
+
+// LoadSharedConfigOptions struct contains optional values that can be used to load the config.
+type LoadSharedConfigOptions struct {
+
+	// CredentialsFiles are the shared credentials files
+	CredentialsFiles []string
+
+	// ConfigFiles are the shared config files
+	ConfigFiles []string
+}
+
+// LoadSharedConfigProfile retrieves the configuration from the list of files
+// using the profile provided. The order the files are listed will determine
+// precedence. Values in subsequent files will overwrite values defined in
+// earlier files.
+//
+// For example, given two files A and B. Both define credentials. If the order
+// of the files are A then B, B's credential values will be used instead of A's.
+//
+// If config files are not set, SDK will default to using a file at location `.cybr/config` if present.
+// If credentials files are not set, SDK will default to using a file at location `.cybr/credentials` if present.
+// No default files are set, if files set to an empty slice.
+func LoadSharedConfigProfile(ctx context.Context, profile string, optFns ...func(*LoadSharedConfigOptions)) (SharedConfig, error) {
+	var option LoadSharedConfigOptions
+	for _, fn := range optFns {
+		fn(&option)
+	}
+
+	if option.ConfigFiles == nil {
+		option.ConfigFiles = DefaultSharedConfigFiles
+	}
+
+	if option.CredentialsFiles == nil {
+		option.CredentialsFiles = DefaultSharedCredentialsFiles
+	}
+
+	// load shared configuration sections from shared configuration INI options
+	configSections, err := loadIniFiles(option.ConfigFiles)
+	if err != nil {
+		return SharedConfig{}, err
+	}
+
+	// check for profile prefix and drop duplicates or invalid profiles
+	configSections = processConfigSections(configSections)
+
+	// load shared credentials sections from shared credentials INI options
+	credentialsSections, err := loadIniFiles(option.CredentialsFiles)
+	if err != nil {
+		return SharedConfig{}, err
+	}
+
+	// check for profile prefix and drop duplicates or invalid profiles
+	credentialsSections = processCredentialsSections(credentialsSections)
+
+	cfg := SharedConfig{}
+	if err = cfg.setFromIniSections(profile, configSections, credentialsSections); err != nil {
+		return SharedConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+// sectionsGroup is the ordered list of sections loaded from each of the
+// shared config or credentials files. Keyed by the section's profile name.
+type sectionsGroup []map[string]ini.Section
+
+// processConfigSections normalizes the sections of the shared config files.
+// Sections prefixed with "profile " are keyed by their profile name. The
+// "default" section is keyed as is. All other sections are dropped.
+func processConfigSections(groups sectionsGroup) sectionsGroup {
+	processed := make(sectionsGroup, 0, len(groups))
+	for _, sections := range groups {
+		out := map[string]ini.Section{}
+		for name, section := range sections {
+			switch {
+			case strings.HasPrefix(name, profilePrefix):
+				out[strings.TrimSpace(strings.TrimPrefix(name, profilePrefix))] = section
+			case name == DefaultSharedConfigProfile:
+				// Values in a "profile default" section take precedence
+				// over the bare "default" section.
+				if _, ok := out[name]; !ok {
+					out[name] = section
+				}
+			}
+		}
+		processed = append(processed, out)
+	}
+
+	return processed
+}
+
+// processCredentialsSections normalizes the sections of the shared
+// credentials files. Sections prefixed with "profile " are not valid within
+// the credentials file, and are dropped.
+func processCredentialsSections(groups sectionsGroup) sectionsGroup {
+	processed := make(sectionsGroup, 0, len(groups))
+	for _, sections := range groups {
+		out := map[string]ini.Section{}
+		for name, section := range sections {
+			if strings.HasPrefix(name, profilePrefix) {
+				continue
+			}
+			out[name] = section
+		}
+		processed = append(processed, out)
+	}
+
+	return processed
+}
+
+// loadIniFiles loads the sections of each of the files. Files that do not
+// exist are skipped.
+func loadIniFiles(filenames []string) (sectionsGroup, error) {
+	var groups sectionsGroup
+
+	for _, filename := range filenames {
+		sections, err := ini.OpenFile(filename)
+		if errors.Is(err, os.ErrNotExist) {
+			// Skip files which can't be found.
+			continue
+		} else if err != nil {
+			return nil, SharedConfigLoadError{Filename: filename, Err: err}
+		}
+
+		group := map[string]ini.Section{}
+		for _, name := range sections.List() {
+			group[name], _ = sections.GetSection(name)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// setFromIniSections loads the configuration from the profile's sections of
+// the config and credentials files. Files later in the lists override the
+// values of earlier files, and credentials files override config files.
+func (c *SharedConfig) setFromIniSections(profile string, configSections, credentialsSections sectionsGroup) error {
+	c.Profile = profile
+
+	var found bool
+	for _, group := range []sectionsGroup{configSections, credentialsSections} {
+		for _, sections := range group {
+			section, ok := sections[profile]
+			if !ok {
+				continue
+			}
+			found = true
+
+			if err := c.setFromIniSection(profile, section); err != nil {
+				return fmt.Errorf("failed to set config from ini section, %w", err)
+			}
+		}
+	}
+
+	if !found {
+		return SharedConfigProfileNotExistError{
+			Profile: profile,
+			Err:     nil,
+		}
+	}
+
+	return nil
+}
+
+// setFromIniSection loads the configuration from the profile section defined
+// in the provided INI section. Only values which are set in the section
+// overwrite those already loaded.
+func (c *SharedConfig) setFromIniSection(profile string, section ini.Section) error {
+	updateString(&c.TenantName, section, tenantNameKey)
+	updateString(&c.TenantID, section, tenantIDKey)
+	updateString(&c.IdentityURL, section, identityURLKey)
+	updateString(&c.AppID, section, appIDKey)
+	updateString(&c.ClientID, section, clientIDKey)
+	updateString(&c.ClientSecret, section, clientSecretKey)
+	updateString(&c.OAuthAppID, section, oauthAppIDKey)
+	updateString(&c.CustomCABundle, section, caBundleKey)
+
+	if err := updateInt(&c.RetryMaxAttempts, section, retryMaxAttemptsKey); err != nil {
+		return fmt.Errorf("failed to load %s from shared config, %v", retryMaxAttemptsKey, err)
+	}
+	if err := updateRetryMode(&c.RetryMode, section, retryModeKey); err != nil {
+		return fmt.Errorf("failed to load %s from shared config, %v", retryModeKey, err)
+	}
+	if err := updateDefaultsMode(&c.DefaultsMode, section, defaultsModeKey); err != nil {
+		return fmt.Errorf("failed to load %s from shared config, %v", defaultsModeKey, err)
+	}
+
+	// Shared Credentials
+	if v := section.String(bearerTokenKey); len(v) > 0 {
+		c.Credentials = cybr.Credentials{
+			BearerToken: v,
+			Source:      fmt.Sprintf("SharedConfigCredentials: %s", profile),
+		}
+	}
+
+	return nil
+}
+
+// SharedConfigLoadError is an error for the shared config file failed to load.
+type SharedConfigLoadError struct {
+	Filename string
+	Err      error
+}
+
+// Unwrap returns the underlying error that caused the failure.
+func (e SharedConfigLoadError) Unwrap() error {
+	return e.Err
+}
+
+func (e SharedConfigLoadError) Error() string {
+	return fmt.Sprintf("failed to load shared config file, %s, %v", e.Filename, e.Err)
+}
+
+// SharedConfigProfileNotExistError is an error for the shared config when
+// the profile was not find in the config file.
+type SharedConfigProfileNotExistError struct {
+	Profile string
+	Err     error
+}
+
+// Unwrap returns the underlying error that caused the failure.
+func (e SharedConfigProfileNotExistError) Unwrap() error {
+	return e.Err
+}
+
+func (e SharedConfigProfileNotExistError) Error() string {
+	return fmt.Sprintf("failed to get shared config profile, %s", e.Profile)
+}
+
+// updateString will only update the dst with the value in the section key, key
+// is present in the section.
+func updateString(dst *string, section ini.Section, key string) {
+	if !section.Has(key) {
+		return
+	}
+	*dst = section.String(key)
+}
+
+// updateInt will only update the dst with the value in the section key, key
+// is present in the section.
+//
+// Down casts the INI integer value from a int64 to an int, which could be
+// different bit size depending on platform.
+func updateInt(dst *int, section ini.Section, key string) error {
+	if !section.Has(key) {
+		return nil
+	}
+
+	v, err := strconv.ParseInt(section.String(key), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid value %s=%s, expect integer", key, section.String(key))
+	}
+
+	*dst = int(v)
+	return nil
+}
+
+// updateRetryMode will only update the dst with the value in the section key,
+// key is present in the section.
+func updateRetryMode(dst *cybr.RetryMode, section ini.Section, key string) (err error) {
+	if !section.Has(key) {
+		return nil
+	}
+
+	if *dst, err = cybr.ParseRetryMode(section.String(key)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateDefaultsMode will only update the dst with the value in the section
+// key, key is present in the section.
+func updateDefaultsMode(dst *cybr.DefaultsMode, section ini.Section, key string) error {
+	if !section.Has(key) {
+		return nil
+	}
+
+	value := section.String(key)
+	if ok := dst.SetFromString(value); !ok {
+		return fmt.Errorf("invalid value: %s", value)
+	}
+
+	return nil
+}
+
+func userHomeDir() string {
+	// Ignore errors since we only care about Windows and *nix.
+	homedir, _ := os.UserHomeDir()
+	return homedir
+}
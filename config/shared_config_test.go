@@ -0,0 +1,203 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+var (
+	testConfigFilename      = filepath.Join("testdata", "shared_config")
+	testCredentialsFilename = filepath.Join("testdata", "shared_credentials")
+)
+
+func TestLoadSharedConfigProfile(t *testing.T) {
+	cases := map[string]struct {
+		Profile    string
+		Expected   SharedConfig
+		ExpectErr  bool
+		ProfileErr bool
+	}{
+		"default profile": {
+			Profile: "default",
+			Expected: SharedConfig{
+				Profile:    "default",
+				TenantName: "default-tenant",
+				RetryMode:  cybr.RetryModeStandard,
+			},
+		},
+		"all keys, credentials file precedence": {
+			Profile: "dev",
+			Expected: SharedConfig{
+				Profile:          "dev",
+				TenantName:       "dev-tenant",
+				TenantID:         "DEV1234",
+				IdentityURL:      "https://dev1234.id.cyberark.cloud",
+				RetryMaxAttempts: 5,
+				RetryMode:        cybr.RetryModeAdaptive,
+				DefaultsMode:     cybr.DefaultsModeStandard,
+				AppID:            "dev-app",
+				CustomCABundle:   "testdata/ca_bundle.pem",
+				Credentials: cybr.Credentials{
+					BearerToken: "credentials-token",
+					Source:      "SharedConfigCredentials: dev",
+				},
+			},
+		},
+		"profile prefixed credentials section ignored": {
+			Profile: "prod",
+			Expected: SharedConfig{
+				Profile:    "prod",
+				TenantName: "prod-tenant",
+				Credentials: cybr.Credentials{
+					BearerToken: "prod-token",
+					Source:      "SharedConfigCredentials: prod",
+				},
+			},
+		},
+		"oauth client credentials": {
+			Profile: "service",
+			Expected: SharedConfig{
+				Profile:      "service",
+				IdentityURL:  "https://svc1234.id.cyberark.cloud",
+				ClientID:     "service-user@example.com",
+				ClientSecret: "client-secret",
+				OAuthAppID:   "my-oauth-app",
+			},
+		},
+		"unprefixed config section ignored": {
+			Profile:    "ignored",
+			ExpectErr:  true,
+			ProfileErr: true,
+		},
+		"missing profile": {
+			Profile:    "missing",
+			ExpectErr:  true,
+			ProfileErr: true,
+		},
+		"invalid max attempts": {
+			Profile:   "invalid_max_attempts",
+			ExpectErr: true,
+		},
+		"invalid retry mode": {
+			Profile:   "invalid_retry_mode",
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := LoadSharedConfigProfile(context.Background(), c.Profile, func(o *LoadSharedConfigOptions) {
+				o.ConfigFiles = []string{testConfigFilename}
+				o.CredentialsFiles = []string{testCredentialsFilename}
+			})
+			if c.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				var profileErr SharedConfigProfileNotExistError
+				if e, a := c.ProfileErr, errors.As(err, &profileErr); e != a {
+					t.Errorf("expect profile not exist error %v, got %v", e, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if diff := cmpDiff(c.Expected, cfg); len(diff) != 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestLoadSharedConfigProfile_FilesNotExist(t *testing.T) {
+	_, err := LoadSharedConfigProfile(context.Background(), "default", func(o *LoadSharedConfigOptions) {
+		o.ConfigFiles = []string{filepath.Join(t.TempDir(), "config")}
+		o.CredentialsFiles = []string{filepath.Join(t.TempDir(), "credentials")}
+	})
+
+	var profileErr SharedConfigProfileNotExistError
+	if !errors.As(err, &profileErr) {
+		t.Fatalf("expect %T error, got %v", profileErr, err)
+	}
+}
+
+func TestLoadDefaultConfig_SharedConfigProfile(t *testing.T) {
+	restoreEnv := clearEnv()
+	defer restoreEnv()
+
+	os.Setenv("CYBR_CONFIG_FILE", testConfigFilename)
+	os.Setenv("CYBR_SHARED_CREDENTIALS_FILE", testCredentialsFilename)
+	os.Setenv("CYBR_PROFILE", "dev")
+
+	cfg, err := LoadDefaultConfig(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "dev-tenant", cfg.TenantName; e != a {
+		t.Errorf("expect %v tenant name, got %v", e, a)
+	}
+	if e, a := "DEV1234", cfg.TenantID; e != a {
+		t.Errorf("expect %v tenant ID, got %v", e, a)
+	}
+	if e, a := 5, cfg.RetryMaxAttempts; e != a {
+		t.Errorf("expect %v max attempts, got %v", e, a)
+	}
+	if e, a := cybr.RetryModeAdaptive, cfg.RetryMode; e != a {
+		t.Errorf("expect %v retry mode, got %v", e, a)
+	}
+	if e, a := cybr.DefaultsModeStandard, cfg.DefaultsMode; e != a {
+		t.Errorf("expect %v defaults mode, got %v", e, a)
+	}
+	if e, a := "dev-app", cfg.AppID; e != a {
+		t.Errorf("expect %v app ID, got %v", e, a)
+	}
+
+	creds, err := cfg.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "credentials-token", creds.BearerToken; e != a {
+		t.Errorf("expect %v bearer token, got %v", e, a)
+	}
+
+	// Environment values take precedence over the shared config profile.
+	os.Setenv("CYBR_TENANT_NAME", "env-tenant")
+	os.Setenv("CYBR_BEARER_TOKEN", "env-token")
+
+	cfg, err = LoadDefaultConfig(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "env-tenant", cfg.TenantName; e != a {
+		t.Errorf("expect %v tenant name, got %v", e, a)
+	}
+	creds, err = cfg.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "env-token", creds.BearerToken; e != a {
+		t.Errorf("expect %v bearer token, got %v", e, a)
+	}
+
+	// Load options take precedence over the environment.
+	cfg, err = LoadDefaultConfig(context.Background(),
+		WithSharedConfigProfile("prod"),
+	)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "env-tenant", cfg.TenantName; e != a {
+		t.Errorf("expect %v tenant name, got %v", e, a)
+	}
+	if _, err = LoadDefaultConfig(context.Background(), WithSharedConfigProfile("missing")); err == nil {
+		t.Errorf("expect error for explicitly selected missing profile")
+	}
+}
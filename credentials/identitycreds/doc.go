@@ -0,0 +1,21 @@
+// Package identitycreds provides a credential provider that logs a user in to
+// CyberArk Identity interactively, answering the tenant's authentication
+// challenges, including multi-factor authentication mechanisms.
+//
+// The provider drives Identity's StartAuthentication and AdvanceAuthentication
+// state machine. For each challenge returned by the tenant the provider's
+// MFAPrompter selects the mechanism to use, e.g. password, OTP, email, SMS, or
+// mobile push, and supplies text answers for mechanisms which require them.
+// Out-of-band mechanisms, such as a mobile push or an email link, are polled
+// until the user has approved the login.
+//
+// The TerminalPrompter prompts the user on a terminal, and the
+// CallbackPrompter allows applications to supply their own user interface.
+//
+// The provider should be wrapped in a cybr.CredentialsCache so the user is
+// only prompted to log in again once the token has expired.
+//
+//	provider := identitycreds.New("https://abc1234.id.cyberark.cloud",
+//		"user@example.com", identitycreds.NewTerminalPrompter())
+//	creds := cybr.NewCredentialsCache(provider)
+package identitycreds
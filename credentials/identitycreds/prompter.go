@@ -0,0 +1,164 @@
+package identitycreds
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// MFAPrompter selects the mechanism used to satisfy each authentication
+// challenge, and provides the answers for mechanisms which require text
+// input from the user.
+type MFAPrompter interface {
+	// SelectMechanism returns the mechanism of the challenge the user will
+	// answer. The returned mechanism must be one of mechanisms.
+	SelectMechanism(ctx context.Context, mechanisms []Mechanism) (Mechanism, error)
+
+	// Prompt returns the user's answer for the mechanism. Prompt is only
+	// called for mechanisms with the AnswerTypeText and
+	// AnswerTypeStartTextOob answer types.
+	Prompt(ctx context.Context, mechanism Mechanism) (string, error)
+}
+
+// CallbackPrompter is an MFAPrompter that delegates to the callback
+// functions provided, allowing applications to supply their own user
+// interface.
+type CallbackPrompter struct {
+	// Selects the mechanism to answer. If nil the first mechanism of each
+	// challenge will be used.
+	SelectMechanismFunc func(ctx context.Context, mechanisms []Mechanism) (Mechanism, error)
+
+	// Returns the answer for the mechanism. Required.
+	PromptFunc func(ctx context.Context, mechanism Mechanism) (string, error)
+}
+
+// SelectMechanism returns the mechanism selected by SelectMechanismFunc, or
+// the first mechanism if SelectMechanismFunc is nil.
+func (p CallbackPrompter) SelectMechanism(ctx context.Context, mechanisms []Mechanism) (Mechanism, error) {
+	if p.SelectMechanismFunc != nil {
+		return p.SelectMechanismFunc(ctx, mechanisms)
+	}
+	if len(mechanisms) == 0 {
+		return Mechanism{}, fmt.Errorf("challenge has no mechanisms")
+	}
+	return mechanisms[0], nil
+}
+
+// Prompt returns the answer provided by PromptFunc.
+func (p CallbackPrompter) Prompt(ctx context.Context, mechanism Mechanism) (string, error) {
+	if p.PromptFunc == nil {
+		return "", fmt.Errorf("no prompt function provided for %s mechanism", mechanism.Name)
+	}
+	return p.PromptFunc(ctx, mechanism)
+}
+
+// TerminalPrompter is an MFAPrompter that prompts the user on a terminal.
+type TerminalPrompter struct {
+	// The reader answers are read from.
+	In io.Reader
+
+	// The writer prompts are written to.
+	Out io.Writer
+
+	// Reads answers, e.g. passwords and OTP codes, without echoing them to
+	// the terminal. If nil answers are read from In.
+	ReadSecret func() (string, error)
+
+	reader *bufio.Reader
+}
+
+// isTerminal and readPassword are values for detecting, and reading without
+// echo from, a terminal. These values can be overridden for testing.
+var (
+	isTerminal   = term.IsTerminal
+	readPassword = term.ReadPassword
+)
+
+// NewTerminalPrompter returns a TerminalPrompter that reads answers from
+// os.Stdin, and writes prompts to os.Stderr. If os.Stdin is a terminal,
+// answers are read without being echoed.
+func NewTerminalPrompter() *TerminalPrompter {
+	p := &TerminalPrompter{
+		In:  os.Stdin,
+		Out: os.Stderr,
+	}
+
+	if fd := int(os.Stdin.Fd()); isTerminal(fd) {
+		p.ReadSecret = func() (string, error) {
+			b, err := readPassword(fd)
+			if err != nil {
+				return "", fmt.Errorf("failed to read answer, %w", err)
+			}
+			return strings.TrimSpace(string(b)), nil
+		}
+	}
+
+	return p
+}
+
+// SelectMechanism prompts the user to select one of the mechanisms if the
+// challenge has more than one.
+func (p *TerminalPrompter) SelectMechanism(ctx context.Context, mechanisms []Mechanism) (Mechanism, error) {
+	if len(mechanisms) == 0 {
+		return Mechanism{}, fmt.Errorf("challenge has no mechanisms")
+	}
+
+	mech := mechanisms[0]
+	if len(mechanisms) > 1 {
+		for i, m := range mechanisms {
+			fmt.Fprintf(p.Out, "%d) %s\n", i+1, m.PromptSelectMech)
+		}
+		fmt.Fprint(p.Out, "Select an authentication method: ")
+
+		line, err := p.readLine()
+		if err != nil {
+			return Mechanism{}, err
+		}
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 1 || n > len(mechanisms) {
+			return Mechanism{}, fmt.Errorf("invalid authentication method selection %q", line)
+		}
+		mech = mechanisms[n-1]
+	}
+
+	// Out-of-band mechanisms are not prompted for, tell the user to approve
+	// the login.
+	if mech.AnswerType == AnswerTypeStartOob {
+		fmt.Fprintln(p.Out, mech.PromptMechChosen)
+	}
+
+	return mech, nil
+}
+
+// Prompt prompts the user for the mechanism's answer. Answers are read with
+// ReadSecret if set.
+func (p *TerminalPrompter) Prompt(ctx context.Context, mechanism Mechanism) (string, error) {
+	fmt.Fprintf(p.Out, "%s: ", mechanism.PromptMechChosen)
+
+	if p.ReadSecret != nil {
+		answer, err := p.ReadSecret()
+		fmt.Fprintln(p.Out)
+		return answer, err
+	}
+
+	return p.readLine()
+}
+
+func (p *TerminalPrompter) readLine() (string, error) {
+	if p.reader == nil {
+		p.reader = bufio.NewReader(p.In)
+	}
+
+	line, err := p.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || len(line) == 0) {
+		return "", fmt.Errorf("failed to read answer, %w", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,100 @@
+package identitycreds
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTerminalPrompter(t *testing.T) {
+	var out bytes.Buffer
+	secrets := []string{"password", "123456"}
+	p := &TerminalPrompter{
+		In:  strings.NewReader("2\n"),
+		Out: &out,
+		ReadSecret: func() (string, error) {
+			secret := secrets[0]
+			secrets = secrets[1:]
+			return secret, nil
+		},
+	}
+
+	answer, err := p.Prompt(context.Background(), Mechanism{Name: "UP", PromptMechChosen: "Enter Password"})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "password", answer; e != a {
+		t.Errorf("expect %v answer, got %v", e, a)
+	}
+
+	mech, err := p.SelectMechanism(context.Background(), []Mechanism{
+		{Name: "PF", PromptSelectMech: "Mobile App"},
+		{Name: "OTP", PromptSelectMech: "OATH OTP", PromptMechChosen: "Enter OTP"},
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "OTP", mech.Name; e != a {
+		t.Errorf("expect %v mechanism, got %v", e, a)
+	}
+
+	answer, err = p.Prompt(context.Background(), mech)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "123456", answer; e != a {
+		t.Errorf("expect %v answer, got %v", e, a)
+	}
+
+	for _, e := range []string{"Enter Password: ", "1) Mobile App", "2) OATH OTP", "Enter OTP: "} {
+		if a := out.String(); !strings.Contains(a, e) {
+			t.Errorf("expect output to contain %q, got %q", e, a)
+		}
+	}
+}
+
+func TestTerminalPrompter_InvalidSelection(t *testing.T) {
+	p := &TerminalPrompter{
+		In:  strings.NewReader("3\n"),
+		Out: &bytes.Buffer{},
+	}
+
+	_, err := p.SelectMechanism(context.Background(), []Mechanism{{Name: "PF"}, {Name: "OTP"}})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+}
+
+func TestNewTerminalPrompter(t *testing.T) {
+	origIsTerminal, origReadPassword := isTerminal, readPassword
+	defer func() { isTerminal, readPassword = origIsTerminal, origReadPassword }()
+
+	readPassword = func(fd int) ([]byte, error) {
+		if e, a := int(os.Stdin.Fd()), fd; e != a {
+			t.Errorf("expect %v fd, got %v", e, a)
+		}
+		return []byte("password\n"), nil
+	}
+
+	isTerminal = func(int) bool { return false }
+	if p := NewTerminalPrompter(); p.ReadSecret != nil {
+		t.Errorf("expect answers to be read from In when stdin is not a terminal")
+	}
+
+	isTerminal = func(int) bool { return true }
+	p := NewTerminalPrompter()
+	p.Out = &bytes.Buffer{}
+	if p.ReadSecret == nil {
+		t.Fatalf("expect answers to be read without echo when stdin is a terminal")
+	}
+
+	answer, err := p.Prompt(context.Background(), Mechanism{Name: "UP", PromptMechChosen: "Enter Password"})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "password", answer; e != a {
+		t.Errorf("expect %v answer, got %v", e, a)
+	}
+}
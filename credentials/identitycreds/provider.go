@@ -0,0 +1,421 @@
+package identitycreds
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrhttp "github.com/strick-j/cybr-sdk-go/cybr/transport/http"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
+// ProviderName is the name of the provider used to specify the source of
+// credentials.
+const ProviderName = "IdentityLoginProvider"
+
+const (
+	startAuthenticationPath   = "/Security/StartAuthentication"
+	advanceAuthenticationPath = "/Security/AdvanceAuthentication"
+
+	defaultPollInterval = 2 * time.Second
+)
+
+// Answer types of the authentication mechanisms returned by Identity.
+const (
+	// AnswerTypeText mechanisms are answered with text provided by the user,
+	// e.g. a password, OTP code, or security question answer.
+	AnswerTypeText = "Text"
+
+	// AnswerTypeStartTextOob mechanisms send the user a code out-of-band,
+	// e.g. by SMS or email, which is then answered with text provided by the
+	// user.
+	AnswerTypeStartTextOob = "StartTextOob"
+
+	// AnswerTypeStartOob mechanisms are approved by the user out-of-band,
+	// e.g. a mobile push notification, and are polled until approved.
+	AnswerTypeStartOob = "StartOob"
+)
+
+// Summaries of the authentication state returned by Identity.
+const (
+	summaryLoginSuccess       = "LoginSuccess"
+	summaryStartNextChallenge = "StartNextChallenge"
+	summaryNewPackage         = "NewPackage"
+	summaryOobPending         = "OobPending"
+)
+
+// Actions used to advance the authentication of a mechanism.
+const (
+	actionAnswer   = "Answer"
+	actionStartOOB = "StartOOB"
+	actionPoll     = "Poll"
+)
+
+// Mechanism is an authentication mechanism a user can use to satisfy a
+// challenge.
+type Mechanism struct {
+	// The name of the mechanism, e.g. UP, OTP, EMAIL, SMS, or PF.
+	Name string
+
+	// The ID of the mechanism within the authentication session.
+	MechanismID string `json:"MechanismId"`
+
+	// How the mechanism is answered. See AnswerTypeText,
+	// AnswerTypeStartTextOob, and AnswerTypeStartOob.
+	AnswerType string
+
+	// The prompt to display when selecting from the challenge's mechanisms.
+	PromptSelectMech string
+
+	// The prompt to display once the mechanism has been selected.
+	PromptMechChosen string
+}
+
+// Challenge is a step of the authentication that must be satisfied by
+// answering one of its mechanisms.
+type Challenge struct {
+	Mechanisms []Mechanism
+}
+
+// Options is the configuration options for the Identity login provider.
+type Options struct {
+	// The Identity tenant URL the user will log in to, e.g.
+	// https://abc1234.id.cyberark.cloud
+	IdentityURL string
+
+	// The name of the user logging in.
+	Username string
+
+	// The Identity tenant ID, sent with the start of the authentication.
+	// Optional.
+	TenantID string
+
+	// The prompter used to select mechanisms and provide answers for the
+	// challenges.
+	Prompter MFAPrompter
+
+	// The interval between polls of out-of-band mechanisms. If zero the
+	// provider will poll every 2 seconds.
+	PollInterval time.Duration
+
+	// The duration the returned credentials are valid for if the expiry can
+	// not be read from the token's exp claim. If zero the credentials will not
+	// expire.
+	DefaultTokenTTL time.Duration
+
+	// The HTTP client the provider will use to authenticate. If nil a default
+	// HTTP client will be used.
+	HTTPClient cybr.HTTPClient
+}
+
+// Provider is a credentials provider that logs a user in to CyberArk
+// Identity, prompting the user to answer the tenant's authentication
+// challenges.
+//
+// The Provider is not safe for concurrent use without a
+// cybr.CredentialsCache wrapping it.
+type Provider struct {
+	options Options
+}
+
+// New returns an initialized Provider that will log username in to the
+// Identity tenant at identityURL, using prompter to answer the challenges. A
+// variadic list of functional options can be provided to modify the
+// Provider's Options.
+func New(identityURL, username string, prompter MFAPrompter, optFns ...func(*Options)) *Provider {
+	options := Options{
+		IdentityURL: identityURL,
+		Username:    username,
+		Prompter:    prompter,
+	}
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	if options.HTTPClient == nil {
+		options.HTTPClient = cybrhttp.NewBuildableClient()
+	}
+	if options.PollInterval == 0 {
+		options.PollInterval = defaultPollInterval
+	}
+
+	return &Provider{
+		options: options,
+	}
+}
+
+// authResponse is the envelope of the Identity authentication responses.
+type authResponse struct {
+	Success   bool       `json:"success"`
+	Result    authResult `json:"Result"`
+	Message   string     `json:"Message"`
+	ErrorCode string     `json:"ErrorCode"`
+}
+
+type authResult struct {
+	SessionID  string      `json:"SessionId"`
+	PodFqdn    string      `json:"PodFqdn"`
+	Challenges []Challenge `json:"Challenges"`
+	Summary    string      `json:"Summary"`
+	Token      string      `json:"Token"`
+}
+
+type startAuthenticationRequest struct {
+	TenantID string `json:"TenantId,omitempty"`
+	User     string `json:"User"`
+	Version  string `json:"Version"`
+}
+
+type advanceAuthenticationRequest struct {
+	SessionID   string `json:"SessionId"`
+	MechanismID string `json:"MechanismId"`
+	Action      string `json:"Action"`
+	Answer      string `json:"Answer,omitempty"`
+}
+
+// Retrieve logs the user in to the Identity tenant, prompting for the
+// answers to each challenge, and returns the resulting bearer token.
+func (p *Provider) Retrieve(ctx context.Context) (cybr.Credentials, error) {
+	if len(p.options.IdentityURL) == 0 {
+		return cybr.Credentials{}, fmt.Errorf("identity URL is required")
+	}
+	if len(p.options.Username) == 0 {
+		return cybr.Credentials{}, fmt.Errorf("username is required")
+	}
+	if p.options.Prompter == nil {
+		return cybr.Credentials{}, fmt.Errorf("MFA prompter is required")
+	}
+
+	baseURL := strings.TrimRight(p.options.IdentityURL, "/")
+	result, err := p.startAuthentication(ctx, baseURL)
+	if err != nil {
+		return cybr.Credentials{}, err
+	}
+
+	// The user may belong to a different pod than the tenant URL used,
+	// restart the authentication against the user's pod.
+	if len(result.PodFqdn) != 0 && len(result.Challenges) == 0 {
+		baseURL = "https://" + result.PodFqdn
+		if result, err = p.startAuthentication(ctx, baseURL); err != nil {
+			return cybr.Credentials{}, err
+		}
+	}
+
+	token, err := p.answerChallenges(ctx, baseURL, result)
+	if err != nil {
+		return cybr.Credentials{}, err
+	}
+
+	creds := cybr.Credentials{
+		BearerToken: token,
+		Source:      ProviderName,
+	}
+	if expires, ok := tokenExpiry(token); ok {
+		creds.CanExpire = true
+		creds.Expires = expires
+	} else if p.options.DefaultTokenTTL > 0 {
+		creds.CanExpire = true
+		creds.Expires = sdk.NowTime().Add(p.options.DefaultTokenTTL)
+	}
+
+	return creds, nil
+}
+
+func (p *Provider) startAuthentication(ctx context.Context, baseURL string) (authResult, error) {
+	return p.do(ctx, baseURL+startAuthenticationPath, startAuthenticationRequest{
+		TenantID: p.options.TenantID,
+		User:     p.options.Username,
+		Version:  "1.0",
+	})
+}
+
+// answerChallenges answers each of the authentication session's challenges
+// in order until the tenant reports the login succeeded.
+func (p *Provider) answerChallenges(ctx context.Context, baseURL string, result authResult) (string, error) {
+	if result.Summary == summaryLoginSuccess {
+		return result.Token, nil
+	}
+
+	sessionID := result.SessionID
+	challenges := result.Challenges
+
+	for i := 0; i < len(challenges); i++ {
+		mech, err := p.options.Prompter.SelectMechanism(ctx, challenges[i].Mechanisms)
+		if err != nil {
+			return "", fmt.Errorf("failed to select authentication mechanism, %w", err)
+		}
+
+		res, err := p.answerMechanism(ctx, baseURL, sessionID, mech)
+		if err != nil {
+			return "", err
+		}
+
+		switch res.Summary {
+		case summaryLoginSuccess:
+			return res.Token, nil
+		case summaryStartNextChallenge:
+		case summaryNewPackage:
+			// The tenant has replaced the remaining challenges, e.g. after
+			// the user was identified by their first answer.
+			challenges = res.Challenges
+			i = -1
+		default:
+			return "", &AuthenticationError{
+				Message: fmt.Sprintf("unexpected authentication state %q", res.Summary),
+			}
+		}
+	}
+
+	return "", &AuthenticationError{Message: "authentication challenges exhausted without login success"}
+}
+
+// answerMechanism advances the authentication session using the selected
+// mechanism, prompting for its answer or polling until it is approved.
+func (p *Provider) answerMechanism(ctx context.Context, baseURL, sessionID string, mech Mechanism) (authResult, error) {
+	advance := func(action, answer string) (authResult, error) {
+		return p.do(ctx, baseURL+advanceAuthenticationPath, advanceAuthenticationRequest{
+			SessionID:   sessionID,
+			MechanismID: mech.MechanismID,
+			Action:      action,
+			Answer:      answer,
+		})
+	}
+
+	switch mech.AnswerType {
+	case AnswerTypeText:
+		answer, err := p.options.Prompter.Prompt(ctx, mech)
+		if err != nil {
+			return authResult{}, fmt.Errorf("failed to prompt for %s answer, %w", mech.Name, err)
+		}
+		return advance(actionAnswer, answer)
+
+	case AnswerTypeStartTextOob:
+		if _, err := advance(actionStartOOB, ""); err != nil {
+			return authResult{}, err
+		}
+		answer, err := p.options.Prompter.Prompt(ctx, mech)
+		if err != nil {
+			return authResult{}, fmt.Errorf("failed to prompt for %s answer, %w", mech.Name, err)
+		}
+		return advance(actionAnswer, answer)
+
+	case AnswerTypeStartOob:
+		res, err := advance(actionStartOOB, "")
+		if err != nil {
+			return authResult{}, err
+		}
+		for res.Summary == summaryOobPending {
+			if err := sdk.SleepWithContext(ctx, p.options.PollInterval); err != nil {
+				return authResult{}, err
+			}
+			if res, err = advance(actionPoll, ""); err != nil {
+				return authResult{}, err
+			}
+		}
+		return res, nil
+
+	default:
+		return authResult{}, fmt.Errorf("unsupported answer type %q for mechanism %s", mech.AnswerType, mech.Name)
+	}
+}
+
+// do sends the authentication request body to the URL, and decodes the
+// authentication response.
+func (p *Provider) do(ctx context.Context, url string, body interface{}) (authResult, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return authResult{}, fmt.Errorf("failed to encode authentication request, %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return authResult{}, fmt.Errorf("failed to create authentication request, %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-IDAP-NATIVE-CLIENT", "true")
+
+	resp, err := p.options.HTTPClient.Do(req)
+	if err != nil {
+		return authResult{}, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return authResult{}, fmt.Errorf("failed to read authentication response, %w", err)
+	}
+
+	var authResp authResponse
+	if err := json.Unmarshal(respBody, &authResp); err != nil && resp.StatusCode < 300 {
+		return authResult{}, fmt.Errorf("failed to decode authentication response, %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || !authResp.Success {
+		return authResult{}, &AuthenticationError{
+			StatusCode: resp.StatusCode,
+			ErrorCode:  authResp.ErrorCode,
+			Message:    authResp.Message,
+		}
+	}
+
+	return authResp.Result, nil
+}
+
+// tokenExpiry returns the expiry of the JWT bearer token from its exp claim.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(claims.Exp, 0), true
+}
+
+// AuthenticationError is the error returned when Identity rejects the
+// authentication, or the login can not be completed.
+type AuthenticationError struct {
+	// The HTTP status code of the response, if the error was returned by the
+	// tenant.
+	StatusCode int
+
+	// The error code, and message returned by the tenant.
+	ErrorCode string
+	Message   string
+}
+
+// Error returns the error message.
+func (e *AuthenticationError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("identity authentication failed")
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&sb, ", status code: %d", e.StatusCode)
+	}
+	if len(e.ErrorCode) != 0 {
+		fmt.Fprintf(&sb, ", %s", e.ErrorCode)
+	}
+	if len(e.Message) != 0 {
+		fmt.Fprintf(&sb, ", %s", e.Message)
+	}
+	return sb.String()
+}
@@ -0,0 +1,243 @@
+package identitycreds
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
+func testToken(exp int64) string {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"user","exp":%d}`, exp)))
+	return "eyJhbGciOiJSUzI1NiJ9." + claims + ".signature"
+}
+
+// fakeIdentity is a stand-in for the Identity authentication API. The user
+// must answer a password challenge, then either approve a push notification
+// or answer an OTP code.
+type fakeIdentity struct {
+	t         *testing.T
+	token     string
+	pollsLeft int
+	requests  []advanceAuthenticationRequest
+}
+
+func (f *fakeIdentity) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if e, a := "true", r.Header.Get("X-IDAP-NATIVE-CLIENT"); e != a {
+		f.t.Errorf("expect %v native client header, got %v", e, a)
+	}
+
+	var result interface{}
+	switch r.URL.Path {
+	case startAuthenticationPath:
+		var req startAuthenticationRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if e, a := "user@example.com", req.User; e != a {
+			f.t.Errorf("expect %v user, got %v", e, a)
+		}
+		result = authResult{
+			SessionID: "session",
+			Challenges: []Challenge{
+				{Mechanisms: []Mechanism{
+					{Name: "UP", MechanismID: "up", AnswerType: AnswerTypeText, PromptMechChosen: "Enter Password"},
+				}},
+				{Mechanisms: []Mechanism{
+					{Name: "PF", MechanismID: "push", AnswerType: AnswerTypeStartOob, PromptSelectMech: "Mobile App"},
+					{Name: "OTP", MechanismID: "otp", AnswerType: AnswerTypeText, PromptSelectMech: "OATH OTP"},
+				}},
+			},
+		}
+
+	case advanceAuthenticationPath:
+		var req advanceAuthenticationRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		f.requests = append(f.requests, req)
+
+		switch {
+		case req.MechanismID == "up" && req.Answer == "password":
+			result = authResult{Summary: summaryStartNextChallenge}
+		case req.MechanismID == "otp" && req.Answer == "123456":
+			result = authResult{Summary: summaryLoginSuccess, Token: f.token}
+		case req.MechanismID == "push" && req.Action == actionStartOOB:
+			result = authResult{Summary: summaryOobPending}
+		case req.MechanismID == "push" && req.Action == actionPoll:
+			if f.pollsLeft > 0 {
+				f.pollsLeft--
+				result = authResult{Summary: summaryOobPending}
+			} else {
+				result = authResult{Summary: summaryLoginSuccess, Token: f.token}
+			}
+		default:
+			json.NewEncoder(w).Encode(authResponse{Success: false, Message: "Authentication (login or challenge) has failed."})
+			return
+		}
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"Result":  result,
+	})
+}
+
+func TestProvider_Retrieve(t *testing.T) {
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+
+	exp := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		Mechanism      string
+		Answers        map[string]string
+		ExpectRequests []advanceAuthenticationRequest
+	}{
+		"password and OTP": {
+			Mechanism: "OTP",
+			Answers:   map[string]string{"UP": "password", "OTP": "123456"},
+			ExpectRequests: []advanceAuthenticationRequest{
+				{SessionID: "session", MechanismID: "up", Action: actionAnswer, Answer: "password"},
+				{SessionID: "session", MechanismID: "otp", Action: actionAnswer, Answer: "123456"},
+			},
+		},
+		"password and push": {
+			Mechanism: "PF",
+			Answers:   map[string]string{"UP": "password"},
+			ExpectRequests: []advanceAuthenticationRequest{
+				{SessionID: "session", MechanismID: "up", Action: actionAnswer, Answer: "password"},
+				{SessionID: "session", MechanismID: "push", Action: actionStartOOB},
+				{SessionID: "session", MechanismID: "push", Action: actionPoll},
+				{SessionID: "session", MechanismID: "push", Action: actionPoll},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			fake := &fakeIdentity{t: t, token: testToken(exp.Unix()), pollsLeft: 1}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			prompter := CallbackPrompter{
+				SelectMechanismFunc: func(ctx context.Context, mechanisms []Mechanism) (Mechanism, error) {
+					for _, m := range mechanisms {
+						if m.Name == "UP" || m.Name == c.Mechanism {
+							return m, nil
+						}
+					}
+					return Mechanism{}, fmt.Errorf("mechanism not found")
+				},
+				PromptFunc: func(ctx context.Context, m Mechanism) (string, error) {
+					return c.Answers[m.Name], nil
+				},
+			}
+
+			p := New(server.URL, "user@example.com", prompter)
+			creds, err := p.Retrieve(context.Background())
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := fake.token, creds.BearerToken; e != a {
+				t.Errorf("expect %v token, got %v", e, a)
+			}
+			if e, a := ProviderName, creds.Source; e != a {
+				t.Errorf("expect %v source, got %v", e, a)
+			}
+			if !creds.CanExpire {
+				t.Errorf("expect credentials to expire")
+			}
+			if e, a := exp, creds.Expires; !e.Equal(a) {
+				t.Errorf("expect %v expires, got %v", e, a)
+			}
+
+			if e, a := len(c.ExpectRequests), len(fake.requests); e != a {
+				t.Fatalf("expect %v requests, got %v", e, a)
+			}
+			for i, e := range c.ExpectRequests {
+				if a := fake.requests[i]; e != a {
+					t.Errorf("expect %v request, got %v", e, a)
+				}
+			}
+		})
+	}
+}
+
+func TestProvider_RetrieveFailedAnswer(t *testing.T) {
+	server := httptest.NewServer(&fakeIdentity{t: t})
+	defer server.Close()
+
+	p := New(server.URL, "user@example.com", CallbackPrompter{
+		PromptFunc: func(ctx context.Context, m Mechanism) (string, error) {
+			return "wrong", nil
+		},
+	})
+	_, err := p.Retrieve(context.Background())
+
+	var authErr *AuthenticationError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expect %T error, got %v", authErr, err)
+	}
+	if e, a := "Authentication (login or challenge) has failed.", authErr.Message; e != a {
+		t.Errorf("expect %v message, got %v", e, a)
+	}
+}
+
+func TestProvider_DefaultTokenTTL(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	defer restoreTime()
+
+	fake := &fakeIdentity{t: t, token: "opaque-token"}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	p := New(server.URL, "user@example.com", CallbackPrompter{
+		SelectMechanismFunc: func(ctx context.Context, mechanisms []Mechanism) (Mechanism, error) {
+			return mechanisms[len(mechanisms)-1], nil
+		},
+		PromptFunc: func(ctx context.Context, m Mechanism) (string, error) {
+			return map[string]string{"UP": "password", "OTP": "123456"}[m.Name], nil
+		},
+	}, func(o *Options) {
+		o.DefaultTokenTTL = time.Hour
+	})
+
+	creds, err := p.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := time.Date(2024, 1, 2, 4, 4, 5, 0, time.UTC), creds.Expires; !e.Equal(a) {
+		t.Errorf("expect %v expires, got %v", e, a)
+	}
+}
+
+func TestProvider_PollCanceled(t *testing.T) {
+	fake := &fakeIdentity{t: t, pollsLeft: 1000}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	p := New(server.URL, "user@example.com", CallbackPrompter{
+		PromptFunc: func(ctx context.Context, m Mechanism) (string, error) {
+			return "password", nil
+		},
+	}, func(o *Options) {
+		o.PollInterval = 10 * time.Millisecond
+	})
+
+	_, err := p.Retrieve(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expect deadline exceeded error, got %v", err)
+	}
+}
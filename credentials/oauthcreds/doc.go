@@ -0,0 +1,16 @@
+// Package oauthcreds provides a credential provider that retrieves bearer
+// tokens from CyberArk Identity using the OAuth2 client credentials grant.
+//
+// The provider exchanges a service user's client ID and secret for a bearer
+// token. By default the token is requested from the tenant's platform token
+// endpoint, /oauth2/platformtoken. If an OAuth application ID is configured,
+// the token is requested from the custom OAuth application's token endpoint,
+// /oauth2/token/{appId}, instead.
+//
+// The provider should be wrapped in a cybr.CredentialsCache so the token is
+// reused until it expires.
+//
+//	provider := oauthcreds.New("https://abc1234.id.cyberark.cloud",
+//		"service-user@example.com", "client-secret")
+//	creds := cybr.NewCredentialsCache(provider)
+package oauthcreds
@@ -0,0 +1,224 @@
+package oauthcreds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrhttp "github.com/strick-j/cybr-sdk-go/cybr/transport/http"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
+// ProviderName is the name of the provider used to specify the source of
+// credentials.
+const ProviderName = "OAuthClientCredentialsProvider"
+
+const (
+	platformTokenPath = "/oauth2/platformtoken"
+	appTokenPath      = "/oauth2/token/"
+)
+
+// Options is the configuration options for the OAuth client credentials
+// provider.
+type Options struct {
+	// The Identity tenant URL tokens will be requested from, e.g.
+	// https://abc1234.id.cyberark.cloud
+	IdentityURL string
+
+	// The client ID of the service user.
+	ClientID string
+
+	// The client secret of the service user.
+	ClientSecret string
+
+	// The ID of a custom OAuth application. If set, tokens will be requested
+	// from the application's token endpoint, /oauth2/token/{AppID}, instead
+	// of the platform token endpoint.
+	AppID string
+
+	// The scope to request for the token. Only used when AppID is set.
+	Scope string
+
+	// The HTTP client the provider will use to request tokens. If nil a
+	// default HTTP client will be used.
+	HTTPClient cybr.HTTPClient
+}
+
+// Provider is a credentials provider that retrieves bearer tokens by
+// exchanging a service user's client ID and secret with CyberArk Identity.
+//
+// The Provider is not safe for concurrent use without a
+// cybr.CredentialsCache wrapping it.
+type Provider struct {
+	options Options
+}
+
+// New returns an initialized Provider that will exchange the client ID and
+// secret with the Identity tenant at identityURL. A variadic list of
+// functional options can be provided to modify the Provider's Options.
+func New(identityURL, clientID, clientSecret string, optFns ...func(*Options)) *Provider {
+	options := Options{
+		IdentityURL:  identityURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	if options.HTTPClient == nil {
+		options.HTTPClient = cybrhttp.NewBuildableClient()
+	}
+
+	return &Provider{
+		options: options,
+	}
+}
+
+// tokenResponse is the OAuth2 token endpoint's response body.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// errorResponse is the OAuth2 token endpoint's error response body.
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Retrieve exchanges the client ID and secret for a bearer token. The
+// returned credentials expire based on the token's expires_in value.
+func (p *Provider) Retrieve(ctx context.Context) (cybr.Credentials, error) {
+	if len(p.options.IdentityURL) == 0 {
+		return cybr.Credentials{}, &RetrieveError{Err: fmt.Errorf("identity URL is required")}
+	}
+	if len(p.options.ClientID) == 0 || len(p.options.ClientSecret) == 0 {
+		return cybr.Credentials{}, &RetrieveError{Err: fmt.Errorf("client ID and client secret are required")}
+	}
+
+	req, err := p.newTokenRequest(ctx)
+	if err != nil {
+		return cybr.Credentials{}, &RetrieveError{Err: err}
+	}
+
+	resp, err := p.options.HTTPClient.Do(req)
+	if err != nil {
+		return cybr.Credentials{}, &RetrieveError{Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return cybr.Credentials{}, &RetrieveError{Err: fmt.Errorf("failed to read token response, %w", err)}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResp errorResponse
+		_ = json.Unmarshal(body, &errResp)
+		return cybr.Credentials{}, &RetrieveError{
+			StatusCode:       resp.StatusCode,
+			ErrorCode:        errResp.Error,
+			ErrorDescription: errResp.ErrorDescription,
+		}
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return cybr.Credentials{}, &RetrieveError{Err: fmt.Errorf("failed to decode token response, %w", err)}
+	}
+	if len(token.AccessToken) == 0 {
+		return cybr.Credentials{}, &RetrieveError{Err: fmt.Errorf("token response did not contain an access token")}
+	}
+
+	creds := cybr.Credentials{
+		BearerToken: token.AccessToken,
+		Source:      ProviderName,
+	}
+	if token.ExpiresIn > 0 {
+		creds.CanExpire = true
+		creds.Expires = sdk.NowTime().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	return creds, nil
+}
+
+// newTokenRequest builds the HTTP request for the token endpoint. The
+// platform token endpoint accepts the client credentials in the form body,
+// whereas custom OAuth applications accept them with basic authentication.
+func (p *Provider) newTokenRequest(ctx context.Context) (*http.Request, error) {
+	endpoint := strings.TrimRight(p.options.IdentityURL, "/")
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+
+	if len(p.options.AppID) == 0 {
+		endpoint += platformTokenPath
+		form.Set("client_id", p.options.ClientID)
+		form.Set("client_secret", p.options.ClientSecret)
+	} else {
+		endpoint += appTokenPath + url.PathEscape(p.options.AppID)
+		if len(p.options.Scope) != 0 {
+			form.Set("scope", p.options.Scope)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request, %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if len(p.options.AppID) != 0 {
+		req.SetBasicAuth(p.options.ClientID, p.options.ClientSecret)
+	}
+
+	return req, nil
+}
+
+// RetrieveError is the error returned when the provider fails to retrieve a
+// bearer token from the Identity tenant.
+type RetrieveError struct {
+	// The HTTP status code of the token endpoint's response, if a response
+	// was received.
+	StatusCode int
+
+	// The OAuth2 error code, and description returned by the token endpoint.
+	ErrorCode        string
+	ErrorDescription string
+
+	Err error
+}
+
+// Error returns the error message.
+func (e *RetrieveError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("failed to retrieve OAuth client credentials")
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&sb, ", status code: %d", e.StatusCode)
+	}
+	if len(e.ErrorCode) != 0 {
+		fmt.Fprintf(&sb, ", %s", e.ErrorCode)
+	}
+	if len(e.ErrorDescription) != 0 {
+		fmt.Fprintf(&sb, ": %s", e.ErrorDescription)
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&sb, ", %v", e.Err)
+	}
+	return sb.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *RetrieveError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,168 @@
+package oauthcreds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
+func TestProvider_PlatformToken(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	defer restoreTime()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "/oauth2/platformtoken", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		if e, a := http.MethodPost, r.Method; e != a {
+			t.Errorf("expect %v method, got %v", e, a)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		for k, e := range map[string]string{
+			"grant_type":    "client_credentials",
+			"client_id":     "service-user@example.com",
+			"client_secret": "secret",
+		} {
+			if a := r.PostForm.Get(k); e != a {
+				t.Errorf("expect %v form %v, got %v", k, e, a)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":900}`))
+	}))
+	defer server.Close()
+
+	p := New(server.URL, "service-user@example.com", "secret")
+	creds, err := p.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := cybr.Credentials{
+		BearerToken: "token",
+		Source:      ProviderName,
+		CanExpire:   true,
+		Expires:     time.Date(2024, 1, 2, 3, 19, 5, 0, time.UTC),
+	}
+	if e, a := expect, creds; e != a {
+		t.Errorf("expect %v credentials, got %v", e, a)
+	}
+}
+
+func TestProvider_AppToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "/oauth2/token/my-app", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "service-user@example.com" || pass != "secret" {
+			t.Errorf("expect basic auth credentials, got %v, %v, %v", user, pass, ok)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if e, a := "api", r.PostForm.Get("scope"); e != a {
+			t.Errorf("expect %v scope, got %v", e, a)
+		}
+		if a := r.PostForm.Get("client_secret"); len(a) != 0 {
+			t.Errorf("expect client secret to not be in form body, got %v", a)
+		}
+
+		w.Write([]byte(`{"access_token":"app-token","token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	p := New(server.URL+"/", "service-user@example.com", "secret", func(o *Options) {
+		o.AppID = "my-app"
+		o.Scope = "api"
+	})
+	creds, err := p.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "app-token", creds.BearerToken; e != a {
+		t.Errorf("expect %v token, got %v", e, a)
+	}
+	if creds.CanExpire {
+		t.Errorf("expect credentials without expires_in to not expire")
+	}
+}
+
+func TestProvider_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"unauthorized_client","error_description":"invalid client creds"}`))
+	}))
+	defer server.Close()
+
+	p := New(server.URL, "service-user@example.com", "wrong")
+	_, err := p.Retrieve(context.Background())
+
+	var retrieveErr *RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		t.Fatalf("expect %T error, got %v", retrieveErr, err)
+	}
+	if e, a := http.StatusBadRequest, retrieveErr.StatusCode; e != a {
+		t.Errorf("expect %v status code, got %v", e, a)
+	}
+	if e, a := "unauthorized_client", retrieveErr.ErrorCode; e != a {
+		t.Errorf("expect %v error code, got %v", e, a)
+	}
+	if e, a := "invalid client creds", retrieveErr.ErrorDescription; e != a {
+		t.Errorf("expect %v error description, got %v", e, a)
+	}
+}
+
+func TestProvider_CredentialsCache(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":900}`))
+	}))
+	defer server.Close()
+
+	cache := cybr.NewCredentialsCache(New(server.URL, "service-user@example.com", "secret"))
+	for i := 0; i < 3; i++ {
+		if _, err := cache.Retrieve(context.Background()); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+	if e, a := 1, calls; e != a {
+		t.Errorf("expect %v token requests, got %v", e, a)
+	}
+
+	cache.Invalidate()
+	if _, err := cache.Retrieve(context.Background()); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := 2, calls; e != a {
+		t.Errorf("expect %v token requests, got %v", e, a)
+	}
+}
+
+func TestProvider_MissingOptions(t *testing.T) {
+	cases := map[string]*Provider{
+		"identity URL":  New("", "id", "secret"),
+		"client ID":     New("https://example.com", "", "secret"),
+		"client secret": New("https://example.com", "id", ""),
+	}
+
+	for name, p := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := p.Retrieve(context.Background())
+			var retrieveErr *RetrieveError
+			if !errors.As(err, &retrieveErr) {
+				t.Fatalf("expect %T error, got %v", retrieveErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Package ssocreds provides a credential provider for retrieving bearer
+// tokens from the SSO token cache.
+//
+// The SSO token cache is populated by logging in to the organization's
+// Identity tenant with Login. Login opens the OIDC application's authorize
+// endpoint in the user's browser using the authorization code flow with PKCE,
+// receiving the authorization code on a loopback redirect listener. Hosts
+// without a browser can log in with the device authorization flow instead.
+//
+//	err := ssocreds.Login(context.TODO(), ssocreds.LoginOptions{
+//		StartURL:    "https://example.cyberark.cloud",
+//		IdentityURL: "https://abc1234.id.cyberark.cloud",
+//		AppID:       "my-oidc-app",
+//		ClientID:    "client-id",
+//	})
+//
+// The cached token is stored as a JSON file in the user's home
+// directory, named after the SHA1 hash of the portal's start URL.
+//
+//	~/.cybr/sso/cache/<sha1-hex-encoded-startURL>.json
+//
+// The cached token file contains the access token and its expiry, and
+// optionally a refresh token, along with the OAuth client ID and token
+// endpoint used to refresh it.
+//
+//	{
+//		"accessToken": "eyJhbGciOi...",
+//		"expiresAt": "2024-01-02T15:00:00Z",
+//		"refreshToken": "...",
+//		"clientId": "...",
+//		"tokenEndpoint": "https://abc1234.id.cyberark.cloud/oauth2/token/app",
+//		"startUrl": "https://example.cyberark.cloud"
+//	}
+//
+// If the access token has expired the provider will refresh it, and write the
+// refreshed token back to the cache file. If the token can not be refreshed
+// the provider returns an InvalidTokenError, and the user must log in again.
+//
+//	provider := ssocreds.New(ssocreds.Options{
+//		StartURL: "https://example.cyberark.cloud",
+//	})
+//	creds := cybr.NewCredentialsCache(provider)
+package ssocreds
@@ -0,0 +1,233 @@
+package ssocreds
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
+var osUserHomeDir = os.UserHomeDir
+
+// StandardCachedTokenFilepath returns the filepath for the cached SSO token
+// file for the start URL, or error if unable get derive the path. Key that
+// will be used to compute a SHA1 value that is hex encoded.
+//
+// Derives the filepath using the Key as:
+//
+//	~/.cybr/sso/cache/<sha1-hex-encoded-key>.json
+func StandardCachedTokenFilepath(key string) (string, error) {
+	homeDir, err := osUserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to get USER's home directory for cached token, %w", err)
+	}
+	hash := sha1.New()
+	if _, err := hash.Write([]byte(key)); err != nil {
+		return "", fmt.Errorf("unable to compute cached token filepath key SHA1 hash, %w", err)
+	}
+
+	cacheFilename := strings.ToLower(hex.EncodeToString(hash.Sum(nil))) + ".json"
+
+	return filepath.Join(homeDir, ".cybr", "sso", "cache", cacheFilename), nil
+}
+
+// token is the contents of the cached SSO token file. Fields the SDK does not
+// know are preserved when the token is written back to the file.
+type token struct {
+	tokenKnownFields
+	UnknownFields map[string]interface{} `json:"-"`
+}
+
+func (t token) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{}
+
+	setTokenFieldString(fields, "accessToken", t.AccessToken)
+	setTokenFieldTime(fields, "expiresAt", t.ExpiresAt)
+	setTokenFieldString(fields, "refreshToken", t.RefreshToken)
+	setTokenFieldString(fields, "clientId", t.ClientID)
+	setTokenFieldString(fields, "tokenEndpoint", t.TokenEndpoint)
+	setTokenFieldString(fields, "startUrl", t.StartURL)
+
+	for k, v := range t.UnknownFields {
+		if _, ok := fields[k]; ok {
+			return nil, fmt.Errorf("unknown token field %v, duplicates known field", k)
+		}
+		fields[k] = v
+	}
+
+	return json.Marshal(fields)
+}
+
+func setTokenFieldString(fields map[string]interface{}, key, value string) {
+	if value == "" {
+		return
+	}
+	fields[key] = value
+}
+
+func setTokenFieldTime(fields map[string]interface{}, key string, value time.Time) {
+	if value.IsZero() {
+		return
+	}
+	fields[key] = value.UTC().Format(time.RFC3339)
+}
+
+func (t *token) UnmarshalJSON(b []byte) error {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+
+	t.UnknownFields = map[string]interface{}{}
+
+	for k, v := range fields {
+		var err error
+		switch k {
+		case "accessToken":
+			err = getTokenFieldString(v, &t.AccessToken)
+		case "expiresAt":
+			err = getTokenFieldTime(v, &t.ExpiresAt)
+		case "refreshToken":
+			err = getTokenFieldString(v, &t.RefreshToken)
+		case "clientId":
+			err = getTokenFieldString(v, &t.ClientID)
+		case "tokenEndpoint":
+			err = getTokenFieldString(v, &t.TokenEndpoint)
+		case "startUrl":
+			err = getTokenFieldString(v, &t.StartURL)
+		default:
+			t.UnknownFields[k] = v
+		}
+
+		if err != nil {
+			return fmt.Errorf("field %q, %w", k, err)
+		}
+	}
+
+	return nil
+}
+
+func getTokenFieldString(v interface{}, value *string) error {
+	var ok bool
+	*value, ok = v.(string)
+	if !ok {
+		return fmt.Errorf("expect value to be string, got %T", v)
+	}
+	return nil
+}
+
+func getTokenFieldTime(v interface{}, value *time.Time) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("expect value to be string, got %T", v)
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	*value = t.UTC()
+	return nil
+}
+
+// tokenKnownFields are the fields of the cached SSO token file the SDK uses.
+type tokenKnownFields struct {
+	AccessToken string
+	ExpiresAt   time.Time
+
+	// The refresh token, and the OAuth client and token endpoint it was
+	// issued by. Used to refresh the access token once it has expired.
+	RefreshToken  string
+	ClientID      string
+	TokenEndpoint string
+
+	StartURL string
+}
+
+// defaultTokenTTL is the lifetime of an access token if the token endpoint's
+// response does not include the token's lifetime.
+const defaultTokenTTL = 1 * time.Hour
+
+// tokenExpiresAt returns the expiry of an access token issued now with the
+// lifetime in seconds. defaultTokenTTL is used if the lifetime is not known.
+func tokenExpiresAt(expiresIn int64) time.Time {
+	ttl := time.Duration(expiresIn) * time.Second
+	if ttl <= 0 {
+		ttl = defaultTokenTTL
+	}
+	return sdk.NowTime().Add(ttl).UTC()
+}
+
+func (t token) Expired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// loadCachedToken reads and decodes the cached SSO token file.
+func loadCachedToken(filename string) (token, error) {
+	fileBytes, err := os.ReadFile(filename)
+	if err != nil {
+		return token{}, fmt.Errorf("failed to read cached SSO token file, %w", err)
+	}
+
+	var t token
+	if err := json.Unmarshal(fileBytes, &t); err != nil {
+		return token{}, fmt.Errorf("failed to parse cached SSO token file, %w", err)
+	}
+
+	if len(t.AccessToken) == 0 || t.ExpiresAt.IsZero() {
+		return token{}, fmt.Errorf(
+			"cached SSO token must contain accessToken and expiresAt fields")
+	}
+
+	return t, nil
+}
+
+// storeCachedToken encodes the token and writes it to the cached SSO token
+// file. The token is written to a temporary file which replaces the cache
+// file once complete, so readers never observe a partially written token.
+func storeCachedToken(filename string, t token, fileMode os.FileMode) (err error) {
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cached SSO token directory, %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(dir, filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary cached SSO token file, %w", err)
+	}
+	tmpFilename := tmpFile.Name()
+
+	defer func() {
+		if err != nil {
+			tmpFile.Close()
+			os.Remove(tmpFilename)
+		}
+	}()
+
+	if err := tmpFile.Chmod(fileMode); err != nil && runtime.GOOS != "windows" {
+		return fmt.Errorf("failed to set cached SSO token file permissions, %w", err)
+	}
+
+	if err := json.NewEncoder(tmpFile).Encode(t); err != nil {
+		return fmt.Errorf("failed to encode cached SSO token, %w", err)
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temporary cached SSO token file, %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary cached SSO token file, %w", err)
+	}
+
+	if err := os.Rename(tmpFilename, filename); err != nil {
+		return fmt.Errorf("failed to replace cached SSO token file, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package ssocreds
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestStandardCachedTokenFilepath(t *testing.T) {
+	origHomeDir := osUserHomeDir
+	defer func() { osUserHomeDir = origHomeDir }()
+
+	osUserHomeDir = func() (string, error) {
+		return "/home/user", nil
+	}
+
+	filename, err := StandardCachedTokenFilepath("https://example.cyberark.cloud")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := filepath.Join("/home/user", ".cybr", "sso", "cache",
+		"ab272e824366ae49fa4f0d5aabf8ff8a6886e038.json")
+	if e, a := expect, filename; e != a {
+		t.Errorf("expect %v filename, got %v", e, a)
+	}
+}
+
+func TestLoadCachedToken(t *testing.T) {
+	tok, err := loadCachedToken(filepath.Join("testdata", "valid_token.json"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := token{
+		tokenKnownFields: tokenKnownFields{
+			AccessToken:   "access-token",
+			ExpiresAt:     time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC),
+			RefreshToken:  "refresh-token",
+			ClientID:      "client-id",
+			TokenEndpoint: "https://abc1234.id.cyberark.cloud/oauth2/token/app",
+			StartURL:      "https://example.cyberark.cloud",
+		},
+		UnknownFields: map[string]interface{}{
+			"region": "us-east-1",
+		},
+	}
+	if !reflect.DeepEqual(expect, tok) {
+		t.Errorf("expect %v token, got %v", expect, tok)
+	}
+
+	if _, err := loadCachedToken(filepath.Join("testdata", "missing_expires_token.json")); err == nil {
+		t.Errorf("expect error for token without expiresAt, got none")
+	}
+	if _, err := loadCachedToken(filepath.Join("testdata", "not_exist.json")); err == nil {
+		t.Errorf("expect error for missing token file, got none")
+	}
+}
+
+func TestStoreCachedToken(t *testing.T) {
+	tok, err := loadCachedToken(filepath.Join("testdata", "valid_token.json"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "sso", "cache", "token.json")
+	if err := storeCachedToken(filename, tok, 0600); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if e, a := os.FileMode(0600), info.Mode().Perm(); e != a {
+			t.Errorf("expect %v file mode, got %v", e, a)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(filename))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := 1, len(entries); e != a {
+		t.Errorf("expect %v files in cache directory, got %v", e, a)
+	}
+
+	actual, err := loadCachedToken(filename)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if !reflect.DeepEqual(tok, actual) {
+		t.Errorf("expect %v token, got %v", tok, actual)
+	}
+}
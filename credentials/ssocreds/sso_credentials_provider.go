@@ -1,9 +1,25 @@
 package ssocreds
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrhttp "github.com/strick-j/cybr-sdk-go/cybr/transport/http"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
 // ProviderName is the name of the provider used to specify the source of
 // credentials.
 const ProviderName = "ssocreds"
 
+// Options is the Provider options structure.
 type Options struct {
 
 	// The user name that is assigned to the user.
@@ -21,12 +37,166 @@ type Options struct {
 	// If custom cached token filepath is used, the Provider's startUrl
 	// parameter will be ignored.
 	CachedTokenFilepath string
+
+	// The HTTP client the provider will use to refresh the cached token. If
+	// nil a default HTTP client will be used.
+	HTTPClient cybr.HTTPClient
 }
 
-// Provider is an AWS credential provider that retrieves temporary CYBR
-// credentials by exchanging an SSO login token.
+// Provider is a credential provider that retrieves bearer tokens from the
+// SSO token cache populated by logging in to the organization's user portal.
+//
+// If the cached access token has expired the provider will use the cached
+// refresh token to retrieve a new access token, and write it back to the
+// cache. If the token can not be refreshed an InvalidTokenError is returned,
+// and the user must log in again.
 type Provider struct {
 	options Options
 
-	CachedTokenFilepath string
+	// The cached token filepath is resolved once, on first use, so concurrent
+	// calls to Retrieve share the same path.
+	resolveFilepathOnce    sync.Once
+	cachedTokenFilepath    string
+	cachedTokenFilepathErr error
+}
+
+// New returns a new SSO credential provider. The provided options may be
+// modified with the variadic list of functional options.
+func New(options Options, optFns ...func(options *Options)) *Provider {
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	if options.HTTPClient == nil {
+		options.HTTPClient = cybrhttp.NewBuildableClient()
+	}
+
+	return &Provider{
+		options: options,
+	}
+}
+
+// Retrieve retrieves the bearer token from the SSO token cache, refreshing
+// it if it has expired.
+func (p *Provider) Retrieve(ctx context.Context) (cybr.Credentials, error) {
+	cachedTokenFilepath, err := p.resolveCachedTokenFilepath()
+	if err != nil {
+		return cybr.Credentials{}, &InvalidTokenError{Err: err}
+	}
+
+	tokenFile, err := loadCachedToken(cachedTokenFilepath)
+	if err != nil {
+		return cybr.Credentials{}, &InvalidTokenError{Err: err}
+	}
+
+	if tokenFile.Expired(sdk.NowTime()) {
+		if tokenFile, err = p.refreshToken(ctx, cachedTokenFilepath, tokenFile); err != nil {
+			return cybr.Credentials{}, &InvalidTokenError{Err: err}
+		}
+	}
+
+	return cybr.Credentials{
+		BearerToken: tokenFile.AccessToken,
+		CanExpire:   true,
+		Expires:     tokenFile.ExpiresAt,
+		Source:      ProviderName,
+	}, nil
+}
+
+// resolveCachedTokenFilepath returns the filepath of the cached token, either
+// from the provider's options or derived from the start URL.
+func (p *Provider) resolveCachedTokenFilepath() (string, error) {
+	p.resolveFilepathOnce.Do(func() {
+		if len(p.options.CachedTokenFilepath) != 0 {
+			p.cachedTokenFilepath = p.options.CachedTokenFilepath
+			return
+		}
+		p.cachedTokenFilepath, p.cachedTokenFilepathErr = StandardCachedTokenFilepath(p.options.StartURL)
+	})
+	return p.cachedTokenFilepath, p.cachedTokenFilepathErr
+}
+
+// refreshResponse is the token endpoint's refresh token grant response body.
+type refreshResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// refreshToken exchanges the cached refresh token for a new access token, and
+// writes the refreshed token back to the cache file.
+func (p *Provider) refreshToken(ctx context.Context, cachedTokenFilepath string, t token) (token, error) {
+	if len(t.RefreshToken) == 0 || len(t.TokenEndpoint) == 0 {
+		return token{}, fmt.Errorf("cached SSO token has expired and can not be refreshed")
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", t.RefreshToken)
+	if len(t.ClientID) != 0 {
+		form.Set("client_id", t.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return token{}, fmt.Errorf("failed to create refresh token request, %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.options.HTTPClient.Do(req)
+	if err != nil {
+		return token{}, fmt.Errorf("failed to refresh cached SSO token, %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return token{}, fmt.Errorf("failed to read refresh token response, %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return token{}, fmt.Errorf("failed to refresh cached SSO token, status code: %d, %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var refreshed refreshResponse
+	if err := json.Unmarshal(body, &refreshed); err != nil {
+		return token{}, fmt.Errorf("failed to decode refresh token response, %w", err)
+	}
+	if len(refreshed.AccessToken) == 0 {
+		return token{}, fmt.Errorf("refresh token response did not contain an access token")
+	}
+
+	t.AccessToken = refreshed.AccessToken
+	t.ExpiresAt = tokenExpiresAt(refreshed.ExpiresIn)
+	if len(refreshed.RefreshToken) != 0 {
+		t.RefreshToken = refreshed.RefreshToken
+	}
+
+	if err := storeCachedToken(cachedTokenFilepath, t, 0600); err != nil {
+		return token{}, fmt.Errorf("unable to cache refreshed SSO token, %w", err)
+	}
+
+	return t, nil
+}
+
+// InvalidTokenError is the error type that is returned if the cached SSO
+// token is missing, invalid, or has expired and can not be refreshed. The
+// user must log in again to populate the token cache.
+type InvalidTokenError struct {
+	Err error
+}
+
+// Unwrap returns the underlying error.
+func (i *InvalidTokenError) Unwrap() error {
+	return i.Err
+}
+
+// Error returns the error message.
+func (i *InvalidTokenError) Error() string {
+	const msg = "the SSO session has expired or is invalid, log in again to refresh the token cache"
+	if i.Err == nil {
+		return msg
+	}
+	return msg + ": " + i.Err.Error()
 }
@@ -0,0 +1,269 @@
+package ssocreds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
+func writeTestToken(t *testing.T, tok token) string {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "token.json")
+	if err := storeCachedToken(filename, tok, 0600); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	return filename
+}
+
+func TestProvider(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	p := New(Options{
+		CachedTokenFilepath: filepath.Join("testdata", "valid_token.json"),
+	})
+
+	creds, err := p.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := cybr.Credentials{
+		BearerToken: "access-token",
+		CanExpire:   true,
+		Expires:     time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC),
+		Source:      ProviderName,
+	}
+	if e, a := expect, creds; e != a {
+		t.Errorf("expect %v credentials, got %v", e, a)
+	}
+}
+
+func TestProvider_StartURLCachedTokenFilepath(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	homeDir := t.TempDir()
+	origHomeDir := osUserHomeDir
+	defer func() { osUserHomeDir = origHomeDir }()
+	osUserHomeDir = func() (string, error) { return homeDir, nil }
+
+	filename, err := StandardCachedTokenFilepath("https://example.cyberark.cloud")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := storeCachedToken(filename, token{tokenKnownFields: tokenKnownFields{
+		AccessToken: "start-url-token",
+		ExpiresAt:   time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC),
+	}}, 0600); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	p := New(Options{StartURL: "https://example.cyberark.cloud"})
+	creds, err := p.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "start-url-token", creds.BearerToken; e != a {
+		t.Errorf("expect %v bearer token, got %v", e, a)
+	}
+}
+
+func TestProvider_ConcurrentRetrieve(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	homeDir := t.TempDir()
+	origHomeDir := osUserHomeDir
+	defer func() { osUserHomeDir = origHomeDir }()
+	osUserHomeDir = func() (string, error) { return homeDir, nil }
+
+	filename, err := StandardCachedTokenFilepath("https://example.cyberark.cloud")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := storeCachedToken(filename, token{tokenKnownFields: tokenKnownFields{
+		AccessToken: "start-url-token",
+		ExpiresAt:   time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC),
+	}}, 0600); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	p := New(Options{StartURL: "https://example.cyberark.cloud"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			creds, err := p.Retrieve(context.Background())
+			if err != nil {
+				t.Errorf("expect no error, got %v", err)
+				return
+			}
+			if e, a := "start-url-token", creds.BearerToken; e != a {
+				t.Errorf("expect %v bearer token, got %v", e, a)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestProvider_Refresh(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		for k, e := range map[string]string{
+			"grant_type":    "refresh_token",
+			"refresh_token": "refresh-token",
+			"client_id":     "client-id",
+		} {
+			if a := r.PostForm.Get(k); e != a {
+				t.Errorf("expect %v form %v, got %v", k, e, a)
+			}
+		}
+		w.Write([]byte(`{"access_token":"refreshed-token","refresh_token":"rotated-token","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	filename := writeTestToken(t, token{
+		tokenKnownFields: tokenKnownFields{
+			AccessToken:   "access-token",
+			ExpiresAt:     time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC),
+			RefreshToken:  "refresh-token",
+			ClientID:      "client-id",
+			TokenEndpoint: server.URL + "/oauth2/token/app",
+		},
+		UnknownFields: map[string]interface{}{"custom": "value"},
+	})
+
+	p := New(Options{CachedTokenFilepath: filename})
+	creds, err := p.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expectExpires := time.Date(2024, 1, 2, 17, 0, 0, 0, time.UTC)
+	if e, a := "refreshed-token", creds.BearerToken; e != a {
+		t.Errorf("expect %v bearer token, got %v", e, a)
+	}
+	if e, a := expectExpires, creds.Expires; !e.Equal(a) {
+		t.Errorf("expect %v expires, got %v", e, a)
+	}
+
+	cached, err := loadCachedToken(filename)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "refreshed-token", cached.AccessToken; e != a {
+		t.Errorf("expect %v cached access token, got %v", e, a)
+	}
+	if e, a := "rotated-token", cached.RefreshToken; e != a {
+		t.Errorf("expect %v cached refresh token, got %v", e, a)
+	}
+	if e, a := expectExpires, cached.ExpiresAt; !e.Equal(a) {
+		t.Errorf("expect %v cached expires, got %v", e, a)
+	}
+	if e, a := "value", cached.UnknownFields["custom"]; e != a {
+		t.Errorf("expect %v unknown field preserved, got %v", e, a)
+	}
+}
+
+func TestProvider_RefreshWithoutExpiresIn(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"access_token":"refreshed-token"}`))
+	}))
+	defer server.Close()
+
+	filename := writeTestToken(t, token{
+		tokenKnownFields: tokenKnownFields{
+			AccessToken:   "access-token",
+			ExpiresAt:     time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC),
+			RefreshToken:  "refresh-token",
+			TokenEndpoint: server.URL + "/oauth2/token/app",
+		},
+	})
+
+	p := New(Options{CachedTokenFilepath: filename})
+	for i := 0; i < 2; i++ {
+		creds, err := p.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if e, a := time.Date(2024, 1, 2, 17, 0, 0, 0, time.UTC), creds.Expires; !e.Equal(a) {
+			t.Errorf("expect %v expires, got %v", e, a)
+		}
+	}
+
+	// The refreshed token is not expired, so is only refreshed once.
+	if e, a := 1, requests; e != a {
+		t.Errorf("expect %v refresh requests, got %v", e, a)
+	}
+}
+
+func TestProvider_InvalidToken(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer server.Close()
+
+	expired := tokenKnownFields{
+		AccessToken: "access-token",
+		ExpiresAt:   time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC),
+	}
+	refreshable := expired
+	refreshable.RefreshToken = "refresh-token"
+	refreshable.TokenEndpoint = server.URL
+
+	cases := map[string]string{
+		"missing cache file": filepath.Join(t.TempDir(), "not_exist.json"),
+		"expired without refresh token": writeTestToken(t, token{
+			tokenKnownFields: expired,
+		}),
+		"refresh failed": writeTestToken(t, token{
+			tokenKnownFields: refreshable,
+		}),
+	}
+
+	for name, filename := range cases {
+		t.Run(name, func(t *testing.T) {
+			before, _ := os.ReadFile(filename)
+
+			p := New(Options{CachedTokenFilepath: filename})
+			_, err := p.Retrieve(context.Background())
+
+			var invalidErr *InvalidTokenError
+			if !errors.As(err, &invalidErr) {
+				t.Fatalf("expect %T error, got %v", invalidErr, err)
+			}
+
+			after, _ := os.ReadFile(filename)
+			if e, a := string(before), string(after); e != a {
+				t.Errorf("expect cache file to be unchanged, got %v", a)
+			}
+		})
+	}
+}
@@ -0,0 +1,462 @@
+package ssocreds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrhttp "github.com/strick-j/cybr-sdk-go/cybr/transport/http"
+	"github.com/strick-j/cybr-sdk-go/internal/rand"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
+const (
+	loopbackCallbackPath = "/oauth/callback"
+
+	deviceCodeGrantType       = "urn:ietf:params:oauth:grant-type:device_code"
+	defaultDevicePollInterval = 5 * time.Second
+)
+
+// LoginOptions is the configuration options for logging in to the
+// organization's Identity tenant, and populating the SSO token cache.
+type LoginOptions struct {
+	// The URL that points to the organization's ISPSS user portal. Used to
+	// derive the cached token filepath, and stored in the cached token.
+	StartURL string
+
+	// The filepath the token will be cached to. If unset the StartURL will
+	// be used to determine the filepath. See Options.CachedTokenFilepath.
+	CachedTokenFilepath string
+
+	// The Identity tenant URL, e.g. https://abc1234.id.cyberark.cloud, and
+	// the ID of the OIDC application the user will log in to. Used to
+	// derive the authorization, and token endpoints if not set.
+	//
+	//	{IdentityURL}/OAuth2/Authorize/{AppID}
+	//	{IdentityURL}/OAuth2/Token/{AppID}
+	IdentityURL string
+	AppID       string
+
+	// The OIDC application's authorization, token, and device
+	// authorization endpoints. The device authorization endpoint is
+	// required to log in with the device authorization flow.
+	AuthorizeEndpoint           string
+	TokenEndpoint               string
+	DeviceAuthorizationEndpoint string
+
+	// The client ID of the OIDC application.
+	ClientID string
+
+	// The scopes to request. If empty the openid scope will be requested.
+	Scopes []string
+
+	// The user name that is assigned to the user. Sent as a login hint to
+	// the authorization endpoint if set.
+	UserName string
+
+	// The port of the loopback redirect listener. Must match a redirect URI
+	// registered with the OIDC application. If zero a random port is used.
+	RedirectPort int
+
+	// Opens the authorization URL in the user's browser. If nil the URL will
+	// be opened with the platform's default browser. If opening the browser
+	// fails, the login falls back to the device authorization flow if a
+	// device authorization endpoint is set. Otherwise the URL is written to
+	// Out for the user to open.
+	OpenBrowser func(url string) error
+
+	// Log in with the device authorization flow instead of the browser.
+	// Intended for headless hosts without a browser.
+	UseDeviceCode bool
+
+	// The writer instructions for the user are written to. If nil
+	// os.Stderr is used.
+	Out io.Writer
+
+	// The HTTP client used to exchange tokens with the Identity tenant. If
+	// nil a default HTTP client will be used.
+	HTTPClient cybr.HTTPClient
+}
+
+// Login logs the user in to the organization's Identity tenant using the
+// OIDC authorization code flow with PKCE, and writes the resulting tokens to
+// the SSO token cache read by the Provider.
+//
+// The authorization URL is opened in the user's browser, and the
+// authorization code is received by a listener on the loopback interface.
+// Hosts without a browser can use the device authorization flow instead.
+func Login(ctx context.Context, options LoginOptions, optFns ...func(*LoginOptions)) error {
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if err := resolveLoginOptions(&options); err != nil {
+		return err
+	}
+
+	var (
+		resp *tokenResponse
+		err  error
+	)
+	if options.UseDeviceCode {
+		resp, err = loginWithDeviceCode(ctx, options)
+	} else {
+		resp, err = loginWithBrowser(ctx, options)
+	}
+	if err != nil {
+		return err
+	}
+
+	filename := options.CachedTokenFilepath
+	if filename == "" {
+		if filename, err = StandardCachedTokenFilepath(options.StartURL); err != nil {
+			return err
+		}
+	}
+
+	t := token{
+		tokenKnownFields: tokenKnownFields{
+			AccessToken:   resp.AccessToken,
+			ExpiresAt:     tokenExpiresAt(resp.ExpiresIn),
+			RefreshToken:  resp.RefreshToken,
+			ClientID:      options.ClientID,
+			TokenEndpoint: options.TokenEndpoint,
+			StartURL:      options.StartURL,
+		},
+	}
+	if err := storeCachedToken(filename, t, 0600); err != nil {
+		return fmt.Errorf("unable to cache SSO token, %w", err)
+	}
+
+	return nil
+}
+
+func resolveLoginOptions(o *LoginOptions) error {
+	identityURL := strings.TrimRight(o.IdentityURL, "/")
+	if o.AuthorizeEndpoint == "" && identityURL != "" && o.AppID != "" {
+		o.AuthorizeEndpoint = identityURL + "/OAuth2/Authorize/" + url.PathEscape(o.AppID)
+	}
+	if o.TokenEndpoint == "" && identityURL != "" && o.AppID != "" {
+		o.TokenEndpoint = identityURL + "/OAuth2/Token/" + url.PathEscape(o.AppID)
+	}
+
+	if o.ClientID == "" {
+		return fmt.Errorf("client ID is required to log in")
+	}
+	if o.TokenEndpoint == "" {
+		return fmt.Errorf("token endpoint, or identity URL and app ID, are required to log in")
+	}
+	if o.UseDeviceCode && o.DeviceAuthorizationEndpoint == "" {
+		return fmt.Errorf("device authorization endpoint is required to log in with a device code")
+	}
+	if !o.UseDeviceCode && o.AuthorizeEndpoint == "" {
+		return fmt.Errorf("authorize endpoint, or identity URL and app ID, are required to log in")
+	}
+	if o.StartURL == "" && o.CachedTokenFilepath == "" {
+		return fmt.Errorf("start URL or cached token filepath is required to log in")
+	}
+
+	if len(o.Scopes) == 0 {
+		o.Scopes = []string{"openid"}
+	}
+	if o.OpenBrowser == nil {
+		o.OpenBrowser = openBrowser
+	}
+	if o.Out == nil {
+		o.Out = os.Stderr
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = cybrhttp.NewBuildableClient()
+	}
+
+	return nil
+}
+
+// loginWithBrowser performs the authorization code flow with PKCE, receiving
+// the authorization code on a loopback redirect listener.
+func loginWithBrowser(ctx context.Context, o LoginOptions) (*tokenResponse, error) {
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(o.RedirectPort)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start loopback redirect listener, %w", err)
+	}
+	defer listener.Close()
+
+	redirectURI := fmt.Sprintf("http://%s%s", listener.Addr().String(), loopbackCallbackPath)
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(loopbackCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		var result callbackResult
+		switch {
+		case query.Get("state") != state:
+			result.err = fmt.Errorf("authorization response state does not match request")
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("authorization failed, %s: %s",
+				query.Get("error"), query.Get("error_description"))
+		case query.Get("code") == "":
+			result.err = fmt.Errorf("authorization response did not contain a code")
+		default:
+			result.code = query.Get("code")
+		}
+
+		if result.err != nil {
+			http.Error(w, "Login failed, return to the terminal for details.", http.StatusBadRequest)
+		} else {
+			io.WriteString(w, "Login complete, you may close this window.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", o.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", strings.Join(o.Scopes, " "))
+	query.Set("state", state)
+	query.Set("code_challenge", pkceChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+	if o.UserName != "" {
+		query.Set("login_hint", o.UserName)
+	}
+	authorizeURL := o.AuthorizeEndpoint + "?" + query.Encode()
+
+	if err := o.OpenBrowser(authorizeURL); err != nil {
+		if o.DeviceAuthorizationEndpoint != "" {
+			fmt.Fprintf(o.Out, "Unable to open browser, %v. Falling back to device authorization.\n", err)
+			return loginWithDeviceCode(ctx, o)
+		}
+		fmt.Fprintf(o.Out, "Unable to open browser. Open the following URL to log in:\n\n%s\n\n", authorizeURL)
+	}
+
+	var result callbackResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", result.code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("client_id", o.ClientID)
+	form.Set("code_verifier", verifier)
+
+	return requestToken(ctx, o, form)
+}
+
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+// loginWithDeviceCode performs the device authorization flow, polling the
+// token endpoint until the user has approved the login on another device.
+func loginWithDeviceCode(ctx context.Context, o LoginOptions) (*tokenResponse, error) {
+	form := url.Values{}
+	form.Set("client_id", o.ClientID)
+	form.Set("scope", strings.Join(o.Scopes, " "))
+
+	body, err := postForm(ctx, o.HTTPClient, o.DeviceAuthorizationEndpoint, form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start device authorization, %w", err)
+	}
+
+	var device deviceAuthorizationResponse
+	if err := json.Unmarshal(body, &device); err != nil {
+		return nil, fmt.Errorf("failed to decode device authorization response, %w", err)
+	}
+
+	verificationURI := device.VerificationURIComplete
+	if verificationURI == "" {
+		verificationURI = device.VerificationURI
+	}
+	fmt.Fprintf(o.Out, "To log in, open the following URL and enter the code %s:\n\n%s\n\n",
+		device.UserCode, verificationURI)
+
+	interval := defaultDevicePollInterval
+	if device.Interval > 0 {
+		interval = time.Duration(device.Interval) * time.Second
+	}
+	if device.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(device.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	form = url.Values{}
+	form.Set("grant_type", deviceCodeGrantType)
+	form.Set("device_code", device.DeviceCode)
+	form.Set("client_id", o.ClientID)
+
+	for {
+		resp, err := requestToken(ctx, o, form)
+		if err == nil {
+			return resp, nil
+		}
+
+		var tokenErr *tokenError
+		if !errors.As(err, &tokenErr) {
+			return nil, err
+		}
+		switch tokenErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, err
+		}
+
+		if err := sdk.SleepWithContext(ctx, interval); err != nil {
+			return nil, fmt.Errorf("device authorization was not approved, %w", err)
+		}
+	}
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// tokenError is the OAuth2 error returned by the token endpoint.
+type tokenError struct {
+	StatusCode  int
+	Code        string
+	Description string
+}
+
+func (e *tokenError) Error() string {
+	msg := fmt.Sprintf("token request failed, status code: %d, %s", e.StatusCode, e.Code)
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg
+}
+
+func requestToken(ctx context.Context, o LoginOptions, form url.Values) (*tokenResponse, error) {
+	body, err := postForm(ctx, o.HTTPClient, o.TokenEndpoint, form)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp tokenResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode token response, %w", err)
+	}
+	if resp.AccessToken == "" {
+		return nil, fmt.Errorf("token response did not contain an access token")
+	}
+
+	return &resp, nil
+}
+
+// postForm posts the form to the endpoint, returning the response body. If
+// the endpoint responds with an error status a *tokenError is returned.
+func postForm(ctx context.Context, client cybr.HTTPClient, endpoint string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResp struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		_ = json.Unmarshal(body, &errResp)
+		return nil, &tokenError{
+			StatusCode:  resp.StatusCode,
+			Code:        errResp.Error,
+			Description: errResp.ErrorDescription,
+		}
+	}
+
+	return body, nil
+}
+
+// randomString returns a URL safe base64 encoded string of n random bytes.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", fmt.Errorf("failed to read random value, %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// pkceChallenge returns the S256 PKCE code challenge for the verifier.
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// openBrowser opens the URL with the platform's default browser.
+func openBrowser(u string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	return cmd.Start()
+}
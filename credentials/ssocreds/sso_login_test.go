@@ -0,0 +1,240 @@
+package ssocreds
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
+// fakeAuthServer is a stand-in for the Identity tenant's OIDC endpoints.
+type fakeAuthServer struct {
+	t *testing.T
+
+	// authorization code flow
+	codeChallenge string
+	redirectURI   string
+
+	// device authorization flow
+	pendingPolls int
+}
+
+func (f *fakeAuthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/OAuth2/Authorize/app":
+		q := r.URL.Query()
+		if e, a := "S256", q.Get("code_challenge_method"); e != a {
+			f.t.Errorf("expect %v code challenge method, got %v", e, a)
+		}
+		if e, a := "user@example.com", q.Get("login_hint"); e != a {
+			f.t.Errorf("expect %v login hint, got %v", e, a)
+		}
+		f.codeChallenge = q.Get("code_challenge")
+		f.redirectURI = q.Get("redirect_uri")
+
+		redirect, _ := url.Parse(f.redirectURI)
+		redirect.RawQuery = url.Values{"code": {"auth-code"}, "state": {q.Get("state")}}.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+
+	case "/OAuth2/Device/app":
+		fmt.Fprint(w, `{"device_code":"device-code","user_code":"ABCD-EFGH",`+
+			`"verification_uri":"https://example.com/device","expires_in":600,"interval":1}`)
+
+	case "/OAuth2/Token/app":
+		r.ParseForm()
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if e, a := "auth-code", r.PostForm.Get("code"); e != a {
+				f.t.Errorf("expect %v code, got %v", e, a)
+			}
+			if e, a := f.redirectURI, r.PostForm.Get("redirect_uri"); e != a {
+				f.t.Errorf("expect %v redirect URI, got %v", e, a)
+			}
+			if e, a := f.codeChallenge, pkceChallenge(r.PostForm.Get("code_verifier")); e != a {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant","error_description":"PKCE verification failed"}`)
+				return
+			}
+		case deviceCodeGrantType:
+			if e, a := "device-code", r.PostForm.Get("device_code"); e != a {
+				f.t.Errorf("expect %v device code, got %v", e, a)
+			}
+			if f.pendingPolls > 0 {
+				f.pendingPolls--
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"authorization_pending"}`)
+				return
+			}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"unsupported_grant_type"}`)
+			return
+		}
+		fmt.Fprint(w, `{"access_token":"access-token","refresh_token":"refresh-token","expires_in":3600}`)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testLoginOptions(serverURL, filename string) LoginOptions {
+	return LoginOptions{
+		StartURL:                    "https://example.cyberark.cloud",
+		CachedTokenFilepath:         filename,
+		IdentityURL:                 serverURL,
+		AppID:                       "app",
+		DeviceAuthorizationEndpoint: serverURL + "/OAuth2/Device/app",
+		ClientID:                    "client-id",
+		UserName:                    "user@example.com",
+		Out:                         &bytes.Buffer{},
+	}
+}
+
+func assertCachedLoginToken(t *testing.T, filename, tokenEndpoint string) {
+	t.Helper()
+
+	cached, err := loadCachedToken(filename)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := tokenKnownFields{
+		AccessToken:   "access-token",
+		ExpiresAt:     time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC),
+		RefreshToken:  "refresh-token",
+		ClientID:      "client-id",
+		TokenEndpoint: tokenEndpoint,
+		StartURL:      "https://example.cyberark.cloud",
+	}
+	if e, a := expect, cached.tokenKnownFields; e != a {
+		t.Errorf("expect %v cached token, got %v", e, a)
+	}
+}
+
+func TestLogin_Browser(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	server := httptest.NewServer(&fakeAuthServer{t: t})
+	defer server.Close()
+
+	filename := filepath.Join(t.TempDir(), "token.json")
+	options := testLoginOptions(server.URL, filename)
+	options.OpenBrowser = func(u string) error {
+		// Follows the authorization redirect to the loopback listener, as
+		// the user's browser would.
+		go func() {
+			resp, err := http.Get(u)
+			if err != nil {
+				t.Errorf("expect no error, got %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+		return nil
+	}
+
+	if err := Login(context.Background(), options); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	assertCachedLoginToken(t, filename, server.URL+"/OAuth2/Token/app")
+
+	// The cached token is usable by the Provider.
+	creds, err := New(Options{CachedTokenFilepath: filename}).Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "access-token", creds.BearerToken; e != a {
+		t.Errorf("expect %v bearer token, got %v", e, a)
+	}
+}
+
+func TestLogin_DeviceCode(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	origSleep := sdk.SleepWithContext
+	defer func() { sdk.SleepWithContext = origSleep }()
+	var sleeps int
+	sdk.SleepWithContext = func(ctx context.Context, dur time.Duration) error {
+		sleeps++
+		return nil
+	}
+
+	cases := map[string]func(*LoginOptions){
+		"explicit": func(o *LoginOptions) {
+			o.UseDeviceCode = true
+		},
+		"browser fallback": func(o *LoginOptions) {
+			o.OpenBrowser = func(string) error {
+				return fmt.Errorf("no display")
+			}
+		},
+	}
+
+	for name, optFn := range cases {
+		t.Run(name, func(t *testing.T) {
+			sleeps = 0
+			server := httptest.NewServer(&fakeAuthServer{t: t, pendingPolls: 2})
+			defer server.Close()
+
+			filename := filepath.Join(t.TempDir(), "token.json")
+			options := testLoginOptions(server.URL, filename)
+			out := &bytes.Buffer{}
+			options.Out = out
+
+			if err := Login(context.Background(), options, optFn); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := 2, sleeps; e != a {
+				t.Errorf("expect %v polls to sleep, got %v", e, a)
+			}
+			for _, e := range []string{"ABCD-EFGH", "https://example.com/device"} {
+				if a := out.String(); !strings.Contains(a, e) {
+					t.Errorf("expect output to contain %q, got %q", e, a)
+				}
+			}
+
+			assertCachedLoginToken(t, filename, server.URL+"/OAuth2/Token/app")
+		})
+	}
+}
+
+func TestLogin_AuthorizationDenied(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "token.json")
+	options := testLoginOptions("https://example.com", filename)
+	options.OpenBrowser = func(u string) error {
+		authorize, _ := url.Parse(u)
+		redirect, _ := url.Parse(authorize.Query().Get("redirect_uri"))
+		redirect.RawQuery = url.Values{
+			"error": {"access_denied"},
+			"state": {authorize.Query().Get("state")},
+		}.Encode()
+
+		go func() {
+			resp, err := http.Get(redirect.String())
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	err := Login(context.Background(), options)
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if e, a := "access_denied", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+}
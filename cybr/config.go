@@ -21,9 +21,10 @@ type Config struct {
 	// and must configured globally or on a per-client basis unless otherwise noted.
 	TenantName string
 
-	// The tenant ID to use for the service client. This parameter is optional
-	// and may be configured globally or on a per-client basis. If not provided,
-	// the tenant ID will be resolved using the tenant name.
+	// The Identity tenant ID, e.g. aa1234. This parameter is optional and is
+	// loaded from the SDK's configuration sources. It is not resolved
+	// automatically if not configured; use the discovery package's Client to
+	// look up the tenant ID of a tenant name.
 	TenantID string
 
 	// The credentials object to use when signing requests.
@@ -92,16 +93,46 @@ type Config struct {
 	// standard error.
 	Logger logging.Logger
 
+	// Configures the events that will be sent to the configured logger. This
+	// can be used to configure the logging of signing, retries, request, and
+	// responses of the SDK clients.
+	//
+	// See the ClientLogMode type documentation for the complete set of logging
+	// modes and available configuration.
+	ClientLogMode ClientLogMode
+
 	// AppId is an optional application specific identifier that can be set.
 	// When set it will be appended to the User-Agent header of every request
 	// in the form of App/{AppId}. This variable is sourced from environment
-	// variable AWS_SDK_UA_APP_ID or the shared config profile attribute sdk_ua_app_id.
-	// TODO: EDIT ABOVE
+	// variable CYBR_APP_ID when using config.LoadDefaultConfig.
 	AppID string
 
 	// BaseEndpoint is an intermediary transfer location to a service specific
 	// BaseEndpoint on a service's Options.
 	BaseEndpoint *string
+
+	// An endpoint resolver that can be used to provide or override an endpoint
+	// for the given service and the service client's endpoint options. API
+	// clients created with NewFromConfig resolve endpoints with this resolver
+	// first. If it returns an EndpointNotFoundError, the endpoint is resolved
+	// with the client's default endpoint resolver.
+	//
+	// The service identifier passed to the resolver is the service client's
+	// ServiceID, e.g. "DPA" or "PCloud".
+	EndpointResolverWithOptions EndpointResolverWithOptions
+
+	// The configured DefaultsMode. If not specified, service clients will
+	// default to legacy.
+	//
+	// Supported modes are: auto, cross-region, in-region, legacy, mobile,
+	// standard
+	DefaultsMode DefaultsMode
+
+	// The RuntimeEnvironment configuration, only populated if the DefaultsMode
+	// is set to DefaultsModeAuto and is initialized by
+	// `config.LoadDefaultConfig`. You should not populate this structure
+	// programmatically, or rely on the values here within your applications.
+	RuntimeEnvironment RuntimeEnvironment
 }
 
 // NewConfig returns a new Config pointer that can be chained with builder
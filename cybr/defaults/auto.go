@@ -0,0 +1,40 @@
+package defaults
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+var getGOOS = func() string {
+	return runtime.GOOS
+}
+
+// ResolveDefaultsModeAuto is used to determine the effective cybr.DefaultsMode
+// when the mode is set to cybr.DefaultsModeAuto.
+//
+// Mobile platforms resolve to mobile mode. CI systems resolve to standard
+// mode. Otherwise if both the region the SDK is executing in, and the
+// tenant's region are known, in-region or cross-region mode is resolved
+// depending on whether the regions match. Standard mode is used if the
+// environment can not be determined.
+func ResolveDefaultsModeAuto(environment cybr.RuntimeEnvironment) cybr.DefaultsMode {
+	goos := getGOOS()
+	if goos == "android" || goos == "ios" {
+		return cybr.DefaultsModeMobile
+	}
+
+	if environment.EnvironmentIdentifier == cybr.ExecutionEnvironmentCI {
+		return cybr.DefaultsModeStandard
+	}
+
+	if len(environment.Region) > 0 && len(environment.TenantRegion) > 0 {
+		if strings.EqualFold(environment.Region, environment.TenantRegion) {
+			return cybr.DefaultsModeInRegion
+		}
+		return cybr.DefaultsModeCrossRegion
+	}
+
+	return cybr.DefaultsModeStandard
+}
@@ -0,0 +1,73 @@
+package defaults
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+func TestResolveDefaultsModeAuto(t *testing.T) {
+	cases := []struct {
+		Environment cybr.RuntimeEnvironment
+		GOOS        string
+		Expected    cybr.DefaultsMode
+	}{
+		{
+			Environment: cybr.RuntimeEnvironment{},
+			Expected:    cybr.DefaultsModeStandard,
+		},
+		{
+			Environment: cybr.RuntimeEnvironment{},
+			GOOS:        "ios",
+			Expected:    cybr.DefaultsModeMobile,
+		},
+		{
+			Environment: cybr.RuntimeEnvironment{},
+			GOOS:        "android",
+			Expected:    cybr.DefaultsModeMobile,
+		},
+		{
+			Environment: cybr.RuntimeEnvironment{
+				Region:       "us-east-1",
+				TenantRegion: "US-EAST-1",
+			},
+			Expected: cybr.DefaultsModeInRegion,
+		},
+		{
+			Environment: cybr.RuntimeEnvironment{
+				Region:       "eu-west-1",
+				TenantRegion: "us-east-1",
+			},
+			Expected: cybr.DefaultsModeCrossRegion,
+		},
+		{
+			Environment: cybr.RuntimeEnvironment{
+				EnvironmentIdentifier: cybr.ExecutionEnvironmentCI,
+				Region:                "us-east-1",
+				TenantRegion:          "us-east-1",
+			},
+			Expected: cybr.DefaultsModeStandard,
+		},
+		{
+			Environment: cybr.RuntimeEnvironment{
+				Region: "us-east-1",
+			},
+			Expected: cybr.DefaultsModeStandard,
+		},
+	}
+
+	for i, tt := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if len(tt.GOOS) > 0 {
+				origGOOS := getGOOS
+				defer func() { getGOOS = origGOOS }()
+				getGOOS = func() string { return tt.GOOS }
+			}
+
+			if e, a := tt.Expected, ResolveDefaultsModeAuto(tt.Environment); e != a {
+				t.Errorf("expect %v mode, got %v", e, a)
+			}
+		})
+	}
+}
@@ -15,6 +15,20 @@ func GetModeConfiguration(mode cybr.DefaultsMode) (Configuration, error) {
 	mv.SetFromString(string(mode))
 
 	switch mv {
+	case cybr.DefaultsModeCrossRegion:
+		settings := Configuration{
+			ConnectTimeout:        cybr.Duration(3100 * time.Millisecond),
+			RetryMode:             cybr.RetryMode("standard"),
+			TLSNegotiationTimeout: cybr.Duration(3100 * time.Millisecond),
+		}
+		return settings, nil
+	case cybr.DefaultsModeInRegion:
+		settings := Configuration{
+			ConnectTimeout:        cybr.Duration(1100 * time.Millisecond),
+			RetryMode:             cybr.RetryMode("standard"),
+			TLSNegotiationTimeout: cybr.Duration(1100 * time.Millisecond),
+		}
+		return settings, nil
 	case cybr.DefaultsModeMobile:
 		settings := Configuration{
 			ConnectTimeout:        cybr.Duration(30000 * time.Millisecond),
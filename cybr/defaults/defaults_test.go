@@ -73,3 +73,66 @@ func TestConfigV1(t *testing.T) {
 		})
 	}
 }
+
+func TestGetModeConfiguration(t *testing.T) {
+	cases := []struct {
+		Mode      cybr.DefaultsMode
+		Expected  Configuration
+		ExpectErr bool
+	}{
+		{
+			Mode: cybr.DefaultsModeCrossRegion,
+			Expected: Configuration{
+				ConnectTimeout:        cybr.Duration(3100 * time.Millisecond),
+				TLSNegotiationTimeout: cybr.Duration(3100 * time.Millisecond),
+				RetryMode:             cybr.RetryModeStandard,
+			},
+		},
+		{
+			Mode: cybr.DefaultsModeInRegion,
+			Expected: Configuration{
+				ConnectTimeout:        cybr.Duration(1100 * time.Millisecond),
+				TLSNegotiationTimeout: cybr.Duration(1100 * time.Millisecond),
+				RetryMode:             cybr.RetryModeStandard,
+			},
+		},
+		{
+			Mode: cybr.DefaultsModeMobile,
+			Expected: Configuration{
+				ConnectTimeout:        cybr.Duration(30000 * time.Millisecond),
+				TLSNegotiationTimeout: cybr.Duration(30000 * time.Millisecond),
+				RetryMode:             cybr.RetryModeStandard,
+			},
+		},
+		{
+			Mode: cybr.DefaultsModeStandard,
+			Expected: Configuration{
+				ConnectTimeout:        cybr.Duration(3100 * time.Millisecond),
+				TLSNegotiationTimeout: cybr.Duration(3100 * time.Millisecond),
+				RetryMode:             cybr.RetryModeStandard,
+			},
+		},
+		{
+			Mode:      cybr.DefaultsModeLegacy,
+			ExpectErr: true,
+		},
+	}
+
+	for i, tt := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			got, err := GetModeConfiguration(tt.Mode)
+			if tt.ExpectErr {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.Expected, got); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
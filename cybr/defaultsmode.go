@@ -15,12 +15,32 @@ const (
 	//
 	// Note that the auto detection is heuristics-based and does not guarantee 100%
 	// accuracy. STANDARD mode will be used if the execution environment cannot
-	// be determined. The auto detection might query EC2 Instance Metadata service
-	// (https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ec2-instance-metadata.html),
-	// which might introduce latency. Therefore we recommend choosing an explicit
-	// defaults_mode instead if startup latency is critical to your application
+	// be determined. The auto detection inspects the environment for signs of
+	// running in a CI system. When the region the SDK is running in is known,
+	// the platform discovery API may be queried for the tenant's region, which
+	// might introduce latency. Therefore we recommend
+	// choosing an explicit defaults_mode instead if startup latency is critical
+	// to your application
 	DefaultsModeAuto DefaultsMode = "auto"
 
+	// DefaultsModeCrossRegion builds on the standard mode and includes
+	// optimization tailored for applications which call the tenant's services
+	// from a different region
+	//
+	// Note that the default values vended from this mode might change as best practices
+	// may evolve. As a result, it is encouraged to perform tests when upgrading
+	// the SDK
+	DefaultsModeCrossRegion DefaultsMode = "cross-region"
+
+	// DefaultsModeInRegion builds on the standard mode and includes
+	// optimization tailored for applications which call the tenant's services
+	// from within the same region as the tenant
+	//
+	// Note that the default values vended from this mode might change as best practices
+	// may evolve. As a result, it is encouraged to perform tests when upgrading
+	// the SDK
+	DefaultsModeInRegion DefaultsMode = "in-region"
+
 	// DefaultsModeLegacy provides default settings that vary per SDK and were used
 	// prior to establishment of defaults_mode
 	DefaultsModeLegacy DefaultsMode = "legacy"
@@ -51,6 +71,12 @@ func (d *DefaultsMode) SetFromString(v string) (ok bool) {
 	case strings.EqualFold(v, string(DefaultsModeAuto)):
 		*d = DefaultsModeAuto
 		ok = true
+	case strings.EqualFold(v, string(DefaultsModeCrossRegion)):
+		*d = DefaultsModeCrossRegion
+		ok = true
+	case strings.EqualFold(v, string(DefaultsModeInRegion)):
+		*d = DefaultsModeInRegion
+		ok = true
 	case strings.EqualFold(v, string(DefaultsModeLegacy)):
 		*d = DefaultsModeLegacy
 		ok = true
@@ -0,0 +1,352 @@
+// Package discovery provides a client for the CyberArk platform discovery
+// API. The platform discovery API resolves a tenant name to the tenant's
+// Identity URL, region, and the endpoints of the services the tenant is
+// subscribed to.
+//
+// Tenants in different regions, or on the shared services and dedicated
+// platforms, are served from different hostnames. The discovery client
+// allows API clients to resolve the hostnames of a tenant without the
+// hostnames being configured.
+//
+// Discovery results are cached by the Client for a TTL, and concurrent
+// discovery of the same tenant is deduplicated so only a single request is
+// made. Failed discoveries are cached for a shorter ErrorTTL, so a platform
+// discovery API outage does not add a request to every API call.
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrhttp "github.com/strick-j/cybr-sdk-go/cybr/transport/http"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+	"github.com/strick-j/cybr-sdk-go/internal/sync/singleflight"
+)
+
+const (
+	// DefaultEndpoint is the platform discovery API endpoint.
+	DefaultEndpoint = "https://platform-discovery.cyberark.cloud"
+
+	// DefaultTTL is the duration discovery results are cached for.
+	DefaultTTL = 1 * time.Hour
+
+	// DefaultErrorTTL is the duration failed discoveries are cached for.
+	DefaultErrorTTL = 1 * time.Minute
+
+	discoveryPath = "/api/v2/services/subdomain/"
+)
+
+// Keys of the services in the platform discovery API's response.
+const (
+	ServiceKeyIdentity   = "identity_administration"
+	ServiceKeyDPA        = "dpa"
+	ServiceKeyPCloud     = "pcloud"
+	ServiceKeySecretsHub = "secrets_hub"
+)
+
+// Options is the configuration options for the discovery Client.
+type Options struct {
+	// The platform discovery API endpoint. Defaults to DefaultEndpoint.
+	Endpoint string
+
+	// The duration discovery results are cached for. Defaults to DefaultTTL.
+	// A negative TTL disables caching.
+	TTL time.Duration
+
+	// The duration failed discoveries are cached for. While a failure is
+	// cached, Discover returns the failure's error without making a request.
+	// Defaults to DefaultErrorTTL. A negative ErrorTTL disables caching of
+	// failures.
+	ErrorTTL time.Duration
+
+	// The HTTP client the discovery client will use to make requests. If nil
+	// a default HTTP client will be used.
+	HTTPClient cybr.HTTPClient
+}
+
+// ServiceEndpoint is the endpoint of a service discovered for a tenant.
+type ServiceEndpoint struct {
+	// The URL of the service's API.
+	API string
+
+	// The URL of the service's user interface, if any.
+	UI string
+
+	// The region the service is hosted in, if known.
+	Region string
+}
+
+// Result is the discovery result of a tenant.
+type Result struct {
+	// The tenant name the result was discovered for.
+	TenantName string
+
+	// The URL of the tenant's Identity tenant, e.g.
+	// https://aa1234.id.cyberark.cloud
+	IdentityURL string
+
+	// The ID of the tenant's Identity tenant, e.g. aa1234. Derived from the
+	// IdentityURL hostname.
+	TenantID string
+
+	// The region the tenant is hosted in.
+	Region string
+
+	// The endpoints of the services the tenant is subscribed to, keyed by
+	// the platform discovery API's service key.
+	Endpoints map[string]ServiceEndpoint
+}
+
+// ServiceEndpoint returns the endpoint discovered for the service key, and
+// if the service was discovered.
+func (r *Result) ServiceEndpoint(key string) (ServiceEndpoint, bool) {
+	if r == nil {
+		return ServiceEndpoint{}, false
+	}
+	v, ok := r.Endpoints[key]
+	return v, ok && len(v.API) != 0
+}
+
+// Error is returned when the platform discovery API is unable to discover
+// the tenant.
+type Error struct {
+	TenantName string
+	StatusCode int
+	Err        error
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("failed to discover tenant %q, status code: %d, %v", e.TenantName, e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("failed to discover tenant %q, %v", e.TenantName, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatusCode returns the HTTP status code of the discovery response, if
+// any.
+func (e *Error) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+type cachedResult struct {
+	result  *Result
+	err     error
+	expires time.Time
+}
+
+// Client discovers the endpoints of tenants with the platform discovery API.
+//
+// The Client is safe for concurrent use.
+type Client struct {
+	options Options
+
+	mu    sync.RWMutex
+	cache map[string]cachedResult
+	sf    singleflight.Group
+}
+
+// New returns an initialized discovery Client. A variadic list of functional
+// options can be provided to modify the Client's Options.
+func New(optFns ...func(*Options)) *Client {
+	options := Options{
+		Endpoint: DefaultEndpoint,
+		TTL:      DefaultTTL,
+		ErrorTTL: DefaultErrorTTL,
+	}
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	if options.HTTPClient == nil {
+		options.HTTPClient = cybrhttp.NewBuildableClient()
+	}
+
+	return &Client{
+		options: options,
+		cache:   map[string]cachedResult{},
+	}
+}
+
+// Discover returns the discovery result of the tenant. Cached results are
+// returned until their TTL expires, and cached failures until their ErrorTTL
+// expires. Concurrent calls for the same tenant share a single request to the
+// platform discovery API.
+func (c *Client) Discover(ctx context.Context, tenantName string) (*Result, error) {
+	tenantName = strings.ToLower(tenantName)
+	if len(tenantName) == 0 {
+		return nil, &Error{Err: fmt.Errorf("tenant name is required")}
+	}
+
+	if v, ok := c.getCached(tenantName); ok {
+		return v.result, v.err
+	}
+
+	resCh := c.sf.DoChan(tenantName, func() (interface{}, error) {
+		return c.singleDiscover(&suppressedContext{ctx}, tenantName)
+	})
+	select {
+	case res := <-resCh:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*Result), nil
+	case <-ctx.Done():
+		return nil, &cybr.RequestCanceledError{Err: ctx.Err()}
+	}
+}
+
+// Invalidate removes the cached discovery result, or failure, of the tenant,
+// so the next
+// call to Discover will request it from the platform discovery API.
+func (c *Client) Invalidate(tenantName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.cache, strings.ToLower(tenantName))
+}
+
+func (c *Client) getCached(tenantName string) (cachedResult, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	v, ok := c.cache[tenantName]
+	if !ok || !sdk.NowTime().Before(v.expires) {
+		return cachedResult{}, false
+	}
+	return v, true
+}
+
+func (c *Client) setCached(tenantName string, v cachedResult, ttl time.Duration) {
+	if ttl < 0 {
+		return
+	}
+
+	v.expires = sdk.NowTime().Add(ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache[tenantName] = v
+}
+
+func (c *Client) singleDiscover(ctx context.Context, tenantName string) (interface{}, error) {
+	if v, ok := c.getCached(tenantName); ok {
+		return v.result, v.err
+	}
+
+	result, err := c.discover(ctx, tenantName)
+	if err != nil {
+		c.setCached(tenantName, cachedResult{err: err}, c.options.ErrorTTL)
+		return nil, err
+	}
+
+	c.setCached(tenantName, cachedResult{result: result}, c.options.TTL)
+
+	return result, nil
+}
+
+// discoveryService is a service entry in the platform discovery API's
+// response.
+type discoveryService struct {
+	API    string `json:"api"`
+	UI     string `json:"ui"`
+	Region string `json:"region"`
+}
+
+func (c *Client) discover(ctx context.Context, tenantName string) (*Result, error) {
+	endpoint := strings.TrimRight(c.options.Endpoint, "/") + discoveryPath + url.PathEscape(tenantName)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, &Error{TenantName: tenantName, Err: fmt.Errorf("failed to create request, %w", err)}
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.options.HTTPClient.Do(req)
+	if err != nil {
+		return nil, &Error{TenantName: tenantName, Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &Error{TenantName: tenantName, Err: fmt.Errorf("failed to read response, %w", err)}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &Error{
+			TenantName: tenantName,
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("%s", strings.TrimSpace(string(body))),
+		}
+	}
+
+	var services map[string]json.RawMessage
+	if err := json.Unmarshal(body, &services); err != nil {
+		return nil, &Error{TenantName: tenantName, Err: fmt.Errorf("failed to decode response, %w", err)}
+	}
+
+	result := &Result{
+		TenantName: tenantName,
+		Endpoints:  map[string]ServiceEndpoint{},
+	}
+	for key, raw := range services {
+		var service discoveryService
+		if err := json.Unmarshal(raw, &service); err != nil {
+			// Entries which are not service endpoints are ignored.
+			continue
+		}
+		result.Endpoints[key] = ServiceEndpoint{
+			API:    service.API,
+			UI:     service.UI,
+			Region: service.Region,
+		}
+	}
+
+	identity, ok := result.ServiceEndpoint(ServiceKeyIdentity)
+	if !ok {
+		return nil, &Error{TenantName: tenantName, Err: fmt.Errorf("response did not contain the Identity tenant URL")}
+	}
+	identityURL, err := url.Parse(identity.API)
+	if err != nil || len(identityURL.Host) == 0 {
+		return nil, &Error{TenantName: tenantName, Err: fmt.Errorf("invalid Identity tenant URL %q", identity.API)}
+	}
+
+	result.IdentityURL = identityURL.Scheme + "://" + identityURL.Host
+	result.TenantID = strings.SplitN(identityURL.Hostname(), ".", 2)[0]
+	result.Region = identity.Region
+
+	return result, nil
+}
+
+// suppressedContext wraps a context, suppressing its cancellation and
+// deadline. Allows a discovery request shared by multiple callers to
+// complete even if the caller that started it is canceled.
+type suppressedContext struct {
+	context.Context
+}
+
+func (s *suppressedContext) Deadline() (deadline time.Time, ok bool) {
+	return time.Time{}, false
+}
+
+func (s *suppressedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (s *suppressedContext) Err() error {
+	return nil
+}
@@ -0,0 +1,259 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
+const discoveryResponse = `{
+	"identity_administration": {
+		"ui": "https://aa1234.id.cyberark.cloud/adminportal",
+		"api": "https://aa1234.id.cyberark.cloud",
+		"region": "us-east-1"
+	},
+	"dpa": {
+		"ui": "https://example.dpa.cyberark.cloud",
+		"api": "https://example.dpa.cyberark.cloud/api",
+		"region": "us-east-1"
+	},
+	"pcloud": {
+		"api": "https://example.privilegecloud.cyberark.cloud/api"
+	},
+	"tenant_flags": ["flag"]
+}`
+
+func newDiscoveryServer(t *testing.T, calls *int32, release <-chan struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if release != nil {
+			<-release
+		}
+
+		switch r.URL.Path {
+		case "/api/v2/services/subdomain/example":
+			fmt.Fprint(w, discoveryResponse)
+		case "/api/v2/services/subdomain/noidentity":
+			fmt.Fprint(w, `{"dpa":{"api":"https://noidentity.dpa.cyberark.cloud/api"}}`)
+		case "/api/v2/services/subdomain/outage":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":"service unavailable"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"subdomain not found"}`)
+		}
+	}))
+}
+
+func TestClient_Discover(t *testing.T) {
+	var calls int32
+	server := newDiscoveryServer(t, &calls, nil)
+	defer server.Close()
+
+	client := New(func(o *Options) {
+		o.Endpoint = server.URL
+	})
+
+	result, err := client.Discover(context.Background(), "Example")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "example", result.TenantName; e != a {
+		t.Errorf("expect %v tenant name, got %v", e, a)
+	}
+	if e, a := "https://aa1234.id.cyberark.cloud", result.IdentityURL; e != a {
+		t.Errorf("expect %v identity URL, got %v", e, a)
+	}
+	if e, a := "aa1234", result.TenantID; e != a {
+		t.Errorf("expect %v tenant ID, got %v", e, a)
+	}
+	if e, a := "us-east-1", result.Region; e != a {
+		t.Errorf("expect %v region, got %v", e, a)
+	}
+
+	dpa, ok := result.ServiceEndpoint(ServiceKeyDPA)
+	if !ok {
+		t.Fatalf("expect dpa endpoint to be discovered")
+	}
+	if e, a := "https://example.dpa.cyberark.cloud/api", dpa.API; e != a {
+		t.Errorf("expect %v dpa API, got %v", e, a)
+	}
+	if _, ok := result.ServiceEndpoint(ServiceKeySecretsHub); ok {
+		t.Errorf("expect secrets hub endpoint to not be discovered")
+	}
+}
+
+func TestClient_DiscoverErrors(t *testing.T) {
+	var calls int32
+	server := newDiscoveryServer(t, &calls, nil)
+	defer server.Close()
+
+	client := New(func(o *Options) {
+		o.Endpoint = server.URL
+	})
+
+	cases := map[string]struct {
+		TenantName   string
+		ExpectStatus int
+	}{
+		"not found":   {TenantName: "unknown", ExpectStatus: 404},
+		"no identity": {TenantName: "noidentity"},
+		"no tenant":   {TenantName: ""},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.Discover(context.Background(), c.TenantName)
+
+			var discoveryErr *Error
+			if !errors.As(err, &discoveryErr) {
+				t.Fatalf("expect %T error, got %v", discoveryErr, err)
+			}
+			if e, a := c.ExpectStatus, discoveryErr.HTTPStatusCode(); e != a {
+				t.Errorf("expect %v status code, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestClient_DiscoverCache(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	restoreTime := sdk.TestingUseReferenceTime(now)
+	defer restoreTime()
+
+	var calls int32
+	server := newDiscoveryServer(t, &calls, nil)
+	defer server.Close()
+
+	client := New(func(o *Options) {
+		o.Endpoint = server.URL
+		o.TTL = 10 * time.Minute
+	})
+
+	discover := func() {
+		t.Helper()
+		if _, err := client.Discover(context.Background(), "example"); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+
+	discover()
+	discover()
+	if e, a := int32(1), atomic.LoadInt32(&calls); e != a {
+		t.Errorf("expect %v discovery calls, got %v", e, a)
+	}
+
+	sdk.TestingUseReferenceTime(now.Add(10 * time.Minute))
+	discover()
+	if e, a := int32(2), atomic.LoadInt32(&calls); e != a {
+		t.Errorf("expect %v discovery calls after TTL, got %v", e, a)
+	}
+
+	client.Invalidate("example")
+	discover()
+	if e, a := int32(3), atomic.LoadInt32(&calls); e != a {
+		t.Errorf("expect %v discovery calls after invalidate, got %v", e, a)
+	}
+}
+
+func TestClient_DiscoverErrorCache(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		ErrorTTL    time.Duration
+		ExpectCalls int32
+	}{
+		"default error TTL": {
+			ExpectCalls: 1,
+		},
+		"error caching disabled": {
+			ErrorTTL:    -1,
+			ExpectCalls: 2,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			restoreTime := sdk.TestingUseReferenceTime(now)
+			defer restoreTime()
+
+			var calls int32
+			server := newDiscoveryServer(t, &calls, nil)
+			defer server.Close()
+
+			client := New(func(o *Options) {
+				o.Endpoint = server.URL
+				if c.ErrorTTL != 0 {
+					o.ErrorTTL = c.ErrorTTL
+				}
+			})
+
+			discover := func() {
+				t.Helper()
+				_, err := client.Discover(context.Background(), "outage")
+
+				var discoveryErr *Error
+				if !errors.As(err, &discoveryErr) {
+					t.Fatalf("expect %T error, got %v", discoveryErr, err)
+				}
+				if e, a := http.StatusServiceUnavailable, discoveryErr.HTTPStatusCode(); e != a {
+					t.Errorf("expect %v status code, got %v", e, a)
+				}
+			}
+
+			discover()
+			discover()
+			if e, a := c.ExpectCalls, atomic.LoadInt32(&calls); e != a {
+				t.Errorf("expect %v discovery calls, got %v", e, a)
+			}
+
+			sdk.TestingUseReferenceTime(now.Add(DefaultErrorTTL))
+			discover()
+			if e, a := c.ExpectCalls+1, atomic.LoadInt32(&calls); e != a {
+				t.Errorf("expect %v discovery calls after error TTL, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestClient_DiscoverConcurrent(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := newDiscoveryServer(t, &calls, release)
+	defer server.Close()
+
+	client := New(func(o *Options) {
+		o.Endpoint = server.URL
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Discover(context.Background(), "example"); err != nil {
+				t.Errorf("expect no error, got %v", err)
+			}
+		}()
+	}
+
+	// Wait for the shared request to be in flight before releasing it.
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if e, a := int32(1), atomic.LoadInt32(&calls); e != a {
+		t.Errorf("expect %v discovery calls, got %v", e, a)
+	}
+}
@@ -38,17 +38,6 @@ type Endpoint struct {
 	// API Client's Options.
 	HostnameImmutable bool
 
-	// The AWS partition the endpoint belongs to.
-	PartitionID string
-
-	// The service name that should be used for signing the requests to the
-	// endpoint.
-	SigningName string
-
-	// The signing method that should be used for signing the requests to the
-	// endpoint.
-	SigningMethod string
-
 	// The source of the Endpoint. By default, this will be EndpointSourceServiceMetadata.
 	// When providing a custom endpoint, you should set the source as EndpointSourceCustom.
 	// If source is not provided when providing a custom endpoint, the SDK may not
@@ -60,9 +49,20 @@ type Endpoint struct {
 // EndpointSource is the endpoint source type.
 type EndpointSource int
 
+const (
+	// EndpointSourceServiceMetadata denotes the endpoint was derived from the
+	// service's endpoint metadata and the client's tenant name.
+	EndpointSourceServiceMetadata EndpointSource = iota
+
+	// EndpointSourceCustom denotes endpoint is a custom endpoint. This source
+	// should be used when user provides a custom endpoint to be used by the
+	// SDK, such as with Config.BaseEndpoint.
+	EndpointSourceCustom
+)
+
 // EndpointNotFoundError is a sentinel error to indicate that the
 // EndpointResolver implementation was unable to resolve an endpoint for the
-// given service and region. Resolvers should use this to indicate that an API
+// given service and tenant. Resolvers should use this to indicate that an API
 // client should fallback and attempt to use it's internal default resolver to
 // resolve the endpoint.
 type EndpointNotFoundError struct {
@@ -80,7 +80,7 @@ func (e *EndpointNotFoundError) Unwrap() error {
 }
 
 // EndpointResolverWithOptions is an endpoint resolver that can be used to provide or
-// override an endpoint for the given service, region, and the service client's EndpointOptions. API clients will
+// override an endpoint for the given service, and the service client's EndpointOptions. API clients will
 // attempt to use the EndpointResolverWithOptions first to resolve an endpoint if
 // available. If the EndpointResolverWithOptions returns an EndpointNotFoundError error,
 // API clients will fallback to attempting to resolve the endpoint using its
@@ -0,0 +1,113 @@
+package cybr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// ResponseError provides the HTTP centric error type wrapping the underlying
+// error with the HTTP response value and the deserialized request ID.
+//
+// The underlying error of a service's error response is an *APIError carrying
+// the service specific error code, message, and details. Use errors.As to
+// retrieve it.
+//
+//	var apiErr *cybr.APIError
+//	if errors.As(err, &apiErr) {
+//		log.Printf("code: %s, details: %s", apiErr.Code, apiErr.Details)
+//	}
+type ResponseError struct {
+	*smithyhttp.ResponseError
+
+	// RequestID associated with response error
+	RequestID string
+}
+
+// ServiceRequestID returns the request id associated with Response Error
+func (e *ResponseError) ServiceRequestID() string { return e.RequestID }
+
+// Error returns the formatted error
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf(
+		"https response error StatusCode: %d, RequestID: %s, %v",
+		e.Response.StatusCode, e.RequestID, e.Err)
+}
+
+// As populates target and returns true if the type of target is a error type that
+// the ResponseError embeds, (e.g. smithy HTTP ResponseError)
+func (e *ResponseError) As(target interface{}) bool {
+	return errors.As(e.ResponseError, target)
+}
+
+// APIError is the error returned by a CyberArk service in the body of an
+// error response. APIError implements the smithy.APIError interface.
+type APIError struct {
+	// Code is the service specific error code, (e.g. PASWS013E).
+	Code string
+
+	// Message is the error message returned by the service.
+	Message string
+
+	// Details is the additional description of the error returned by the
+	// service, if any.
+	Details string
+
+	// Fault is the party at fault for the error, if known.
+	Fault smithy.ErrorFault
+}
+
+// Error returns the formatted error
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("api error %s: %s", e.ErrorCode(), e.ErrorMessage())
+	if len(e.Details) != 0 {
+		msg += ", " + e.Details
+	}
+	return msg
+}
+
+// ErrorCode returns the service specific error code.
+func (e *APIError) ErrorCode() string { return e.Code }
+
+// ErrorMessage returns the error message.
+func (e *APIError) ErrorMessage() string { return e.Message }
+
+// ErrorFault returns the party at fault for the error.
+func (e *APIError) ErrorFault() smithy.ErrorFault { return e.Fault }
+
+// IsNotFound returns if the error is the result of an HTTP 404 Not Found
+// response.
+func IsNotFound(err error) bool {
+	return isHTTPStatusCode(err, http.StatusNotFound)
+}
+
+// IsConflict returns if the error is the result of an HTTP 409 Conflict
+// response.
+func IsConflict(err error) bool {
+	return isHTTPStatusCode(err, http.StatusConflict)
+}
+
+// IsUnauthorized returns if the error is the result of an HTTP 401
+// Unauthorized response.
+func IsUnauthorized(err error) bool {
+	return isHTTPStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsThrottled returns if the error is the result of an HTTP 429 Too Many
+// Requests response.
+func IsThrottled(err error) bool {
+	return isHTTPStatusCode(err, http.StatusTooManyRequests)
+}
+
+// isHTTPStatusCode returns if any error in the error's chain has the HTTP
+// status code.
+func isHTTPStatusCode(err error, statusCode int) bool {
+	var statusErr interface{ HTTPStatusCode() int }
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.HTTPStatusCode() == statusCode
+}
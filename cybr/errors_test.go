@@ -0,0 +1,108 @@
+package cybr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+func newTestResponseError(statusCode int, err error) *ResponseError {
+	return &ResponseError{
+		ResponseError: &smithyhttp.ResponseError{
+			Response: &smithyhttp.Response{Response: &http.Response{StatusCode: statusCode}},
+			Err:      err,
+		},
+		RequestID: "request-id",
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	apiErr := &APIError{
+		Code:    "PASWS013E",
+		Message: "Account not found",
+		Details: "the account does not exist",
+	}
+	err := fmt.Errorf("operation error: %w", newTestResponseError(http.StatusNotFound, apiErr))
+
+	expect := "operation error: https response error StatusCode: 404, RequestID: request-id, " +
+		"api error PASWS013E: Account not found, the account does not exist"
+	if e, a := expect, err.Error(); e != a {
+		t.Errorf("expect %v error, got %v", e, a)
+	}
+
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("expect %T error", respErr)
+	}
+	if e, a := "request-id", respErr.ServiceRequestID(); e != a {
+		t.Errorf("expect %v request ID, got %v", e, a)
+	}
+	if e, a := http.StatusNotFound, respErr.HTTPStatusCode(); e != a {
+		t.Errorf("expect %v status code, got %v", e, a)
+	}
+
+	var smithyRespErr *smithyhttp.ResponseError
+	if !errors.As(err, &smithyRespErr) {
+		t.Errorf("expect %T error", smithyRespErr)
+	}
+
+	var smithyAPIErr smithy.APIError
+	if !errors.As(err, &smithyAPIErr) {
+		t.Fatalf("expect %T error", smithyAPIErr)
+	}
+	if e, a := "PASWS013E", smithyAPIErr.ErrorCode(); e != a {
+		t.Errorf("expect %v error code, got %v", e, a)
+	}
+}
+
+func TestIsHTTPStatusCodeErrors(t *testing.T) {
+	cases := map[string]struct {
+		Err                                        error
+		NotFound, Conflict, Unauthorized, Throttle bool
+	}{
+		"nil": {},
+		"not response error": {
+			Err: fmt.Errorf("some error"),
+		},
+		"not found": {
+			Err:      newTestResponseError(http.StatusNotFound, &APIError{}),
+			NotFound: true,
+		},
+		"conflict": {
+			Err:      fmt.Errorf("wrapped: %w", newTestResponseError(http.StatusConflict, &APIError{})),
+			Conflict: true,
+		},
+		"unauthorized": {
+			Err:          newTestResponseError(http.StatusUnauthorized, &APIError{}),
+			Unauthorized: true,
+		},
+		"throttled": {
+			Err:      newTestResponseError(http.StatusTooManyRequests, &APIError{}),
+			Throttle: true,
+		},
+		"server error": {
+			Err: newTestResponseError(http.StatusInternalServerError, &APIError{}),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.NotFound, IsNotFound(c.Err); e != a {
+				t.Errorf("expect not found %v, got %v", e, a)
+			}
+			if e, a := c.Conflict, IsConflict(c.Err); e != a {
+				t.Errorf("expect conflict %v, got %v", e, a)
+			}
+			if e, a := c.Unauthorized, IsUnauthorized(c.Err); e != a {
+				t.Errorf("expect unauthorized %v, got %v", e, a)
+			}
+			if e, a := c.Throttle, IsThrottled(c.Err); e != a {
+				t.Errorf("expect throttled %v, got %v", e, a)
+			}
+		})
+	}
+}
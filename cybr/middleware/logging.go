@@ -0,0 +1,326 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+// RedactedValue is logged in place of redacted header and body values.
+const RedactedValue = "[REDACTED]"
+
+// RedactionRules are the rules for redacting sensitive values from the HTTP
+// request and response messages logged by the RequestResponseLogger.
+type RedactionRules struct {
+	// Headers are the names of the HTTP headers whose values are redacted.
+	// Header names are matched case-insensitively.
+	Headers []string
+
+	// Fields are the names of the JSON object members, and form-encoded
+	// fields, whose values are redacted. JSON members are redacted at any
+	// depth of the document. Field names are matched case-insensitively.
+	Fields []string
+}
+
+// DefaultRedactionRules returns the rules used to redact credentials, tokens,
+// and secrets from logged messages when none are provided. A new value is
+// returned on each call, so the rules can be extended by the caller.
+//
+//	rules := middleware.DefaultRedactionRules()
+//	rules.Fields = append(rules.Fields, "ssn")
+func DefaultRedactionRules() RedactionRules {
+	return RedactionRules{
+		Headers: []string{
+			"Authorization",
+			"Proxy-Authorization",
+			"Cookie",
+			"Set-Cookie",
+		},
+		Fields: []string{
+			"client_secret",
+			"password",
+			"secret",
+			"newCredentials",
+			"access_token",
+			"refresh_token",
+			"id_token",
+			"token",
+			"bearer_token",
+			"code_verifier",
+		},
+	}
+}
+
+// redactor applies a set of RedactionRules.
+type redactor struct {
+	headers map[string]struct{}
+	fields  map[string]struct{}
+}
+
+func newRedactor(rules RedactionRules) redactor {
+	r := redactor{
+		headers: make(map[string]struct{}, len(rules.Headers)),
+		fields:  make(map[string]struct{}, len(rules.Fields)),
+	}
+	for _, h := range rules.Headers {
+		r.headers[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+	for _, f := range rules.Fields {
+		r.fields[strings.ToLower(f)] = struct{}{}
+	}
+	return r
+}
+
+// redactHeader returns a copy of the header with the values of the redacted
+// headers replaced.
+func (r redactor) redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for k, vs := range redacted {
+		if _, ok := r.headers[http.CanonicalHeaderKey(k)]; !ok {
+			continue
+		}
+		for i := range vs {
+			vs[i] = RedactedValue
+		}
+	}
+	return redacted
+}
+
+// redactBody returns the body with the values of redacted fields replaced.
+// JSON and form-encoded bodies are redacted, other bodies are returned as is.
+func (r redactor) redactBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/x-www-form-urlencoded" {
+		return r.redactForm(body)
+	}
+
+	if json.Valid(body) {
+		return r.redactJSON(body)
+	}
+
+	return body
+}
+
+func (r redactor) redactForm(body []byte) []byte {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return []byte(RedactedValue)
+	}
+
+	for k, vs := range values {
+		if _, ok := r.fields[strings.ToLower(k)]; !ok {
+			continue
+		}
+		for i := range vs {
+			vs[i] = RedactedValue
+		}
+	}
+	return []byte(values.Encode())
+}
+
+func (r redactor) redactJSON(body []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return []byte(RedactedValue)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(r.redactJSONValue(v)); err != nil {
+		return []byte(RedactedValue)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+func (r redactor) redactJSONValue(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		for k, mv := range tv {
+			if _, ok := r.fields[strings.ToLower(k)]; ok {
+				tv[k] = RedactedValue
+				continue
+			}
+			tv[k] = r.redactJSONValue(mv)
+		}
+	case []interface{}:
+		for i, lv := range tv {
+			tv[i] = r.redactJSONValue(lv)
+		}
+	}
+	return v
+}
+
+// RequestResponseLogger is a DeserializeMiddleware that logs the HTTP request
+// and response messages of each request attempt to the stack's logger. Values
+// matching the RedactionRules are redacted from the logged messages.
+type RequestResponseLogger struct {
+	LogRequest         bool
+	LogRequestWithBody bool
+
+	LogResponse         bool
+	LogResponseWithBody bool
+
+	// RedactionRules are the rules for redacting sensitive values from the
+	// logged messages. If nil, DefaultRedactionRules will be used.
+	RedactionRules *RedactionRules
+}
+
+// ID is the middleware identifier.
+func (r *RequestResponseLogger) ID() string {
+	return "RequestResponseLogger"
+}
+
+// HandleDeserialize logs the request and response messages, with sensitive
+// values redacted.
+func (r *RequestResponseLogger) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	logger := middleware.GetLogger(ctx)
+
+	rules := DefaultRedactionRules()
+	if r.RedactionRules != nil {
+		rules = *r.RedactionRules
+	}
+	redact := newRedactor(rules)
+
+	if r.LogRequest || r.LogRequestWithBody {
+		req, ok := in.Request.(*smithyhttp.Request)
+		if !ok {
+			return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+		}
+
+		var dump []byte
+		dump, req, err = dumpRequest(ctx, req, redact, r.LogRequestWithBody)
+		if err != nil {
+			return out, metadata, fmt.Errorf("failed to dump request, %w", err)
+		}
+		in.Request = req
+
+		logger.Logf(logging.Debug, "Request\n%s", dump)
+	}
+
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil || !(r.LogResponse || r.LogResponseWithBody) {
+		return out, metadata, err
+	}
+
+	resp, ok := out.RawResponse.(*smithyhttp.Response)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", out.RawResponse)
+	}
+
+	dump, err := dumpResponse(resp, redact, r.LogResponseWithBody)
+	if err != nil {
+		return out, metadata, fmt.Errorf("failed to dump response, %w", err)
+	}
+
+	logger.Logf(logging.Debug, "Response\n%s", dump)
+
+	return out, metadata, nil
+}
+
+// dumpRequest returns the wire representation of the request with sensitive
+// values redacted. If the body is logged, the request returned must be used
+// in place of the request provided, as the body's stream is consumed.
+func dumpRequest(ctx context.Context, req *smithyhttp.Request, redact redactor, withBody bool) (
+	[]byte, *smithyhttp.Request, error,
+) {
+	rc := req.Build(ctx)
+
+	var body []byte
+	if withBody && rc.Body != nil && rc.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(rc.Body); err != nil {
+			return nil, req, err
+		}
+		if req, err = req.SetStream(bytes.NewReader(body)); err != nil {
+			return nil, req, err
+		}
+	}
+
+	// The logged copy's body is never read, as the body is dumped separately
+	// once redacted.
+	logged := rc.Clone(ctx)
+	logged.Header = redact.redactHeader(rc.Header)
+	if body != nil {
+		logged.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	dump, err := httputil.DumpRequestOut(logged, false)
+	if err != nil {
+		return nil, req, err
+	}
+
+	return append(dump, redact.redactBody(rc.Header.Get("Content-Type"), body)...), req, nil
+}
+
+// dumpResponse returns the wire representation of the response with
+// sensitive values redacted. If the body is logged, it is read and replaced
+// so it can still be deserialized.
+func dumpResponse(resp *smithyhttp.Response, redact redactor, withBody bool) ([]byte, error) {
+	var body []byte
+	if withBody && resp.Body != nil && resp.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	logged := *resp.Response
+	logged.Header = redact.redactHeader(resp.Header)
+	logged.Body = nil
+
+	dump, err := httputil.DumpResponse(&logged, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(dump, redact.redactBody(resp.Header.Get("Content-Type"), body)...), nil
+}
+
+// AddRequestResponseLogMiddlewareOptions is the set of options for adding the
+// RequestResponseLogger middleware to a stack.
+type AddRequestResponseLogMiddlewareOptions struct {
+	// The client log mode selecting the request and response messages logged.
+	ClientLogMode cybr.ClientLogMode
+
+	// The rules for redacting sensitive values from the logged messages. If
+	// nil, DefaultRedactionRules will be used.
+	RedactionRules *RedactionRules
+}
+
+// AddRequestResponseLogMiddleware adds the RequestResponseLogger middleware
+// to the end of the stack's Deserialize step, so the messages of each request
+// attempt are logged as sent and received.
+func AddRequestResponseLogMiddleware(stack *middleware.Stack, options AddRequestResponseLogMiddlewareOptions) error {
+	return stack.Deserialize.Add(&RequestResponseLogger{
+		LogRequest:          options.ClientLogMode.IsRequest(),
+		LogRequestWithBody:  options.ClientLogMode.IsRequestWithBody(),
+		LogResponse:         options.ClientLogMode.IsResponse(),
+		LogResponseWithBody: options.ClientLogMode.IsResponseWithBody(),
+		RedactionRules:      options.RedactionRules,
+	}, middleware.After)
+}
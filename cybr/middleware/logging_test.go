@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+func TestRedactor(t *testing.T) {
+	cases := map[string]struct {
+		Rules       *RedactionRules
+		ContentType string
+		Body        string
+		Expect      string
+	}{
+		"json": {
+			ContentType: "application/json",
+			Body:        `{"userName":"admin","Password":"p@ss","nested":[{"secret":"s3cr3t","name":"a<b"}]}`,
+			Expect:      `{"Password":"[REDACTED]","nested":[{"name":"a<b","secret":"[REDACTED]"}],"userName":"admin"}`,
+		},
+		"json tokens": {
+			ContentType: "application/json",
+			Body:        `{"access_token":"abc","expires_in":3600,"token_type":"Bearer"}`,
+			Expect:      `{"access_token":"[REDACTED]","expires_in":3600,"token_type":"Bearer"}`,
+		},
+		"json object value": {
+			Body:   `{"newCredentials":{"value":"abc"}}`,
+			Expect: `{"newCredentials":"[REDACTED]"}`,
+		},
+		"form": {
+			ContentType: "application/x-www-form-urlencoded; charset=utf-8",
+			Body:        "client_id=user%40example.com&client_secret=abc&grant_type=client_credentials",
+			Expect:      "client_id=user%40example.com&client_secret=%5BREDACTED%5D&grant_type=client_credentials",
+		},
+		"text": {
+			ContentType: "text/plain",
+			Body:        "password",
+			Expect:      "password",
+		},
+		"custom rules": {
+			Rules:  &RedactionRules{Fields: []string{"ssn"}},
+			Body:   `{"password":"p@ss","SSN":"123"}`,
+			Expect: `{"SSN":"[REDACTED]","password":"p@ss"}`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			rules := DefaultRedactionRules()
+			if c.Rules != nil {
+				rules = *c.Rules
+			}
+
+			actual := newRedactor(rules).redactBody(c.ContentType, []byte(c.Body))
+			if e, a := c.Expect, string(actual); e != a {
+				t.Errorf("expect %v body, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestRequestResponseLogger(t *testing.T) {
+	cases := map[string]struct {
+		Mode          cybr.ClientLogMode
+		ExpectLogged  []string
+		ExpectOmitted []string
+	}{
+		"none": {
+			ExpectOmitted: []string{"Request", "Response"},
+		},
+		"request": {
+			Mode:          cybr.LogRequest,
+			ExpectLogged:  []string{"POST /accounts", "Authorization: [REDACTED]", "X-Custom: value"},
+			ExpectOmitted: []string{"token-value", "userName", "Response"},
+		},
+		"request with body": {
+			Mode:          cybr.LogRequestWithBody,
+			ExpectLogged:  []string{"Authorization: [REDACTED]", `{"secret":"[REDACTED]","userName":"admin"}`},
+			ExpectOmitted: []string{"token-value", "account-secret", "Response"},
+		},
+		"response": {
+			Mode:          cybr.LogResponse,
+			ExpectLogged:  []string{"200 OK", "Set-Cookie: [REDACTED]"},
+			ExpectOmitted: []string{"Request", "session", "refresh_token"},
+		},
+		"response with body": {
+			Mode:          cybr.LogResponseWithBody,
+			ExpectLogged:  []string{"200 OK", `{"id":"1","refresh_token":"[REDACTED]"}`},
+			ExpectOmitted: []string{"Request", "session", "refresh-value"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var logged bytes.Buffer
+			ctx := middleware.SetLogger(context.Background(), logging.NewStandardLogger(&logged))
+
+			req := smithyhttp.NewStackRequest().(*smithyhttp.Request)
+			req.Method = http.MethodPost
+			req.URL.Scheme = "https"
+			req.URL.Host = "example.privilegecloud.cyberark.cloud"
+			req.URL.Path = "/accounts"
+			req.Header.Set("Authorization", "Bearer token-value")
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Custom", "value")
+			req, err := req.SetStream(strings.NewReader(`{"userName":"admin","secret":"account-secret"}`))
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			m := &RequestResponseLogger{
+				LogRequest:          c.Mode.IsRequest(),
+				LogRequestWithBody:  c.Mode.IsRequestWithBody(),
+				LogResponse:         c.Mode.IsResponse(),
+				LogResponseWithBody: c.Mode.IsResponseWithBody(),
+			}
+
+			out, _, err := m.HandleDeserialize(ctx, middleware.DeserializeInput{Request: req},
+				middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (
+					out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+				) {
+					b, err := io.ReadAll(in.Request.(*smithyhttp.Request).GetStream())
+					if err != nil {
+						t.Fatalf("expect no error, got %v", err)
+					}
+					if e, a := `{"userName":"admin","secret":"account-secret"}`, string(b); e != a {
+						t.Errorf("expect %v request body, got %v", e, a)
+					}
+
+					out.RawResponse = &smithyhttp.Response{Response: &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     "200 OK",
+						ProtoMajor: 1,
+						ProtoMinor: 1,
+						Header: http.Header{
+							"Content-Type": []string{"application/json"},
+							"Set-Cookie":   []string{"session=abc"},
+						},
+						Body: io.NopCloser(strings.NewReader(`{"id":"1","refresh_token":"refresh-value"}`)),
+					}}
+					return out, metadata, nil
+				}),
+			)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			b, err := io.ReadAll(out.RawResponse.(*smithyhttp.Response).Body)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := `{"id":"1","refresh_token":"refresh-value"}`, string(b); e != a {
+				t.Errorf("expect %v response body, got %v", e, a)
+			}
+
+			for _, e := range c.ExpectLogged {
+				if a := logged.String(); !strings.Contains(a, e) {
+					t.Errorf("expect log to contain %q, got %q", e, a)
+				}
+			}
+			for _, e := range c.ExpectOmitted {
+				if a := logged.String(); strings.Contains(a, e) {
+					t.Errorf("expect log to not contain %q, got %q", e, a)
+				}
+			}
+		})
+	}
+}
+
+func TestAddRequestResponseLogMiddleware(t *testing.T) {
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	err := AddRequestResponseLogMiddleware(stack, AddRequestResponseLogMiddlewareOptions{
+		ClientLogMode: cybr.LogRequest | cybr.LogResponseWithBody,
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	m, ok := stack.Deserialize.Get((&RequestResponseLogger{}).ID())
+	if !ok {
+		t.Fatalf("expect logger middleware in deserialize step")
+	}
+	logger := m.(*RequestResponseLogger)
+	if !logger.LogRequest || logger.LogRequestWithBody || logger.LogResponse || !logger.LogResponseWithBody {
+		t.Errorf("expect request, and response with body logged, got %+v", logger)
+	}
+}
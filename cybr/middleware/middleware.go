@@ -13,8 +13,13 @@ import (
 	"github.com/strick-j/cybr-sdk-go/internal/sdk"
 )
 
+// invocationIDHeader is the header the unique ID of the API operation
+// invocation is sent with.
+const invocationIDHeader = "Cybr-Sdk-Invocation-Id"
+
 // ClientRequestID is a Smithy BuildMiddleware that will generate a unique ID for logical API operation
-// invocation.
+// invocation. The ID is sent with the Cybr-Sdk-Invocation-Id header, and is the same for all attempts
+// of the operation.
 type ClientRequestID struct{}
 
 // ID the identifier for the ClientRequestID
@@ -36,7 +41,6 @@ func (r ClientRequestID) HandleBuild(ctx context.Context, in middleware.BuildInp
 		return out, metadata, err
 	}
 
-	const invocationIDHeader = "Amz-Sdk-Invocation-Id"
 	req.Header[invocationIDHeader] = append(req.Header[invocationIDHeader][:0], invocationID)
 
 	return next.HandleBuild(ctx, in)
@@ -7,14 +7,42 @@ import (
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
+// DefaultRequestIDHeaders are the response headers CyberArk services return
+// the request's correlation ID in, in the order they are checked.
+var DefaultRequestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Correlation-Id",
+	"X-Cfy-Tx-Id",
+	"X-Amzn-Requestid",
+	"X-Amz-Request-Id",
+}
+
+// RequestIDRetrieverOptions is the set of options for the request ID
+// retriever middleware.
+type RequestIDRetrieverOptions struct {
+	// Headers are the response headers checked, in order, for the request
+	// ID. Defaults to DefaultRequestIDHeaders.
+	Headers []string
+}
+
 // AddRequestIDRetrieverMiddleware adds request id retriever middleware
-func AddRequestIDRetrieverMiddleware(stack *middleware.Stack) error {
+func AddRequestIDRetrieverMiddleware(stack *middleware.Stack, optFns ...func(*RequestIDRetrieverOptions)) error {
+	options := RequestIDRetrieverOptions{
+		Headers: DefaultRequestIDHeaders,
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
 	// add error wrapper middleware before operation deserializers so that it can wrap the error response
 	// returned by operation deserializers
-	return stack.Deserialize.Insert(&requestIDRetriever{}, "OperationDeserializer", middleware.Before)
+	return stack.Deserialize.Insert(&requestIDRetriever{
+		headers: options.Headers,
+	}, "OperationDeserializer", middleware.Before)
 }
 
 type requestIDRetriever struct {
+	headers []string
 }
 
 // ID returns the middleware identifier
@@ -33,14 +61,10 @@ func (m *requestIDRetriever) HandleDeserialize(ctx context.Context, in middlewar
 		return out, metadata, err
 	}
 
-	// TODO : CHECK IF APPLICABLE
-	// Different header which can map to request id
-	requestIDHeaderList := []string{"X-Amzn-Requestid", "X-Amz-RequestId"}
-
-	for _, h := range requestIDHeaderList {
+	for _, h := range m.headers {
 		// check for headers known to contain Request id
 		if v := resp.Header.Get(h); len(v) != 0 {
-			// set reqID on metadata for successful responses.
+			// set reqID on metadata for successful, and error responses.
 			SetRequestIDMetadata(&metadata, v)
 			break
 		}
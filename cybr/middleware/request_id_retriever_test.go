@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+func TestRequestIDRetriever(t *testing.T) {
+	cases := map[string]struct {
+		Headers         []string
+		ResponseHeaders http.Header
+		ExpectFound     bool
+		ExpectID        string
+	}{
+		"no header": {
+			ResponseHeaders: http.Header{},
+		},
+		"default header": {
+			ResponseHeaders: http.Header{"X-Cfy-Tx-Id": []string{"identity-tx"}},
+			ExpectFound:     true,
+			ExpectID:        "identity-tx",
+		},
+		"default header order": {
+			ResponseHeaders: http.Header{
+				"X-Amzn-Requestid": []string{"gateway-id"},
+				"X-Request-Id":     []string{"request-id"},
+			},
+			ExpectFound: true,
+			ExpectID:    "request-id",
+		},
+		"custom headers": {
+			Headers: []string{"X-Custom-Id"},
+			ResponseHeaders: http.Header{
+				"X-Request-Id": []string{"request-id"},
+				"X-Custom-Id":  []string{"custom-id"},
+			},
+			ExpectFound: true,
+			ExpectID:    "custom-id",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+			stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("OperationDeserializer",
+				func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+					middleware.DeserializeOutput, middleware.Metadata, error,
+				) {
+					return next.HandleDeserialize(ctx, in)
+				}), middleware.After)
+
+			err := AddRequestIDRetrieverMiddleware(stack, func(o *RequestIDRetrieverOptions) {
+				if c.Headers != nil {
+					o.Headers = c.Headers
+				}
+			})
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			handler := middleware.DecorateHandler(smithyhttp.NewClientHandler(smithyhttp.ClientDoFunc(
+				func(*http.Request) (*http.Response, error) {
+					return &http.Response{StatusCode: 500, Header: c.ResponseHeaders, Body: http.NoBody}, nil
+				})), stack)
+
+			_, metadata, err := handler.Handle(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			id, found := GetRequestIDMetadata(metadata)
+			if e, a := c.ExpectFound, found; e != a {
+				t.Fatalf("expect request ID found %v, got %v", e, a)
+			}
+			if e, a := c.ExpectID, id; e != a {
+				t.Errorf("expect %v request ID, got %v", e, a)
+			}
+		})
+	}
+}
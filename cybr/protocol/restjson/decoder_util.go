@@ -6,45 +6,103 @@ import (
 	"strings"
 
 	"github.com/aws/smithy-go"
+	"github.com/strick-j/cybr-sdk-go/cybr"
 )
 
-// GetErrorInfo util looks for code, __type, and message members in the
-// json body. These members are optionally available, and the function
-// returns the value of member if it is available. This function is useful to
-// identify the error code, msg in a REST JSON error response.
-func GetErrorInfo(decoder *json.Decoder) (errorType string, message string, err error) {
+// ErrorInfo is the error information decoded from the JSON body of an error
+// response.
+type ErrorInfo struct {
+	// Code is the service specific error code.
+	Code string
+
+	// Message is the error message.
+	Message string
+
+	// Details is the additional description of the error, if any.
+	Details string
+
+	// RequestID is the identifier of the failed request included in the body,
+	// if any.
+	RequestID string
+}
+
+// DecodeErrorInfo decodes the error information from the JSON error response
+// body. The error response shapes of the following services are recognized,
+// with member names matched case-insensitively.
+//
+//	Privilege Cloud:   {"ErrorCode": "...", "ErrorMessage": "...", "Details": "..."}
+//	Identity:          {"success": false, "Message": "...", "MessageID": "...", "ErrorID": "..."}
+//	DPA:               {"code": "...", "message": "...", "description": "..."}
+//	Generic:           {"__type": "...", "message": "..."}
+//
+// Members not present in the body are left empty. An empty body is not an
+// error.
+func DecodeErrorInfo(decoder *json.Decoder) (info ErrorInfo, err error) {
 	var errInfo struct {
-		Code    string
-		Type    string `json:"__type"`
-		Message string
+		// Generic, and DPA
+		Code        string
+		Type        string `json:"__type"`
+		Message     string
+		Description string
+
+		// Privilege Cloud
+		ErrorCode    string
+		ErrorMessage string
+		Details      string
+
+		// Identity
+		MessageID string
+		ErrorID   string
 	}
 
 	err = decoder.Decode(&errInfo)
 	if err != nil {
 		if err == io.EOF {
-			return errorType, message, nil
+			return info, nil
 		}
-		return errorType, message, err
+		return info, err
 	}
 
-	// assign error type
-	if len(errInfo.Code) != 0 {
-		errorType = errInfo.Code
-	} else if len(errInfo.Type) != 0 {
-		errorType = errInfo.Type
+	// assign error code
+	for _, v := range []string{errInfo.ErrorCode, errInfo.Code, errInfo.Type, errInfo.MessageID} {
+		if len(v) != 0 {
+			info.Code = SanitizeErrorCode(v)
+			break
+		}
 	}
 
 	// assign error message
-	if len(errInfo.Message) != 0 {
-		message = errInfo.Message
+	if len(errInfo.ErrorMessage) != 0 {
+		info.Message = errInfo.ErrorMessage
+	} else {
+		info.Message = errInfo.Message
 	}
 
-	// sanitize error
-	if len(errorType) != 0 {
-		errorType = SanitizeErrorCode(errorType)
+	// assign error details
+	if len(errInfo.Details) != 0 {
+		info.Details = errInfo.Details
+	} else {
+		info.Details = errInfo.Description
 	}
 
-	return errorType, message, nil
+	info.RequestID = errInfo.ErrorID
+
+	return info, nil
+}
+
+// GetErrorInfo util looks for code, __type, and message members in the
+// json body. These members are optionally available, and the function
+// returns the value of member if it is available. This function is useful to
+// identify the error code, msg in a REST JSON error response.
+//
+// See DecodeErrorInfo for the error response shapes recognized.
+func GetErrorInfo(decoder *json.Decoder) (errorType string, message string, err error) {
+	info, err := DecodeErrorInfo(decoder)
+	if err != nil {
+		return errorType, message, err
+	}
+
+	return info.Code, info.Message, nil
 }
 
 // SanitizeErrorCode sanitizes the errorCode string .
@@ -83,3 +141,23 @@ func GetSmithyGenericAPIError(decoder *json.Decoder, errorCode string) (*smithy.
 		Message: message,
 	}, nil
 }
+
+// GetAPIError returns the cybr.APIError decoded from the error response body.
+// If errorCode of length greater than 0 is passed in as an argument, it is used
+// instead of the code decoded from the body.
+func GetAPIError(decoder *json.Decoder, errorCode string) (*cybr.APIError, error) {
+	info, err := DecodeErrorInfo(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(errorCode) == 0 {
+		errorCode = info.Code
+	}
+
+	return &cybr.APIError{
+		Code:    errorCode,
+		Message: info.Message,
+		Details: info.Details,
+	}, nil
+}
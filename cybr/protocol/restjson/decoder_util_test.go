@@ -50,6 +50,18 @@ func TestGetErrorInfo(t *testing.T) {
 			expectedDeserializationError: io.ErrUnexpectedEOF.Error(),
 		},
 
+		"privilege cloud": {
+			errorResponse:     []byte(`{"ErrorCode": "PASWS013E", "ErrorMessage": "Account not found"}`),
+			expectedErrorType: "PASWS013E",
+			expectedErrorMsg:  "Account not found",
+		},
+
+		"identity": {
+			errorResponse:     []byte(`{"success": false, "Result": null, "Message": "Authentication failed", "MessageID": "_I18N_AuthFailed", "ErrorID": "abc:123", "ErrorCode": null}`),
+			expectedErrorType: "_I18N_AuthFailed",
+			expectedErrorMsg:  "Authentication failed",
+		},
+
 		"caseless compare": {
 			errorResponse:     []byte(`{"Code": "errorCode", "Message": "errorMessage", "xyz": "abc"}`),
 			expectedErrorType: "errorCode",
@@ -81,3 +93,83 @@ func TestGetErrorInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeErrorInfo(t *testing.T) {
+	cases := map[string]struct {
+		errorResponse []byte
+		expect        ErrorInfo
+	}{
+		"privilege cloud": {
+			errorResponse: []byte(`{"ErrorCode": "PASWS013E", "ErrorMessage": "Account not found", "Details": "id 12_3"}`),
+			expect: ErrorInfo{
+				Code:    "PASWS013E",
+				Message: "Account not found",
+				Details: "id 12_3",
+			},
+		},
+		"identity": {
+			errorResponse: []byte(`{"success": false, "Result": null, "Message": "Authentication failed", "MessageID": "AuthFailed", "ErrorID": "b4a0a6d0", "Exception": null}`),
+			expect: ErrorInfo{
+				Code:      "AuthFailed",
+				Message:   "Authentication failed",
+				RequestID: "b4a0a6d0",
+			},
+		},
+		"dpa": {
+			errorResponse: []byte(`{"code": "DPA_POLICY_NOT_FOUND", "message": "Policy not found", "description": "policy abc does not exist"}`),
+			expect: ErrorInfo{
+				Code:    "DPA_POLICY_NOT_FOUND",
+				Message: "Policy not found",
+				Details: "policy abc does not exist",
+			},
+		},
+		"empty": {},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			info, err := DecodeErrorInfo(json.NewDecoder(bytes.NewReader(c.errorResponse)))
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expect, info; e != a {
+				t.Errorf("expect %+v error info, got %+v", e, a)
+			}
+		})
+	}
+}
+
+func TestGetAPIError(t *testing.T) {
+	body := []byte(`{"code": "DPA_POLICY_NOT_FOUND", "message": "Policy not found", "description": "policy abc does not exist"}`)
+
+	cases := map[string]struct {
+		errorCode  string
+		expectCode string
+	}{
+		"body code": {
+			expectCode: "DPA_POLICY_NOT_FOUND",
+		},
+		"override code": {
+			errorCode:  "NotFound",
+			expectCode: "NotFound",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			apiErr, err := GetAPIError(json.NewDecoder(bytes.NewReader(body)), c.errorCode)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.expectCode, apiErr.Code; e != a {
+				t.Errorf("expect %v code, got %v", e, a)
+			}
+			if e, a := "Policy not found", apiErr.Message; e != a {
+				t.Errorf("expect %v message, got %v", e, a)
+			}
+			if e, a := "policy abc does not exist", apiErr.Details; e != a {
+				t.Errorf("expect %v details, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package restjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrmiddleware "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+)
+
+// identityEnvelope is the envelope CyberArk Identity wraps the result of
+// operations in.
+type identityEnvelope struct {
+	Success   *bool           `json:"success"`
+	Result    json.RawMessage `json:"Result"`
+	Message   string          `json:"Message"`
+	MessageID string          `json:"MessageID"`
+	ErrorID   string          `json:"ErrorID"`
+	ErrorCode string          `json:"ErrorCode"`
+	Exception json.RawMessage `json:"Exception"`
+}
+
+// IdentityEnvelope is a DeserializeMiddleware that unwraps the
+// {success, Result, Message, MessageID, ErrorID, Exception} envelope of
+// CyberArk Identity responses.
+//
+// Identity returns HTTP 200 responses for many failed operations, with the
+// failure only indicated by the envelope's success member. A success:false
+// envelope is converted into a *cybr.APIError, with the envelope's MessageID
+// as the error code. Otherwise the response body is replaced with the
+// envelope's Result, so the operation deserializer decodes the result
+// directly. Response bodies which are not an envelope are left unmodified.
+type IdentityEnvelope struct{}
+
+// ID returns the middleware identifier
+func (m *IdentityEnvelope) ID() string {
+	return "IdentityEnvelope"
+}
+
+// HandleDeserialize unwraps the Identity envelope of the raw response.
+func (m *IdentityEnvelope) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	resp, ok := out.RawResponse.(*smithyhttp.Response)
+	if !ok || resp.Body == nil {
+		return out, metadata, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return out, metadata, &smithy.DeserializationError{
+			Err: fmt.Errorf("failed to read response body, %w", err),
+		}
+	}
+
+	var envelope identityEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Success == nil {
+		// Not an Identity envelope, leave the body for the operation
+		// deserializer as is.
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return out, metadata, nil
+	}
+
+	if len(envelope.ErrorID) != 0 {
+		if _, ok := cybrmiddleware.GetRequestIDMetadata(metadata); !ok {
+			cybrmiddleware.SetRequestIDMetadata(&metadata, envelope.ErrorID)
+		}
+	}
+
+	if !*envelope.Success {
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return out, metadata, envelope.apiError()
+	}
+
+	result := []byte(envelope.Result)
+	if len(result) == 0 {
+		result = []byte("null")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(result))
+	resp.ContentLength = int64(len(result))
+
+	return out, metadata, nil
+}
+
+// apiError returns the API error for a success:false envelope.
+func (e identityEnvelope) apiError() *cybr.APIError {
+	code := e.MessageID
+	if len(code) == 0 {
+		code = e.ErrorCode
+	}
+
+	// The exception is only included as the error's details if it is a
+	// string, and not an exception object.
+	var details string
+	json.Unmarshal(e.Exception, &details)
+
+	return &cybr.APIError{
+		Code:    code,
+		Message: e.Message,
+		Details: details,
+		Fault:   smithy.FaultClient,
+	}
+}
+
+// AddIdentityEnvelopeMiddleware adds the IdentityEnvelope middleware to the
+// stack after the operation deserializer, so the deserializer receives the
+// unwrapped result.
+func AddIdentityEnvelopeMiddleware(stack *middleware.Stack) error {
+	return stack.Deserialize.Insert(&IdentityEnvelope{}, "OperationDeserializer", middleware.After)
+}
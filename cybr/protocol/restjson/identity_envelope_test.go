@@ -0,0 +1,124 @@
+package restjson
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrmiddleware "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+)
+
+func TestIdentityEnvelope(t *testing.T) {
+	cases := map[string]struct {
+		Body            string
+		ExpectBody      string
+		ExpectErr       *cybr.APIError
+		ExpectRequestID string
+	}{
+		"success": {
+			Body:       `{"success":true,"Result":{"Token":"abc","Count":1},"Message":null,"MessageID":null,"ErrorID":null}`,
+			ExpectBody: `{"Token":"abc","Count":1}`,
+		},
+		"success without result": {
+			Body:       `{"success":true,"Message":null}`,
+			ExpectBody: `null`,
+		},
+		"failure": {
+			Body: `{"success":false,"Result":null,"Message":"Authentication (login or challenge) has failed.",` +
+				`"MessageID":"_I18N_AuthenticationFailed","Exception":"MFA required","ErrorID":"b4a0a6d0:123","ErrorCode":null}`,
+			ExpectErr: &cybr.APIError{
+				Code:    "_I18N_AuthenticationFailed",
+				Message: "Authentication (login or challenge) has failed.",
+				Details: "MFA required",
+			},
+			ExpectRequestID: "b4a0a6d0:123",
+		},
+		"failure exception object": {
+			Body:      `{"success":false,"Message":"Failed","ErrorCode":"E1","Exception":{"Type":"System.Exception"}}`,
+			ExpectErr: &cybr.APIError{Code: "E1", Message: "Failed"},
+		},
+		"not envelope": {
+			Body:       `{"Token":"abc"}`,
+			ExpectBody: `{"Token":"abc"}`,
+		},
+		"not json": {
+			Body:       `<html></html>`,
+			ExpectBody: `<html></html>`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &IdentityEnvelope{}
+			out, metadata, err := m.HandleDeserialize(context.Background(), middleware.DeserializeInput{},
+				middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (
+					out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+				) {
+					out.RawResponse = &smithyhttp.Response{Response: &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(c.Body)),
+					}}
+					return out, metadata, nil
+				}),
+			)
+
+			if c.ExpectErr != nil {
+				var apiErr *cybr.APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("expect %T error, got %v", apiErr, err)
+				}
+				if e, a := c.ExpectErr.Code, apiErr.Code; e != a {
+					t.Errorf("expect %v code, got %v", e, a)
+				}
+				if e, a := c.ExpectErr.Message, apiErr.Message; e != a {
+					t.Errorf("expect %v message, got %v", e, a)
+				}
+				if e, a := c.ExpectErr.Details, apiErr.Details; e != a {
+					t.Errorf("expect %v details, got %v", e, a)
+				}
+			} else if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			requestID, _ := cybrmiddleware.GetRequestIDMetadata(metadata)
+			if e, a := c.ExpectRequestID, requestID; e != a {
+				t.Errorf("expect %v request ID, got %v", e, a)
+			}
+
+			if c.ExpectErr != nil {
+				return
+			}
+			b, err := io.ReadAll(out.RawResponse.(*smithyhttp.Response).Body)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if e, a := c.ExpectBody, string(b); e != a {
+				t.Errorf("expect %v body, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestAddIdentityEnvelopeMiddleware(t *testing.T) {
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("OperationDeserializer",
+		func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+			middleware.DeserializeOutput, middleware.Metadata, error,
+		) {
+			return next.HandleDeserialize(ctx, in)
+		}), middleware.After)
+
+	if err := AddIdentityEnvelopeMiddleware(stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := "OperationDeserializer,IdentityEnvelope", strings.Join(stack.Deserialize.List(), ","); e != a {
+		t.Errorf("expect %v middleware, got %v", e, a)
+	}
+}
@@ -0,0 +1,31 @@
+package ratelimit
+
+import "testing"
+
+func TestTokenBucket(t *testing.T) {
+	bucket := NewTokenBucket(10)
+
+	if avail, ok := bucket.Retrieve(4); !ok || avail != 6 {
+		t.Fatalf("expect 6 tokens retrieved, got %v, %v", avail, ok)
+	}
+	if avail, ok := bucket.Retrieve(7); ok || avail != 6 {
+		t.Fatalf("expect retrieve to fail with 6 available, got %v, %v", avail, ok)
+	}
+
+	bucket.Refund(100)
+	if e, a := uint(10), bucket.Remaining(); e != a {
+		t.Errorf("expect refund to be capped at %v, got %v", e, a)
+	}
+
+	if e, a := uint(5), bucket.Resize(5); e != a {
+		t.Errorf("expect %v remaining after resize, got %v", e, a)
+	}
+	if e, a := uint(5), bucket.Capacity(); e != a {
+		t.Errorf("expect %v capacity, got %v", e, a)
+	}
+
+	bucket.Resize(0)
+	if e, a := uint(1), bucket.Capacity(); e != a {
+		t.Errorf("expect capacity to be limited to min capacity %v, got %v", e, a)
+	}
+}
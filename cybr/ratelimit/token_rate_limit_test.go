@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTokenRateLimit(t *testing.T) {
+	limiter := NewTokenRateLimit(10)
+
+	release, err := limiter.GetToken(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, err := limiter.GetToken(context.Background(), 5); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	_, err = limiter.GetToken(context.Background(), 5)
+	var quotaErr QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("expect %T error, got %v", quotaErr, err)
+	}
+
+	if err := release(); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := uint(5), limiter.Remaining(); e != a {
+		t.Errorf("expect %v remaining, got %v", e, a)
+	}
+}
+
+func TestTokenRateLimit_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewTokenRateLimit(10).GetToken(ctx, 5)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expect canceled error, got %v", err)
+	}
+}
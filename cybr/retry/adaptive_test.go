@@ -0,0 +1,178 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+)
+
+type mockErrorCode string
+
+func (m mockErrorCode) Error() string     { return string(m) }
+func (m mockErrorCode) ErrorCode() string { return string(m) }
+
+func TestIsErrorThrottles(t *testing.T) {
+	cases := map[string]struct {
+		Err    error
+		Expect cybr.Ternary
+	}{
+		"no error":       {Err: nil, Expect: cybr.UnknownTernary},
+		"unknown error":  {Err: fmt.Errorf("some error"), Expect: cybr.UnknownTernary},
+		"status 429":     {Err: newHTTPStatusError(429), Expect: cybr.TrueTernary},
+		"status 503":     {Err: newHTTPStatusError(503), Expect: cybr.UnknownTernary},
+		"throttle code":  {Err: mockErrorCode("TooManyRequests"), Expect: cybr.TrueTernary},
+		"unknown code":   {Err: mockErrorCode("NotFound"), Expect: cybr.UnknownTernary},
+		"wrapped status": {Err: fmt.Errorf("wrapped, %w", newHTTPStatusError(429)), Expect: cybr.TrueTernary},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if e, a := c.Expect, IsErrorThrottles(DefaultThrottles).IsErrorThrottle(c.Err); e != a {
+				t.Errorf("expect %v throttle, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestAdaptiveTokenBucket(t *testing.T) {
+	bucket := newAdaptiveTokenBucket(10)
+
+	if v, ok := bucket.Retrieve(4); !ok || v != 6 {
+		t.Errorf("expect 6 remaining and retrieved, got %v, %v", v, ok)
+	}
+	if v, ok := bucket.Retrieve(7); ok || v != 6 {
+		t.Errorf("expect 6 remaining and not retrieved, got %v, %v", v, ok)
+	}
+
+	bucket.Refund(10)
+	if e, a := 10., bucket.Remaining(); e != a {
+		t.Errorf("expect refund to be capped at %v, got %v", e, a)
+	}
+
+	if e, a := 5., bucket.Resize(5); e != a {
+		t.Errorf("expect resize to cap remaining at %v, got %v", e, a)
+	}
+	if e, a := 1., bucket.Resize(0); e != a {
+		t.Errorf("expect resize to be at least min capacity %v, got %v", e, a)
+	}
+}
+
+func TestAdaptiveRateLimit(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	restoreTime := sdk.TestingUseReferenceTime(now)
+	defer restoreTime()
+
+	rateLimit := newAdaptiveRateLimit()
+
+	// Unrestricted until the first throttle.
+	for i := 0; i < 10; i++ {
+		if ok, _ := rateLimit.AcquireToken(1); !ok {
+			t.Fatalf("expect token to be acquired before throttle")
+		}
+		rateLimit.Update(false)
+	}
+
+	// Measure a send rate, then throttle.
+	for i := 0; i < 10; i++ {
+		now = now.Add(100 * time.Millisecond)
+		sdk.TestingUseReferenceTime(now)
+		rateLimit.Update(false)
+	}
+	rateLimit.Update(true)
+
+	if !rateLimit.tokenBucketEnabled {
+		t.Fatalf("expect token bucket to be enabled after throttle")
+	}
+
+	var waits int
+	for i := 0; i < 20; i++ {
+		ok, wait := rateLimit.AcquireToken(1)
+		if ok {
+			continue
+		}
+		if wait <= 0 {
+			t.Fatalf("expect positive wait duration, got %v", wait)
+		}
+		waits++
+		break
+	}
+	if waits == 0 {
+		t.Errorf("expect attempts to be rate limited after throttle")
+	}
+
+	// Sending rate recovers after successful attempts.
+	throttledRate := rateLimit.fillRate
+	for i := 0; i < 50; i++ {
+		now = now.Add(100 * time.Millisecond)
+		sdk.TestingUseReferenceTime(now)
+		rateLimit.Update(false)
+	}
+	if e, a := throttledRate, rateLimit.fillRate; a <= e {
+		t.Errorf("expect fill rate to grow beyond %v, got %v", e, a)
+	}
+}
+
+func TestAdaptiveMode_GetAttemptToken(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	r := NewAdaptiveMode(func(o *AdaptiveModeOptions) {
+		o.FailOnNoAttemptTokens = true
+	})
+
+	release, err := r.GetAttemptToken(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := release(newHTTPStatusError(429)); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	// Throttled with no measured send rate empties the bucket, and with time
+	// frozen no tokens are refilled.
+	if _, err := r.GetAttemptToken(context.Background()); err == nil {
+		t.Fatalf("expect error, got none")
+	}
+}
+
+func TestAdaptiveMode_GetAttemptTokenCanceled(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	r := NewAdaptiveMode()
+
+	release, err := r.GetAttemptToken(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	release(newHTTPStatusError(429))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := r.GetAttemptToken(ctx); err == nil {
+		t.Fatalf("expect error, got none")
+	}
+}
+
+func TestAdaptiveMode_Retryer(t *testing.T) {
+	r := NewAdaptiveMode(func(o *AdaptiveModeOptions) {
+		o.StandardOptions = append(o.StandardOptions, func(so *StandardOptions) {
+			so.MaxAttempts = 5
+		})
+	})
+
+	if e, a := 5, r.MaxAttempts(); e != a {
+		t.Errorf("expect %v max attempts, got %v", e, a)
+	}
+	if !r.IsErrorRetryable(newHTTPStatusError(429)) {
+		t.Errorf("expect throttle error to be retryable")
+	}
+	if err := r.GetInitialToken()(nil); err != nil {
+		t.Errorf("expect no error, got %v", err)
+	}
+}
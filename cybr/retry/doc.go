@@ -0,0 +1,63 @@
+// Package retry provides interfaces and implementations for SDK request retry
+// behavior.
+//
+// # Retryer Interface and Implementations
+//
+// This package defines Retryer interface that is used to either implement
+// custom retry behavior or to extend the existing retry implementations
+// provided by the SDK. This package provides two retry implementations:
+// Standard, and AdaptiveMode.
+//
+// # Standard
+//
+// Standard is the default retryer implementation used by service clients. The
+// standard retryer is a rate limited retryer that has a configurable max
+// attempts to limit the number of retry attempts when a retryable error
+// occurs. In addition, the retryer uses a configurable token bucket to rate
+// limit the retry attempts across the client, and uses an additional delay
+// policy to limit the time between requests. A retryable error is an error
+// that is either a connection reset, a timeout, or has an HTTP 429, 500, 502,
+// 503, or 504 status code. Canceled requests, such as those returning a
+// cybr.RequestCanceledError, are never retried.
+//
+// The delay between attempts uses full jitter exponential backoff, where the
+// delay is a random duration between zero and 2^attempt seconds, limited to
+// the configured max backoff.
+//
+// # AdaptiveMode
+//
+// AdaptiveMode builds on the Standard retryer, adding a client side rate limit
+// for attempts. The rate limit is unrestricted until an attempt fails with a
+// throttle error, such as an HTTP 429 response. Once throttled, the send rate
+// is reduced and attempts may need to wait for a token to become available
+// before being sent. As attempts succeed the send rate is increased again,
+// until the rate limit is no longer needed. The rate limit is shared by all
+// operations made with the retryer.
+//
+//	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRetryer(func() cybr.Retryer {
+//		return retry.NewAdaptiveMode()
+//	}))
+//
+// # Attempt Middleware
+//
+// The Attempt middleware drives the Retryer for each operation invocation. It
+// acquires an attempt token before each attempt, rewinds the request body
+// between attempts, and sleeps for the retry delay before retrying. If the
+// response includes a Retry-After header the next attempt will not be made
+// sooner than the service requested, up to the Attempt's MaxRetryAfter
+// (DefaultMaxRetryAfter by default). The result of each attempt, including
+// its status code, error, latency, and clock skew, is recorded in the
+// operation's result metadata, and can be retrieved with GetAttemptResults.
+//
+//	results, ok := retry.GetAttemptResults(output.ResultMetadata)
+//
+// # Retryer Helpers
+//
+// The retry package provides helpers to wrap an existing Retryer, modifying
+// its behavior, such as AddWithMaxAttempts, AddWithMaxBackoffDelay, and
+// AddWithErrorCodes.
+//
+//	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRetryer(func() cybr.Retryer {
+//		return retry.AddWithMaxAttempts(retry.NewStandard(), 5)
+//	}))
+package retry
@@ -0,0 +1,78 @@
+package retry
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestExponentialJitterBackoff_AttemptDelay(t *testing.T) {
+	maxB := 1 - 1/float64(1<<53)
+
+	cases := map[string]struct {
+		MaxBackoff time.Duration
+		RandFloat  func() (float64, error)
+		Attempt    int
+		Expect     time.Duration
+	}{
+		"min delay floor": {
+			MaxBackoff: 20 * time.Second,
+			RandFloat:  func() (float64, error) { return 0, nil },
+			Attempt:    1,
+			Expect:     0,
+		},
+		"min delay ceiling": {
+			MaxBackoff: 20 * time.Second,
+			RandFloat:  func() (float64, error) { return maxB, nil },
+			Attempt:    1,
+			Expect:     float64Seconds(1.9999999999999998),
+		},
+		"attempt delay": {
+			MaxBackoff: 20 * time.Second,
+			RandFloat:  func() (float64, error) { return 0.5, nil },
+			Attempt:    2,
+			Expect:     float64Seconds(2),
+		},
+		"max delay": {
+			MaxBackoff: 20 * time.Second,
+			RandFloat:  func() (float64, error) { return maxB, nil },
+			Attempt:    2000,
+			Expect:     float64Seconds(20),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			j := NewExponentialJitterBackoff(c.MaxBackoff)
+			j.randFloat64 = c.RandFloat
+
+			d, err := j.BackoffDelay(c.Attempt, nil)
+			if err != nil {
+				t.Fatalf("expect no error, %v", err)
+			}
+
+			if e, a := c.Expect, d; e != a {
+				t.Errorf("expect %v delay, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestExponentialJitterBackoff_MaxBackoffAttempts(t *testing.T) {
+	for i := 1; i <= 6; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			maxBackoff := time.Duration(math.Pow(2, float64(i))) * time.Second
+			j := NewExponentialJitterBackoff(maxBackoff)
+			j.randFloat64 = func() (float64, error) { return 1, nil }
+
+			d, err := j.BackoffDelay(i, nil)
+			if err != nil {
+				t.Fatalf("expect no error, %v", err)
+			}
+			if e, a := maxBackoff, d; e != a {
+				t.Errorf("expect %v delay, got %v", e, a)
+			}
+		})
+	}
+}
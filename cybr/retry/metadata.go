@@ -0,0 +1,69 @@
+package retry
+
+import (
+	"time"
+
+	cybrmiddle "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// attemptResultsKey is a metadata accessor key to retrieve metadata
+// for all request attempts.
+type attemptResultsKey struct{}
+
+// GetAttemptResults retrieves attempts results from middleware metadata.
+func GetAttemptResults(metadata middleware.Metadata) (AttemptResults, bool) {
+	m, ok := metadata.Get(attemptResultsKey{}).(AttemptResults)
+	return m, ok
+}
+
+// AttemptResults represents struct containing metadata returned by all request attempts.
+type AttemptResults struct {
+	// Results is a slice consisting attempt result from all request attempts.
+	// Results are stored in order request attempt is made.
+	Results []AttemptResult
+}
+
+// AttemptResult represents attempt result returned by a single request attempt.
+type AttemptResult struct {
+	// Err is the error if received for the request attempt.
+	Err error
+
+	// Retryable denotes if request may be retried. This states if an
+	// error is considered retryable.
+	Retryable bool
+
+	// Retried indicates if this request was retried.
+	Retried bool
+
+	// StatusCode is the HTTP status code of the attempt's response. Zero if
+	// the attempt did not receive a response.
+	StatusCode int
+
+	// Latency is the duration between the attempt being sent, and its
+	// response being received, or the attempt failing.
+	Latency time.Duration
+
+	// ClockSkew is the difference between the service's clock and the
+	// client's clock when the attempt's response was received. Zero if the
+	// response did not include a Date header.
+	ClockSkew time.Duration
+
+	// RetryDelay is the duration waited before the next attempt was made.
+	// Zero if the attempt was not retried.
+	RetryDelay time.Duration
+
+	// ResponseMetadata is any existing metadata passed via the response middlewares.
+	ResponseMetadata middleware.Metadata
+}
+
+// addAttemptResults adds attempt results to middleware metadata
+func addAttemptResults(metadata *middleware.Metadata, v AttemptResults) {
+	metadata.Set(attemptResultsKey{}, v)
+}
+
+// GetRawResponse returns raw response recorded for the attempt result
+func (a AttemptResult) GetRawResponse() interface{} {
+	return cybrmiddle.GetRawResponse(a.ResponseMetadata)
+}
@@ -0,0 +1,363 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrmiddle "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+type mockRetryer struct {
+	cybr.Retryer
+	MaxAttemptsV int
+	Delay        time.Duration
+	ReleaseErr   error
+}
+
+func (m mockRetryer) IsErrorRetryable(err error) bool {
+	var statusErr interface{ HTTPStatusCode() int }
+	return errors.As(err, &statusErr) && statusErr.HTTPStatusCode() >= 500
+}
+func (m mockRetryer) MaxAttempts() int { return m.MaxAttemptsV }
+func (m mockRetryer) RetryDelay(int, error) (time.Duration, error) {
+	return m.Delay, nil
+}
+func (m mockRetryer) GetRetryToken(context.Context, error) (func(error) error, error) {
+	return func(error) error { return m.ReleaseErr }, nil
+}
+func (m mockRetryer) GetInitialToken() func(error) error { return nopRelease }
+
+func newResponseError(code int, header http.Header) error {
+	return &smithyhttp.ResponseError{
+		Response: &smithyhttp.Response{Response: &http.Response{StatusCode: code, Header: header}},
+		Err:      fmt.Errorf("status code %d", code),
+	}
+}
+
+func TestAttemptMiddleware(t *testing.T) {
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	cases := map[string]struct {
+		Retryer        cybr.Retryer
+		Responses      []error
+		ExpectErr      string
+		ExpectStatus   []int
+		ExpectRetried  []bool
+		ExpectSleeps   []time.Duration
+		ExpectMaxError bool
+	}{
+		"success": {
+			Retryer:       mockRetryer{MaxAttemptsV: 3, Delay: time.Second},
+			Responses:     []error{nil},
+			ExpectStatus:  []int{0},
+			ExpectRetried: []bool{false},
+		},
+		"retry then success": {
+			Retryer:       mockRetryer{MaxAttemptsV: 3, Delay: time.Second},
+			Responses:     []error{newResponseError(503, nil), nil},
+			ExpectStatus:  []int{503, 0},
+			ExpectRetried: []bool{true, false},
+			ExpectSleeps:  []time.Duration{time.Second},
+		},
+		"not retryable": {
+			Retryer:       mockRetryer{MaxAttemptsV: 3, Delay: time.Second},
+			Responses:     []error{newResponseError(404, nil)},
+			ExpectErr:     "status code 404",
+			ExpectStatus:  []int{404},
+			ExpectRetried: []bool{false},
+		},
+		"max attempts": {
+			Retryer:        mockRetryer{MaxAttemptsV: 2, Delay: time.Second},
+			Responses:      []error{newResponseError(500, nil), newResponseError(502, nil)},
+			ExpectErr:      "exceeded maximum number of attempts, 2",
+			ExpectStatus:   []int{500, 502},
+			ExpectRetried:  []bool{true, false},
+			ExpectSleeps:   []time.Duration{time.Second},
+			ExpectMaxError: true,
+		},
+		"retry after seconds": {
+			Retryer: mockRetryer{MaxAttemptsV: 3, Delay: time.Second},
+			Responses: []error{
+				newResponseError(503, http.Header{"Retry-After": []string{"5"}}),
+				nil,
+			},
+			ExpectStatus:  []int{503, 0},
+			ExpectRetried: []bool{true, false},
+			ExpectSleeps:  []time.Duration{5 * time.Second},
+		},
+		"retry after date": {
+			Retryer: mockRetryer{MaxAttemptsV: 3, Delay: time.Second},
+			Responses: []error{
+				newResponseError(503, http.Header{"Retry-After": []string{"Tue, 02 Jan 2024 03:00:10 GMT"}}),
+				nil,
+			},
+			ExpectStatus:  []int{503, 0},
+			ExpectRetried: []bool{true, false},
+			ExpectSleeps:  []time.Duration{10 * time.Second},
+		},
+		"retry after shorter than delay": {
+			Retryer: mockRetryer{MaxAttemptsV: 3, Delay: 3 * time.Second},
+			Responses: []error{
+				newResponseError(503, http.Header{"Retry-After": []string{"1"}}),
+				nil,
+			},
+			ExpectStatus:  []int{503, 0},
+			ExpectRetried: []bool{true, false},
+			ExpectSleeps:  []time.Duration{3 * time.Second},
+		},
+		"retry after capped": {
+			Retryer: mockRetryer{MaxAttemptsV: 3, Delay: time.Second},
+			Responses: []error{
+				newResponseError(503, http.Header{"Retry-After": []string{"86400"}}),
+				nil,
+			},
+			ExpectStatus:  []int{503, 0},
+			ExpectRetried: []bool{true, false},
+			ExpectSleeps:  []time.Duration{DefaultMaxRetryAfter},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			origSleep := sdk.SleepWithContext
+			defer func() { sdk.SleepWithContext = origSleep }()
+			var sleeps []time.Duration
+			sdk.SleepWithContext = func(ctx context.Context, dur time.Duration) error {
+				sleeps = append(sleeps, dur)
+				return nil
+			}
+
+			req := smithyhttp.NewStackRequest().(*smithyhttp.Request)
+			req, _ = req.SetStream(bytes.NewReader([]byte("body")))
+
+			var attempts int
+			m := NewAttemptMiddleware(c.Retryer, smithyhttp.RequestCloner)
+			_, metadata, err := m.HandleFinalize(context.Background(), middleware.FinalizeInput{Request: req},
+				middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+					out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+				) {
+					attempts++
+					if e, a := attempts, mustAttemptNumber(t, ctx); e != a {
+						t.Errorf("expect attempt %v, got %v", e, a)
+					}
+
+					// Each attempt's body starts at the beginning of the stream.
+					b, _ := io.ReadAll(in.Request.(*smithyhttp.Request).GetStream())
+					if e, a := "body", string(b); e != a {
+						t.Errorf("expect %v body, got %v", e, a)
+					}
+
+					return out, metadata, c.Responses[attempts-1]
+				}),
+			)
+			if len(c.ExpectErr) != 0 {
+				if err == nil {
+					t.Fatalf("expect error, got none")
+				}
+				if e, a := c.ExpectErr, err.Error(); !strings.Contains(a, e) {
+					t.Errorf("expect error to contain %v, got %v", e, a)
+				}
+			} else if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			var maxErr *MaxAttemptsError
+			if e, a := c.ExpectMaxError, errors.As(err, &maxErr); e != a {
+				t.Errorf("expect max attempts error %v, got %v", e, a)
+			}
+
+			results, ok := GetAttemptResults(metadata)
+			if !ok {
+				t.Fatalf("expect attempt results in metadata")
+			}
+			if e, a := len(c.ExpectStatus), len(results.Results); e != a {
+				t.Fatalf("expect %v attempt results, got %v", e, a)
+			}
+			for i, result := range results.Results {
+				if e, a := c.ExpectStatus[i], result.StatusCode; e != a {
+					t.Errorf("expect attempt %v status %v, got %v", i, e, a)
+				}
+				if e, a := c.ExpectRetried[i], result.Retried; e != a {
+					t.Errorf("expect attempt %v retried %v, got %v", i, e, a)
+				}
+			}
+
+			if e, a := fmt.Sprint(c.ExpectSleeps), fmt.Sprint(sleeps); len(c.ExpectSleeps) != 0 && e != a {
+				t.Errorf("expect %v sleeps, got %v", e, a)
+			}
+			if len(c.ExpectSleeps) == 0 && len(sleeps) != 0 {
+				t.Errorf("expect no sleeps, got %v", sleeps)
+			}
+		})
+	}
+}
+
+func TestAttemptMiddleware_MaxRetryAfter(t *testing.T) {
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+
+	var attempts int
+	m := NewAttemptMiddleware(mockRetryer{MaxAttemptsV: 3, Delay: time.Second}, smithyhttp.RequestCloner,
+		func(m *Attempt) {
+			m.MaxRetryAfter = 5 * time.Second
+		})
+	_, metadata, err := m.HandleFinalize(context.Background(), middleware.FinalizeInput{Request: smithyhttp.NewStackRequest()},
+		middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+			out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+		) {
+			attempts++
+			if attempts == 1 {
+				return out, metadata, newResponseError(503, http.Header{"Retry-After": []string{"60"}})
+			}
+			return out, metadata, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	results, _ := GetAttemptResults(metadata)
+	if e, a := 5*time.Second, results.Results[0].RetryDelay; e != a {
+		t.Errorf("expect %v retry delay, got %v", e, a)
+	}
+}
+
+func TestAttemptMiddleware_ReleaseRetryTokenError(t *testing.T) {
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+
+	releaseErr := fmt.Errorf("retry quota exceeded")
+	m := NewAttemptMiddleware(mockRetryer{MaxAttemptsV: 3, Delay: time.Second, ReleaseErr: releaseErr},
+		smithyhttp.RequestCloner)
+	_, _, err := m.HandleFinalize(context.Background(), middleware.FinalizeInput{Request: smithyhttp.NewStackRequest()},
+		middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+			out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+		) {
+			return out, metadata, newResponseError(503, nil)
+		}),
+	)
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+	if !errors.Is(err, releaseErr) {
+		t.Errorf("expect release error to be wrapped, got %v", err)
+	}
+	var respErr *smithyhttp.ResponseError
+	if !errors.As(err, &respErr) {
+		t.Errorf("expect request error to be wrapped, got %v", err)
+	}
+	if e, a := "failed to release retry token", err.Error(); !strings.Contains(a, e) {
+		t.Errorf("expect error to contain %v, got %v", e, a)
+	}
+}
+
+func TestAttemptMiddleware_SleepCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := NewAttemptMiddleware(mockRetryer{MaxAttemptsV: 3, Delay: time.Hour}, smithyhttp.RequestCloner)
+	_, _, err := m.HandleFinalize(ctx, middleware.FinalizeInput{Request: smithyhttp.NewStackRequest()},
+		middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+			out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+		) {
+			cancel()
+			return out, metadata, newResponseError(503, nil)
+		}),
+	)
+
+	var canceledErr *cybr.RequestCanceledError
+	if !errors.As(err, &canceledErr) {
+		t.Fatalf("expect %T error, got %v", canceledErr, err)
+	}
+}
+
+func TestAttemptMiddleware_ClockSkew(t *testing.T) {
+	restoreSleep := sdk.TestingUseNopSleep()
+	defer restoreSleep()
+	restoreTime := sdk.TestingUseReferenceTime(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC))
+	defer restoreTime()
+
+	m := NewAttemptMiddleware(mockRetryer{MaxAttemptsV: 3}, smithyhttp.RequestCloner)
+
+	var attempts int
+	_, metadata, err := m.HandleFinalize(context.Background(), middleware.FinalizeInput{Request: smithyhttp.NewStackRequest()},
+		middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+			out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+		) {
+			attempts++
+			if attempts == 1 {
+				// Response Date header is ahead of the client's clock.
+				_, metadata, _ = cybrmiddle.RecordResponseTiming{}.HandleDeserialize(ctx, middleware.DeserializeInput{},
+					middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (
+						out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+					) {
+						out.RawResponse = &smithyhttp.Response{Response: &http.Response{
+							StatusCode: 500,
+							Header:     http.Header{"Date": []string{"Tue, 02 Jan 2024 03:00:02 GMT"}},
+						}}
+						return out, metadata, nil
+					}),
+				)
+				return out, metadata, newResponseError(500, nil)
+			}
+			if e, a := 2*time.Second, retryAttemptClockSkew(t, ctx); e != a {
+				t.Errorf("expect %v clock skew, got %v", e, a)
+			}
+			return out, metadata, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	results, _ := GetAttemptResults(metadata)
+	if e, a := 2*time.Second, results.Results[0].ClockSkew; e != a {
+		t.Errorf("expect %v clock skew, got %v", e, a)
+	}
+}
+
+func mustAttemptNumber(t *testing.T, ctx context.Context) int {
+	t.Helper()
+	v, ok := GetAttemptNumber(ctx)
+	if !ok {
+		t.Fatalf("expect attempt number in context")
+	}
+	return v
+}
+
+func retryAttemptClockSkew(t *testing.T, ctx context.Context) time.Duration {
+	t.Helper()
+	v, _ := getRetryMetadata(ctx)
+	return v.AttemptClockSkew
+}
+
+func TestAddRetryMiddlewares(t *testing.T) {
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	if err := stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("Signing", func(
+		ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+	) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		return next.HandleFinalize(ctx, in)
+	}), middleware.After); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if err := AddRetryMiddlewares(stack, AddRetryMiddlewaresOptions{Retryer: NewStandard()}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := []string{"Retry", "Signing"}, stack.Finalize.List(); fmt.Sprint(e) != fmt.Sprint(a) {
+		t.Errorf("expect %v finalize middleware, got %v", e, a)
+	}
+}
@@ -0,0 +1,186 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+type mockTemporaryError struct{ b bool }
+
+func (m mockTemporaryError) Temporary() bool { return m.b }
+func (m mockTemporaryError) Error() string {
+	return fmt.Sprintf("mock temporary %t", m.b)
+}
+
+type mockTimeoutError struct{ b bool }
+
+func (m mockTimeoutError) Timeout() bool { return m.b }
+func (m mockTimeoutError) Error() string {
+	return fmt.Sprintf("mock timeout %t", m.b)
+}
+
+func newHTTPStatusError(code int) error {
+	return &smithyhttp.ResponseError{
+		Response: &smithyhttp.Response{Response: &http.Response{StatusCode: code}},
+		Err:      fmt.Errorf("status code %d", code),
+	}
+}
+
+func TestRetryConnectionErrors(t *testing.T) {
+	cases := map[string]struct {
+		Err       error
+		Retryable cybr.Ternary
+	}{
+		"nil error": {
+			Err:       nil,
+			Retryable: cybr.UnknownTernary,
+		},
+		"no connection error": {
+			Err:       fmt.Errorf("some error"),
+			Retryable: cybr.UnknownTernary,
+		},
+		"temporary error": {
+			Err:       mockTemporaryError{b: true},
+			Retryable: cybr.TrueTernary,
+		},
+		"timeout error": {
+			Err:       mockTimeoutError{b: true},
+			Retryable: cybr.TrueTernary,
+		},
+		"connection reset": {
+			Err:       fmt.Errorf("read: connection reset"),
+			Retryable: cybr.TrueTernary,
+		},
+		"use of closed network connection": {
+			Err:       fmt.Errorf("use of closed network connection"),
+			Retryable: cybr.TrueTernary,
+		},
+		"url error connection refused": {
+			Err: &url.Error{
+				Err: fmt.Errorf("connection refused"),
+			},
+			Retryable: cybr.TrueTernary,
+		},
+		"url error non temporary": {
+			Err: &url.Error{
+				Err: fmt.Errorf("some error"),
+			},
+			Retryable: cybr.UnknownTernary,
+		},
+		"net op dial error": {
+			Err: &net.OpError{
+				Op:  "dial",
+				Err: fmt.Errorf("some error"),
+			},
+			Retryable: cybr.TrueTernary,
+		},
+		"dns not found": {
+			Err: &net.DNSError{
+				IsNotFound: true,
+			},
+			Retryable: cybr.FalseTernary,
+		},
+		"dns temporary": {
+			Err: &net.DNSError{
+				IsTemporary: true,
+			},
+			Retryable: cybr.TrueTernary,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var r RetryableConnectionError
+
+			retryable := r.IsErrorRetryable(c.Err)
+			if e, a := c.Retryable, retryable; e != a {
+				t.Errorf("expect %v retryable, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestRetryHTTPStatusCodes(t *testing.T) {
+	cases := map[string]struct {
+		Err    error
+		Expect cybr.Ternary
+	}{
+		"not http error": {
+			Err:    fmt.Errorf("some error"),
+			Expect: cybr.UnknownTernary,
+		},
+		"not retryable status": {
+			Err:    newHTTPStatusError(404),
+			Expect: cybr.UnknownTernary,
+		},
+	}
+	for _, code := range []int{429, 500, 502, 503, 504} {
+		cases[fmt.Sprintf("status %d", code)] = struct {
+			Err    error
+			Expect cybr.Ternary
+		}{
+			Err:    newHTTPStatusError(code),
+			Expect: cybr.TrueTernary,
+		}
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := RetryableHTTPStatusCode{Codes: DefaultRetryableHTTPStatusCodes}
+
+			if e, a := c.Expect, r.IsErrorRetryable(c.Err); e != a {
+				t.Errorf("expect %v retryable, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestRetryErrorCodes(t *testing.T) {
+	r := RetryableErrorCode{Codes: DefaultThrottleErrorCodes}
+
+	if e, a := cybr.TrueTernary, r.IsErrorRetryable(&smithy.GenericAPIError{Code: "TooManyRequests"}); e != a {
+		t.Errorf("expect %v retryable, got %v", e, a)
+	}
+	if e, a := cybr.UnknownTernary, r.IsErrorRetryable(&smithy.GenericAPIError{Code: "NotFound"}); e != a {
+		t.Errorf("expect %v retryable, got %v", e, a)
+	}
+}
+
+func TestCanceledError(t *testing.T) {
+	cases := map[string]struct {
+		Err    error
+		Expect cybr.Ternary
+	}{
+		"request canceled": {
+			Err:    &cybr.RequestCanceledError{Err: context.Canceled},
+			Expect: cybr.FalseTernary,
+		},
+		"wrapped request canceled": {
+			Err:    fmt.Errorf("some error, %w", &cybr.RequestCanceledError{Err: context.Canceled}),
+			Expect: cybr.FalseTernary,
+		},
+		"not canceled": {
+			Err:    errors.New("some error"),
+			Expect: cybr.UnknownTernary,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var r NoRetryCanceledError
+
+			if e, a := c.Expect, r.IsErrorRetryable(c.Err); e != a {
+				t.Errorf("expect %v retryable, got %v", e, a)
+			}
+		})
+	}
+}
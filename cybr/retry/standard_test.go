@@ -0,0 +1,161 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/ratelimit"
+)
+
+func TestStandard_IsErrorRetryable(t *testing.T) {
+	cases := map[string]struct {
+		Err    error
+		Expect bool
+	}{
+		"no error":          {Err: nil, Expect: false},
+		"unknown error":     {Err: fmt.Errorf("some error"), Expect: false},
+		"retryable status":  {Err: newHTTPStatusError(503), Expect: true},
+		"throttled":         {Err: newHTTPStatusError(429), Expect: true},
+		"not found":         {Err: newHTTPStatusError(404), Expect: false},
+		"connection reset":  {Err: fmt.Errorf("read: connection reset"), Expect: true},
+		"timeout":           {Err: mockTimeoutError{b: true}, Expect: true},
+		"request canceled":  {Err: &cybr.RequestCanceledError{Err: mockTimeoutError{b: true}}, Expect: false},
+		"context canceled":  {Err: &cybr.RequestCanceledError{Err: context.Canceled}, Expect: false},
+		"retryable wrapped": {Err: fmt.Errorf("wrapped, %w", newHTTPStatusError(500)), Expect: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewStandard()
+			if e, a := c.Expect, r.IsErrorRetryable(c.Err); e != a {
+				t.Errorf("expect %v retryable, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestStandard_Options(t *testing.T) {
+	r := NewStandard(func(o *StandardOptions) {
+		o.MaxAttempts = 5
+		o.Backoff = BackoffDelayerFunc(func(attempt int, err error) (time.Duration, error) {
+			return time.Duration(attempt) * time.Second, nil
+		})
+		o.Retryables = append(o.Retryables, IsErrorRetryableFunc(func(err error) cybr.Ternary {
+			if err != nil && err.Error() == "custom" {
+				return cybr.TrueTernary
+			}
+			return cybr.UnknownTernary
+		}))
+	})
+
+	if e, a := 5, r.MaxAttempts(); e != a {
+		t.Errorf("expect %v max attempts, got %v", e, a)
+	}
+	if d, _ := r.RetryDelay(3, nil); d != 3*time.Second {
+		t.Errorf("expect 3s delay, got %v", d)
+	}
+	if !r.IsErrorRetryable(errors.New("custom")) {
+		t.Errorf("expect custom error to be retryable")
+	}
+}
+
+func TestStandard_RetryQuota(t *testing.T) {
+	r := NewStandard(func(o *StandardOptions) {
+		o.RateLimiter = ratelimit.NewTokenRateLimit(15)
+	})
+
+	ctx := context.Background()
+
+	// A timeout retry costs 10 tokens, and a non timeout retry 5.
+	if _, err := r.GetRetryToken(ctx, mockTimeoutError{b: true}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	release, err := r.GetRetryToken(ctx, fmt.Errorf("some error"))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	_, err = r.GetRetryToken(ctx, fmt.Errorf("some error"))
+	var quotaErr ratelimit.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("expect %T error, got %v", quotaErr, err)
+	}
+
+	// Releasing the token of a failed attempt does not refund it.
+	release(fmt.Errorf("attempt failed"))
+	if _, err := r.GetRetryToken(ctx, fmt.Errorf("some error")); err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	// Releasing the token of a successful attempt refunds the retry cost.
+	release(nil)
+	if _, err := r.GetRetryToken(ctx, fmt.Errorf("some error")); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+}
+
+func TestStandard_NoRetryIncrement(t *testing.T) {
+	limiter := ratelimit.NewTokenRateLimit(10)
+	r := NewStandard(func(o *StandardOptions) {
+		o.RateLimiter = limiter
+	})
+
+	if _, err := r.GetRetryToken(context.Background(), fmt.Errorf("some error")); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	release, err := r.GetAttemptToken(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	release(nil)
+
+	if e, a := uint(6), limiter.Remaining(); e != a {
+		t.Errorf("expect %v remaining tokens, got %v", e, a)
+	}
+}
+
+func TestAddWithMaxAttempts(t *testing.T) {
+	r := AddWithMaxAttempts(NewStandard(), 10)
+	if e, a := 10, r.MaxAttempts(); e != a {
+		t.Errorf("expect %v max attempts, got %v", e, a)
+	}
+
+	if _, ok := r.(cybr.RetryerV2); !ok {
+		t.Errorf("expect wrapped retryer to be a RetryerV2")
+	}
+}
+
+func TestAddWithErrorCodes(t *testing.T) {
+	r := AddWithErrorCodes(cybr.NopRetryer{}, "SessionExpired")
+
+	if !r.IsErrorRetryable(&mockCodeError{code: "SessionExpired"}) {
+		t.Errorf("expect error code to be retryable")
+	}
+	if r.IsErrorRetryable(&mockCodeError{code: "Other"}) {
+		t.Errorf("expect other error code to not be retryable")
+	}
+}
+
+func TestAddWithMaxBackoffDelay(t *testing.T) {
+	r := AddWithMaxBackoffDelay(NewStandard(), 2*time.Second)
+
+	d, err := r.RetryDelay(100, nil)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := 2*time.Second, d; e != a {
+		t.Errorf("expect %v delay, got %v", e, a)
+	}
+}
+
+type mockCodeError struct {
+	code string
+}
+
+func (e *mockCodeError) Error() string     { return e.code }
+func (e *mockCodeError) ErrorCode() string { return e.code }
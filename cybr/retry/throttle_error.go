@@ -0,0 +1,84 @@
+package retry
+
+import (
+	"errors"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+)
+
+// IsErrorThrottle provides the interface of an implementation to determine if
+// a error response from an operation is a throttling error.
+type IsErrorThrottle interface {
+	IsErrorThrottle(error) cybr.Ternary
+}
+
+// IsErrorThrottles is a collection of checks to determine of the error a
+// throttle error. Iterates through the checks and returns the state of
+// throttle if any check returns something other than unknown.
+type IsErrorThrottles []IsErrorThrottle
+
+// IsErrorThrottle returns if the error is a throttle error if any of the
+// checks in the list return a value other than unknown.
+func (r IsErrorThrottles) IsErrorThrottle(err error) cybr.Ternary {
+	for _, re := range r {
+		if v := re.IsErrorThrottle(err); v != cybr.UnknownTernary {
+			return v
+		}
+	}
+	return cybr.UnknownTernary
+}
+
+// IsErrorThrottleFunc wraps a function with the IsErrorThrottle interface.
+type IsErrorThrottleFunc func(error) cybr.Ternary
+
+// IsErrorThrottle returns if the error is a throttle error.
+func (fn IsErrorThrottleFunc) IsErrorThrottle(err error) cybr.Ternary {
+	return fn(err)
+}
+
+// ThrottleErrorCode determines if an attempt should be retried based on the
+// API error code.
+type ThrottleErrorCode struct {
+	Codes map[string]struct{}
+}
+
+// IsErrorThrottle return if the error is a throttle error based on the error
+// codes. Returns unknown if the error doesn't have a code or it is unknown.
+func (r ThrottleErrorCode) IsErrorThrottle(err error) cybr.Ternary {
+	var v interface{ ErrorCode() string }
+
+	if !errors.As(err, &v) {
+		return cybr.UnknownTernary
+	}
+
+	_, ok := r.Codes[v.ErrorCode()]
+	if !ok {
+		return cybr.UnknownTernary
+	}
+
+	return cybr.TrueTernary
+}
+
+// ThrottleHTTPStatusCode determines if an attempt was throttled based on the
+// HTTP status code of the response.
+type ThrottleHTTPStatusCode struct {
+	Codes map[int]struct{}
+}
+
+// IsErrorThrottle return if the error is a throttle error based on the HTTP
+// status code. Returns unknown if the error doesn't have a status code or it
+// is unknown.
+func (r ThrottleHTTPStatusCode) IsErrorThrottle(err error) cybr.Ternary {
+	var v interface{ HTTPStatusCode() int }
+
+	if !errors.As(err, &v) {
+		return cybr.UnknownTernary
+	}
+
+	_, ok := r.Codes[v.HTTPStatusCode()]
+	if !ok {
+		return cybr.UnknownTernary
+	}
+
+	return cybr.TrueTernary
+}
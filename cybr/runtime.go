@@ -0,0 +1,27 @@
+package cybr
+
+// ExecutionEnvironmentID is the identifier of the environment the SDK is
+// executing in.
+type ExecutionEnvironmentID string
+
+// The ExecutionEnvironmentID constants.
+const (
+	// ExecutionEnvironmentCI identifies the SDK is executing in a continuous
+	// integration system.
+	ExecutionEnvironmentCI ExecutionEnvironmentID = "ci"
+)
+
+// RuntimeEnvironment is a collection of values that are determined at runtime
+// based on the environment that the SDK is executing in. Some of these values
+// may or may not be present based on the executing environment and certain SDK
+// configuration properties that drive whether these values are populated..
+type RuntimeEnvironment struct {
+	// The identifier of the environment the SDK is executing in, if known.
+	EnvironmentIdentifier ExecutionEnvironmentID
+
+	// The region the SDK is executing in, if known.
+	Region string
+
+	// The region of the tenant, as resolved by the platform discovery API.
+	TenantRegion string
+}
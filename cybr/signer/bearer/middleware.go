@@ -0,0 +1,179 @@
+// Package bearer provides the middleware for signing API requests with the
+// bearer token retrieved from a cybr.CredentialsProvider.
+package bearer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrmiddleware "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+)
+
+const (
+	signingMiddlewareID = "Signing"
+	authorizationHeader = "Authorization"
+)
+
+// SigningError indicates an error condition occurred while signing the
+// request with the bearer token.
+type SigningError struct {
+	Err error
+}
+
+func (e *SigningError) Error() string {
+	return fmt.Sprintf("failed to sign request: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *SigningError) Unwrap() error {
+	return e.Err
+}
+
+// SignHTTPRequestMiddlewareOptions is the configuration options for the
+// SignHTTPRequestMiddleware.
+type SignHTTPRequestMiddlewareOptions struct {
+	CredentialsProvider cybr.CredentialsProvider
+
+	// LogSigning enables logging of the source of the credentials used to
+	// sign requests, and of credentials refreshed for unauthorized requests.
+	// The bearer token is never logged.
+	LogSigning bool
+}
+
+// SignHTTPRequestMiddleware is a FinalizeMiddleware implementation that sets
+// the Authorization header of the HTTP request to the bearer token retrieved
+// from the credentials provider.
+//
+// If the request is rejected as unauthorized and the credentials provider is
+// a *cybr.CredentialsCache, the cached credentials are invalidated and the
+// request is retried once with freshly retrieved credentials.
+type SignHTTPRequestMiddleware struct {
+	credentialsProvider cybr.CredentialsProvider
+	logSigning          bool
+}
+
+// NewSignHTTPRequestMiddleware constructs a SignHTTPRequestMiddleware using
+// the given options.
+func NewSignHTTPRequestMiddleware(options SignHTTPRequestMiddlewareOptions) *SignHTTPRequestMiddleware {
+	return &SignHTTPRequestMiddleware{
+		credentialsProvider: options.CredentialsProvider,
+		logSigning:          options.LogSigning,
+	}
+}
+
+// ID is the SignHTTPRequestMiddleware identifier.
+func (s *SignHTTPRequestMiddleware) ID() string {
+	return signingMiddlewareID
+}
+
+// HandleFinalize will take the provided input and sign the request
+// accordingly.
+func (s *SignHTTPRequestMiddleware) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+	out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+) {
+	if !haveCredentialProvider(s.credentialsProvider) {
+		return next.HandleFinalize(ctx, in)
+	}
+
+	req, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return out, metadata, &SigningError{Err: fmt.Errorf("unexpected request middleware type %T", in.Request)}
+	}
+
+	// Keep an unsigned copy of the request, in case it must be retried with
+	// refreshed credentials.
+	unsigned := req.Clone()
+
+	signedCtx, err := s.sign(ctx, req)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	out, metadata, err = next.HandleFinalize(signedCtx, in)
+	if !isUnauthorized(metadata, err) {
+		return out, metadata, err
+	}
+
+	cache, ok := s.credentialsProvider.(*cybr.CredentialsCache)
+	if !ok {
+		return out, metadata, err
+	}
+	cache.Invalidate()
+	s.logf(ctx, "request unauthorized, signing request with refreshed credentials")
+
+	if rewindErr := unsigned.RewindStream(); rewindErr != nil {
+		// The request body can not be replayed, return the unauthorized
+		// response as is.
+		return out, metadata, err
+	}
+
+	signedCtx, signErr := s.sign(ctx, unsigned)
+	if signErr != nil {
+		return out, metadata, signErr
+	}
+
+	in.Request = unsigned
+	return next.HandleFinalize(signedCtx, in)
+}
+
+// sign retrieves credentials from the provider, sets the request's
+// Authorization header, and stores the credentials used in the stack values.
+func (s *SignHTTPRequestMiddleware) sign(ctx context.Context, req *smithyhttp.Request) (context.Context, error) {
+	credentials, err := s.credentialsProvider.Retrieve(ctx)
+	if err != nil {
+		return ctx, &SigningError{Err: fmt.Errorf("failed to retrieve credentials: %w", err)}
+	}
+	if len(credentials.BearerToken) == 0 {
+		return ctx, &SigningError{Err: fmt.Errorf("retrieved credentials do not contain a bearer token")}
+	}
+
+	req.Header.Set(authorizationHeader, "Bearer "+credentials.BearerToken)
+	s.logf(ctx, "signed request with bearer token from credentials source %q", credentials.Source)
+
+	return cybrmiddleware.SetSigningCredentials(ctx, credentials), nil
+}
+
+func (s *SignHTTPRequestMiddleware) logf(ctx context.Context, format string, v ...interface{}) {
+	if !s.logSigning {
+		return
+	}
+	middleware.GetLogger(ctx).Logf(logging.Debug, format, v...)
+}
+
+// isUnauthorized returns if the response to the request was an HTTP 401
+// Unauthorized, either from the operation error, or the raw response.
+func isUnauthorized(metadata middleware.Metadata, err error) bool {
+	var statusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &statusErr) {
+		return statusErr.HTTPStatusCode() == http.StatusUnauthorized
+	}
+
+	if resp, ok := cybrmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok {
+		return resp.StatusCode == http.StatusUnauthorized
+	}
+
+	return false
+}
+
+// haveCredentialProvider returns if the provider is set, and is not the
+// cybr.AnonymousCredentials sentinel instructing the SDK to not sign
+// requests.
+func haveCredentialProvider(p cybr.CredentialsProvider) bool {
+	if p == nil {
+		return false
+	}
+
+	return !cybr.IsCredentialsProvider(p, cybr.AnonymousCredentials{})
+}
+
+// AddSignHTTPRequestMiddleware adds the SignHTTPRequestMiddleware to the end
+// of the middleware stack's Finalize step, so each request attempt is signed.
+func AddSignHTTPRequestMiddleware(stack *middleware.Stack, options SignHTTPRequestMiddlewareOptions) error {
+	return stack.Finalize.Add(NewSignHTTPRequestMiddleware(options), middleware.After)
+}
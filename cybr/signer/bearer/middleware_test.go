@@ -0,0 +1,237 @@
+package bearer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrmiddleware "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+)
+
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) Retrieve(ctx context.Context) (cybr.Credentials, error) {
+	p.calls++
+	return cybr.Credentials{
+		BearerToken: fmt.Sprintf("token-%d", p.calls),
+		Source:      "counting",
+	}, nil
+}
+
+func newTestRequest(t *testing.T, body string) *smithyhttp.Request {
+	t.Helper()
+
+	req := smithyhttp.NewStackRequest().(*smithyhttp.Request)
+	r, err := req.SetStream(bytes.NewReader([]byte(body)))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	return r
+}
+
+func TestSignHTTPRequestMiddleware(t *testing.T) {
+	provider := &countingProvider{}
+	m := NewSignHTTPRequestMiddleware(SignHTTPRequestMiddlewareOptions{
+		CredentialsProvider: cybr.NewCredentialsCache(provider),
+	})
+
+	_, _, err := m.HandleFinalize(context.Background(), middleware.FinalizeInput{Request: newTestRequest(t, "")},
+		middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+			out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+		) {
+			req := in.Request.(*smithyhttp.Request)
+			if e, a := "Bearer token-1", req.Header.Get("Authorization"); e != a {
+				t.Errorf("expect %v authorization, got %v", e, a)
+			}
+			if e, a := "token-1", cybrmiddleware.GetSigningCredentials(ctx).BearerToken; e != a {
+				t.Errorf("expect %v signing credentials, got %v", e, a)
+			}
+			return out, metadata, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+}
+
+func TestSignHTTPRequestMiddleware_LogSigning(t *testing.T) {
+	for _, logSigning := range []bool{true, false} {
+		t.Run(fmt.Sprintf("log signing %v", logSigning), func(t *testing.T) {
+			var logged bytes.Buffer
+			ctx := middleware.SetLogger(context.Background(), logging.NewStandardLogger(&logged))
+
+			m := NewSignHTTPRequestMiddleware(SignHTTPRequestMiddlewareOptions{
+				CredentialsProvider: &countingProvider{},
+				LogSigning:          logSigning,
+			})
+
+			_, _, err := m.HandleFinalize(ctx, middleware.FinalizeInput{Request: newTestRequest(t, "")},
+				middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+					out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+				) {
+					return out, metadata, nil
+				}),
+			)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if a := logged.String(); strings.Contains(a, "token-1") {
+				t.Errorf("expect bearer token to not be logged, got %v", a)
+			}
+			if e, a := logSigning, strings.Contains(logged.String(), `"counting"`); e != a {
+				t.Errorf("expect credentials source logged %v, got %v", e, logged.String())
+			}
+		})
+	}
+}
+
+func TestSignHTTPRequestMiddleware_SkipSigning(t *testing.T) {
+	cases := map[string]cybr.CredentialsProvider{
+		"nil":             nil,
+		"anonymous":       cybr.AnonymousCredentials{},
+		"anonymous ptr":   &cybr.AnonymousCredentials{},
+		"cache anonymous": cybr.NewCredentialsCache(cybr.AnonymousCredentials{}),
+	}
+
+	for name, provider := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := NewSignHTTPRequestMiddleware(SignHTTPRequestMiddlewareOptions{
+				CredentialsProvider: provider,
+			})
+
+			_, _, err := m.HandleFinalize(context.Background(), middleware.FinalizeInput{Request: newTestRequest(t, "")},
+				middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+					out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+				) {
+					req := in.Request.(*smithyhttp.Request)
+					if v := req.Header.Get("Authorization"); len(v) != 0 {
+						t.Errorf("expect no authorization, got %v", v)
+					}
+					return out, metadata, nil
+				}),
+			)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSignHTTPRequestMiddleware_RetrieveError(t *testing.T) {
+	m := NewSignHTTPRequestMiddleware(SignHTTPRequestMiddlewareOptions{
+		CredentialsProvider: cybr.CredentialsProviderFunc(func(ctx context.Context) (cybr.Credentials, error) {
+			return cybr.Credentials{}, fmt.Errorf("retrieve failed")
+		}),
+	})
+
+	_, _, err := m.HandleFinalize(context.Background(), middleware.FinalizeInput{Request: newTestRequest(t, "")},
+		middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+			out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+		) {
+			t.Fatalf("expect request to not be sent")
+			return out, metadata, nil
+		}),
+	)
+
+	var signErr *SigningError
+	if !errors.As(err, &signErr) {
+		t.Fatalf("expect %T error, got %v", signErr, err)
+	}
+}
+
+func TestSignHTTPRequestMiddleware_Unauthorized(t *testing.T) {
+	unauthorizedErr := &smithyhttp.ResponseError{
+		Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusUnauthorized}},
+		Err:      fmt.Errorf("unauthorized"),
+	}
+
+	cases := map[string]struct {
+		Cache          bool
+		Responses      []error
+		ExpectErr      bool
+		ExpectAttempts int
+		ExpectTokens   []string
+	}{
+		"refreshed credentials": {
+			Cache:          true,
+			Responses:      []error{unauthorizedErr, nil},
+			ExpectAttempts: 2,
+			ExpectTokens:   []string{"Bearer token-1", "Bearer token-2"},
+		},
+		"retried once": {
+			Cache:          true,
+			Responses:      []error{unauthorizedErr, unauthorizedErr},
+			ExpectErr:      true,
+			ExpectAttempts: 2,
+			ExpectTokens:   []string{"Bearer token-1", "Bearer token-2"},
+		},
+		"not cached": {
+			Responses:      []error{unauthorizedErr},
+			ExpectErr:      true,
+			ExpectAttempts: 1,
+			ExpectTokens:   []string{"Bearer token-1"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var provider cybr.CredentialsProvider = &countingProvider{}
+			if c.Cache {
+				provider = cybr.NewCredentialsCache(provider)
+			}
+			m := NewSignHTTPRequestMiddleware(SignHTTPRequestMiddlewareOptions{
+				CredentialsProvider: provider,
+			})
+
+			var tokens []string
+			_, _, err := m.HandleFinalize(context.Background(), middleware.FinalizeInput{Request: newTestRequest(t, "body")},
+				middleware.FinalizeHandlerFunc(func(ctx context.Context, in middleware.FinalizeInput) (
+					out middleware.FinalizeOutput, metadata middleware.Metadata, err error,
+				) {
+					req := in.Request.(*smithyhttp.Request)
+					tokens = append(tokens, req.Header.Get("Authorization"))
+
+					b, _ := io.ReadAll(req.GetStream())
+					if e, a := "body", string(b); e != a {
+						t.Errorf("expect %v body, got %v", e, a)
+					}
+
+					return out, metadata, c.Responses[len(tokens)-1]
+				}),
+			)
+			if (err != nil) != c.ExpectErr {
+				t.Fatalf("expect error %v, got %v", c.ExpectErr, err)
+			}
+
+			if e, a := c.ExpectAttempts, len(tokens); e != a {
+				t.Fatalf("expect %v attempts, got %v", e, a)
+			}
+			if e, a := strings.Join(c.ExpectTokens, ","), strings.Join(tokens, ","); e != a {
+				t.Errorf("expect %v tokens, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestAddSignHTTPRequestMiddleware(t *testing.T) {
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	if err := AddSignHTTPRequestMiddleware(stack, SignHTTPRequestMiddlewareOptions{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if _, ok := stack.Finalize.Get(signingMiddlewareID); !ok {
+		t.Errorf("expect %v middleware in finalize step", signingMiddlewareID)
+	}
+}
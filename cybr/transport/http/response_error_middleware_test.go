@@ -0,0 +1,91 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrmiddleware "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+)
+
+func TestResponseErrorWrapper(t *testing.T) {
+	apiErr := &cybr.APIError{Code: "PASWS013E", Message: "Account not found"}
+
+	cases := map[string]struct {
+		RawResponse   interface{}
+		Err           error
+		ExpectWrapped bool
+	}{
+		"no error": {
+			RawResponse: &smithyhttp.Response{Response: &http.Response{StatusCode: 200}},
+		},
+		"no response": {
+			Err: apiErr,
+		},
+		"error response": {
+			RawResponse:   &smithyhttp.Response{Response: &http.Response{StatusCode: 404}},
+			Err:           apiErr,
+			ExpectWrapped: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &ResponseErrorWrapper{}
+			_, _, err := m.HandleDeserialize(context.Background(), middleware.DeserializeInput{},
+				middleware.DeserializeHandlerFunc(func(ctx context.Context, in middleware.DeserializeInput) (
+					out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+				) {
+					out.RawResponse = c.RawResponse
+					cybrmiddleware.SetRequestIDMetadata(&metadata, "request-id")
+					return out, metadata, c.Err
+				}),
+			)
+			if (err != nil) != (c.Err != nil) {
+				t.Fatalf("expect error %v, got %v", c.Err, err)
+			}
+
+			var respErr *cybr.ResponseError
+			if e, a := c.ExpectWrapped, errors.As(err, &respErr); e != a {
+				t.Fatalf("expect wrapped %v, got %v", e, a)
+			}
+			if !c.ExpectWrapped {
+				return
+			}
+
+			if e, a := "request-id", respErr.RequestID; e != a {
+				t.Errorf("expect %v request ID, got %v", e, a)
+			}
+			if !cybr.IsNotFound(err) {
+				t.Errorf("expect not found error, got %v", err)
+			}
+
+			var actualAPIErr *cybr.APIError
+			if !errors.As(err, &actualAPIErr) || actualAPIErr != apiErr {
+				t.Errorf("expect %v API error, got %v", apiErr, actualAPIErr)
+			}
+		})
+	}
+}
+
+func TestAddResponseErrorMiddleware(t *testing.T) {
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("RequestIDRetriever",
+		func(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+			middleware.DeserializeOutput, middleware.Metadata, error,
+		) {
+			return next.HandleDeserialize(ctx, in)
+		}), middleware.After)
+
+	if err := AddResponseErrorMiddleware(stack); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if e, a := []string{"ResponseErrorWrapper", "RequestIDRetriever"}, stack.Deserialize.List(); len(a) != 2 || e[0] != a[0] || e[1] != a[1] {
+		t.Errorf("expect %v middleware, got %v", e, a)
+	}
+}
@@ -0,0 +1,253 @@
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CABundleOptions provides the options for adding a CA bundle to the
+// BuildableClient's trusted root certificates.
+type CABundleOptions struct {
+	// If true, the CA bundle replaces the system's root certificates instead
+	// of being appended to them. Only servers with certificates issued by the
+	// CA bundle will be trusted.
+	ReplaceSystemRoots bool
+}
+
+// WithCABundle copies the BuildableClient and returns it with the PEM encoded
+// CA certificates added to the client's trusted root certificates. By default
+// the certificates are appended to the system's root certificates.
+//
+// Returns an error if no certificates could be parsed from the PEM data.
+func (b *BuildableClient) WithCABundle(pemCerts []byte, optFns ...func(*CABundleOptions)) (*BuildableClient, error) {
+	var options CABundleOptions
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	cpy := b.clone()
+	tr := cpy.GetTransport()
+	tlsConfig := ensureTLSConfig(tr)
+
+	var pool *x509.CertPool
+	switch {
+	case options.ReplaceSystemRoots:
+		pool = x509.NewCertPool()
+	case tlsConfig.RootCAs != nil:
+		pool = tlsConfig.RootCAs.Clone()
+	default:
+		var err error
+		if pool, err = x509.SystemCertPool(); err != nil {
+			pool = x509.NewCertPool()
+		}
+	}
+
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return nil, fmt.Errorf("failed to load CA bundle, no PEM encoded certificates found")
+	}
+	tlsConfig.RootCAs = pool
+	cpy.transport = tr
+
+	return cpy, nil
+}
+
+// WithCABundleFile copies the BuildableClient and returns it with the PEM
+// encoded CA certificates read from the file added to the client's trusted
+// root certificates. See WithCABundle for more information.
+func (b *BuildableClient) WithCABundleFile(filename string, optFns ...func(*CABundleOptions)) (*BuildableClient, error) {
+	pemCerts, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle file, %w", err)
+	}
+
+	return b.WithCABundle(pemCerts, optFns...)
+}
+
+// WithClientCertificate copies the BuildableClient and returns it with the
+// client certificate that will be presented to servers requesting mutual TLS
+// authentication.
+func (b *BuildableClient) WithClientCertificate(cert tls.Certificate) *BuildableClient {
+	return b.WithTransportOptions(func(tr *http.Transport) {
+		tlsConfig := ensureTLSConfig(tr)
+		tlsConfig.Certificates = []tls.Certificate{cert}
+		tlsConfig.GetClientCertificate = nil
+	})
+}
+
+// WithClientCertificateFiles copies the BuildableClient and returns it with
+// the PEM encoded client certificate and private key pair read from the files
+// presented to servers requesting mutual TLS authentication.
+//
+// The files are checked for modifications when a new connection is made, and
+// the certificate is reloaded if either file has changed. This allows
+// certificates to be rotated without recreating the client. If a modified
+// pair can not be loaded, the previously loaded certificate continues to be
+// used.
+//
+// Returns an error if the certificate and key can not be loaded initially.
+func (b *BuildableClient) WithClientCertificateFiles(certFile, keyFile string) (*BuildableClient, error) {
+	reloader, err := newCertificateReloader(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.WithTransportOptions(func(tr *http.Transport) {
+		tlsConfig := ensureTLSConfig(tr)
+		tlsConfig.Certificates = nil
+		tlsConfig.GetClientCertificate = reloader.GetClientCertificate
+	}), nil
+}
+
+// WithPinnedPublicKeys copies the BuildableClient and returns it with TLS
+// public key pinning enabled. Each pin is the base64 encoded SHA-256 digest of
+// a certificate's DER encoded SubjectPublicKeyInfo, optionally prefixed with
+// "sha256/".
+//
+// In addition to the standard certificate verification, connections are only
+// allowed if a certificate in the server's verified chains matches one of the
+// pins. Certificates the server presents that are not part of a verified chain
+// are not matched.
+//
+// If the client's TLS config has InsecureSkipVerify set when the pins are
+// added, no chains are verified, and only the server's leaf certificate is
+// matched. Otherwise connections without a verified chain are rejected.
+func (b *BuildableClient) WithPinnedPublicKeys(pins ...string) (*BuildableClient, error) {
+	if len(pins) == 0 {
+		return nil, fmt.Errorf("at least one public key pin is required")
+	}
+
+	digests := make([][]byte, 0, len(pins))
+	for _, pin := range pins {
+		digest, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(pin, "sha256/"))
+		if err != nil || len(digest) != sha256.Size {
+			return nil, fmt.Errorf("invalid public key pin %q, expect base64 encoded SHA-256 digest", pin)
+		}
+		digests = append(digests, digest)
+	}
+
+	return b.WithTransportOptions(func(tr *http.Transport) {
+		tlsConfig := ensureTLSConfig(tr)
+		insecureSkipVerify := tlsConfig.InsecureSkipVerify
+		tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyPinnedPublicKeys(cs, digests, insecureSkipVerify)
+		}
+	}), nil
+}
+
+// verifyPinnedPublicKeys returns an error if no certificate in the
+// connection's verified chains matches one of the pinned public key digests.
+// If there are no verified chains the connection is rejected, unless
+// insecureSkipVerify is set, in which case only the leaf certificate is
+// matched.
+func verifyPinnedPublicKeys(cs tls.ConnectionState, digests [][]byte, insecureSkipVerify bool) error {
+	var certs []*x509.Certificate
+	for _, chain := range cs.VerifiedChains {
+		certs = append(certs, chain...)
+	}
+	if len(certs) == 0 {
+		if !insecureSkipVerify || len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("server certificate chain was not verified, unable to check pinned public keys")
+		}
+		certs = cs.PeerCertificates[:1]
+	}
+
+	for _, cert := range certs {
+		digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+		for _, pin := range digests {
+			if subtle.ConstantTimeCompare(digest[:], pin) == 1 {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("server certificate chain does not match any pinned public key")
+}
+
+func ensureTLSConfig(tr *http.Transport) *tls.Config {
+	if tr.TLSClientConfig == nil {
+		tr.TLSClientConfig = &tls.Config{
+			MinVersion: DefaultHTTPTransportTLSMinVersion,
+		}
+	}
+	return tr.TLSClientConfig
+}
+
+// certificateReloader loads a client certificate from files, reloading it
+// when the files are modified.
+type certificateReloader struct {
+	certFile, keyFile string
+
+	mu          sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+func newCertificateReloader(certFile, keyFile string) (*certificateReloader, error) {
+	r := &certificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetClientCertificate returns the loaded client certificate, reloading it
+// first if the certificate or key file has been modified.
+func (r *certificateReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.modified() {
+		// Keep using the current certificate if the files are mid rotation,
+		// and can not be loaded yet.
+		_ = r.reload()
+	}
+
+	return r.cert, nil
+}
+
+func (r *certificateReloader) modified() bool {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return false
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return false
+	}
+
+	return !certInfo.ModTime().Equal(r.certModTime) || !keyInfo.ModTime().Equal(r.keyModTime)
+}
+
+func (r *certificateReloader) reload() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fmt.Errorf("failed to read client certificate file, %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read client key file, %w", err)
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load client certificate, %w", err)
+	}
+
+	r.cert = &cert
+	r.certModTime = certInfo.ModTime()
+	r.keyModTime = keyInfo.ModTime()
+	return nil
+}
@@ -0,0 +1,319 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func serverCertPEM(server *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
+
+func serverPin(server *httptest.Server) string {
+	digest := sha256.Sum256(server.Certificate().RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(digest[:])
+}
+
+func doGet(t *testing.T, client *BuildableClient, url string) (*http.Response, error) {
+	t.Helper()
+	req, _ := http.NewRequest("GET", url, nil)
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
+
+func TestBuildableClient_WithCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	if _, err := doGet(t, NewBuildableClient(), server.URL); err == nil {
+		t.Fatalf("expect untrusted server certificate error, got none")
+	}
+
+	for name, optFn := range map[string]func(*CABundleOptions){
+		"append":  func(o *CABundleOptions) {},
+		"replace": func(o *CABundleOptions) { o.ReplaceSystemRoots = true },
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewBuildableClient().WithCABundle(serverCertPEM(server), optFn)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if _, err := doGet(t, client, server.URL); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildableClient_WithCABundleFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	filename := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(filename, serverCertPEM(server), 0600); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	client, err := NewBuildableClient().WithCABundleFile(filename)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if _, err := doGet(t, client, server.URL); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if _, err := NewBuildableClient().WithCABundleFile(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Errorf("expect missing file error, got none")
+	}
+	if _, err := NewBuildableClient().WithCABundle([]byte("not a certificate")); err == nil {
+		t.Errorf("expect invalid PEM error, got none")
+	}
+}
+
+func TestBuildableClient_WithPinnedPublicKeys(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	trusted, err := NewBuildableClient().WithCABundle(serverCertPEM(server))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	otherDigest := sha256.Sum256([]byte("other key"))
+	otherPin := base64.StdEncoding.EncodeToString(otherDigest[:])
+
+	cases := map[string]struct {
+		Pins      []string
+		ExpectErr bool
+	}{
+		"matching pin":        {Pins: []string{otherPin, serverPin(server)}},
+		"matching prefix pin": {Pins: []string{"sha256/" + serverPin(server)}},
+		"no matching pin":     {Pins: []string{otherPin}, ExpectErr: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, err := trusted.WithPinnedPublicKeys(c.Pins...)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			_, err = doGet(t, client, server.URL)
+			if (err != nil) != c.ExpectErr {
+				t.Fatalf("expect error %v, got %v", c.ExpectErr, err)
+			}
+		})
+	}
+
+	for _, pins := range [][]string{nil, {"invalid"}, {base64.StdEncoding.EncodeToString([]byte("short"))}} {
+		if _, err := trusted.WithPinnedPublicKeys(pins...); err == nil {
+			t.Errorf("expect invalid pin %v error, got none", pins)
+		}
+	}
+}
+
+func TestBuildableClient_WithPinnedPublicKeysUnverifiedCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// The pinned certificate is appended to the server's otherwise valid
+	// chain, but is not part of the verified chain.
+	pinnedDER := newSelfSignedCertificate(t, "pinned")
+	pinnedCert, err := x509.ParseCertificate(pinnedDER)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	pinnedDigest := sha256.Sum256(pinnedCert.RawSubjectPublicKeyInfo)
+	pinnedPin := base64.StdEncoding.EncodeToString(pinnedDigest[:])
+
+	cert := server.TLS.Certificates[0]
+	cert.Certificate = append(append([][]byte{}, cert.Certificate...), pinnedDER)
+
+	appended := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	appended.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	appended.StartTLS()
+	defer appended.Close()
+
+	trusted, err := NewBuildableClient().WithCABundle(serverCertPEM(server))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	insecure := NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+		ensureTLSConfig(tr).InsecureSkipVerify = true
+	})
+
+	cases := map[string]struct {
+		Client    *BuildableClient
+		Pin       string
+		OptFns    []func(*http.Transport)
+		ExpectErr bool
+	}{
+		"appended pinned certificate": {
+			Client:    trusted,
+			Pin:       pinnedPin,
+			ExpectErr: true,
+		},
+		"verified leaf": {
+			Client: trusted,
+			Pin:    serverPin(server),
+		},
+		"insecure appended pinned certificate": {
+			Client:    insecure,
+			Pin:       pinnedPin,
+			ExpectErr: true,
+		},
+		"insecure leaf": {
+			Client: insecure,
+			Pin:    serverPin(server),
+		},
+		"insecure after pinning": {
+			Client: NewBuildableClient(),
+			Pin:    serverPin(server),
+			OptFns: []func(*http.Transport){func(tr *http.Transport) {
+				ensureTLSConfig(tr).InsecureSkipVerify = true
+			}},
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, err := c.Client.WithPinnedPublicKeys(c.Pin)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			client = client.WithTransportOptions(c.OptFns...)
+
+			_, err = doGet(t, client, appended.URL)
+			if (err != nil) != c.ExpectErr {
+				t.Fatalf("expect error %v, got %v", c.ExpectErr, err)
+			}
+		})
+	}
+}
+
+// newSelfSignedCertificate returns the DER encoding of a generated
+// self-signed certificate with the common name.
+func newSelfSignedCertificate(t *testing.T, commonName string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	return der
+}
+
+// writeClientCertificate generates a self-signed client certificate with the
+// common name, and writes the certificate and key to the files.
+func writeClientCertificate(t *testing.T, certFile, keyFile, commonName string, modTime time.Time) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.Chtimes(f, modTime, modTime); err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+	}
+}
+
+func TestBuildableClient_WithClientCertificateFiles(t *testing.T) {
+	var commonName string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commonName = r.TLS.PeerCertificates[0].Subject.CommonName
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key")
+	modTime := time.Now().Add(-time.Minute)
+	writeClientCertificate(t, certFile, keyFile, "first", modTime)
+
+	client, err := NewBuildableClient().WithCABundle(serverCertPEM(server))
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	client, err = client.WithClientCertificateFiles(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	client = client.WithTransportOptions(func(tr *http.Transport) {
+		// New connection for each request, so certificates are requested
+		// each time.
+		tr.DisableKeepAlives = true
+	})
+
+	if _, err := doGet(t, client, server.URL); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "first", commonName; e != a {
+		t.Errorf("expect %v client certificate, got %v", e, a)
+	}
+
+	writeClientCertificate(t, certFile, keyFile, "rotated", modTime.Add(time.Second))
+
+	if _, err := doGet(t, client, server.URL); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "rotated", commonName; e != a {
+		t.Errorf("expect %v client certificate, got %v", e, a)
+	}
+
+	if _, err := NewBuildableClient().WithClientCertificateFiles(filepath.Join(dir, "missing.pem"), keyFile); err == nil {
+		t.Errorf("expect missing file error, got none")
+	}
+}
@@ -3,6 +3,9 @@ module github.com/strick-j/cybr-sdk-go
 go 1.21.4
 
 require (
-	github.com/aws/smithy-go v1.19.0 // indirect
-	github.com/google/go-cmp v0.6.0 // indirect
+	github.com/aws/smithy-go v1.19.0
+	github.com/google/go-cmp v0.6.0
+	golang.org/x/term v0.15.0
 )
+
+require golang.org/x/sys v0.15.0 // indirect
@@ -0,0 +1,217 @@
+// Package endpoints provides the default endpoint resolver used by the SDK's
+// API clients to derive a service's base URL from the client's tenant name.
+package endpoints
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/discovery"
+)
+
+// tenantPlaceholder is the placeholder in an endpoint's hostname template
+// that is replaced by the tenant name.
+const tenantPlaceholder = "{tenant}"
+
+// Service identifiers of the services the default resolver knows the
+// endpoints of.
+const (
+	ServiceDPA        = "dpa"
+	ServicePCloud     = "pcloud"
+	ServiceSecretsHub = "secretshub"
+	ServiceIDAdmin    = "idadmin"
+)
+
+// validTenantName matches tenant names that can safely be used as a DNS label
+// in the endpoint's hostname.
+var validTenantName = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// Options is the endpoint resolver configuration options.
+type Options struct {
+	// The tenant name the endpoint will be resolved for.
+	TenantName string
+
+	// BaseEndpoint overrides the endpoint resolved from the service's
+	// endpoint metadata. Endpoints resolved from the BaseEndpoint will have
+	// the EndpointSourceCustom source.
+	BaseEndpoint *string
+
+	// DisableHTTPS informs the resolver to return an endpoint that does not
+	// use the HTTPS scheme.
+	DisableHTTPS bool
+
+	// Discovery is the platform discovery result of the tenant. Set by the
+	// service clients' ResolveEndpoint middleware from the client's discovery
+	// client. If set, and the service was discovered for the tenant, the
+	// discovered hostname is used instead of the service's hostname template.
+	Discovery *discovery.Result
+}
+
+// GetDisableHTTPS returns the DisableHTTPS option.
+func (o Options) GetDisableHTTPS() bool {
+	return o.DisableHTTPS
+}
+
+// Endpoint is the endpoint metadata of a service.
+type Endpoint struct {
+	// Hostname is the template of the service's hostname. The {tenant}
+	// placeholder is replaced with the tenant name.
+	Hostname string
+
+	// Path is the base path of the service's API, if any.
+	Path string
+
+	// DiscoveryKey is the key of the service in the platform discovery API's
+	// response, if the service's endpoint can be discovered.
+	DiscoveryKey string
+}
+
+// Endpoints is the set of service endpoint metadata, keyed by service
+// identifier.
+type Endpoints map[string]Endpoint
+
+// DefaultEndpoints is the endpoint metadata of the services supported by the
+// SDK.
+var DefaultEndpoints = Endpoints{
+	ServiceDPA: {
+		Hostname:     "{tenant}.dpa.cyberark.cloud",
+		DiscoveryKey: discovery.ServiceKeyDPA,
+	},
+	ServicePCloud: {
+		Hostname:     "{tenant}.privilegecloud.cyberark.cloud",
+		DiscoveryKey: discovery.ServiceKeyPCloud,
+	},
+	ServiceSecretsHub: {
+		Hostname:     "{tenant}.secretshub.cyberark.cloud",
+		DiscoveryKey: discovery.ServiceKeySecretsHub,
+	},
+	ServiceIDAdmin: {
+		Hostname: "{tenant}.cyberark.cloud",
+		Path:     "/api/idadmin",
+	},
+}
+
+// Resolver resolves a service's endpoint for a tenant from the service's
+// endpoint metadata.
+type Resolver struct {
+	endpoints Endpoints
+}
+
+// New returns a Resolver for the DefaultEndpoints.
+func New() *Resolver {
+	return NewWithEndpoints(DefaultEndpoints)
+}
+
+// NewWithEndpoints returns a Resolver for the endpoint metadata provided.
+func NewWithEndpoints(endpoints Endpoints) *Resolver {
+	return &Resolver{
+		endpoints: endpoints,
+	}
+}
+
+// ResolveEndpoint resolves the endpoint of the service for the tenant. If
+// Options.BaseEndpoint is set it is returned as a custom endpoint. If the
+// service's endpoint was discovered for the tenant, the discovered hostname
+// is used. Otherwise the endpoint is derived from the service's hostname
+// template and the tenant name. The service identifier is matched case
+// insensitively, allowing a service client's ServiceID to be used. An
+// EndpointNotFoundError is returned if the service is unknown, or the tenant
+// name is not valid.
+func (r *Resolver) ResolveEndpoint(service string, options Options) (cybr.Endpoint, error) {
+	if options.BaseEndpoint != nil && len(*options.BaseEndpoint) != 0 {
+		return cybr.Endpoint{
+			URL:               *options.BaseEndpoint,
+			HostnameImmutable: true,
+			Source:            cybr.EndpointSourceCustom,
+		}, nil
+	}
+
+	endpoint, ok := r.endpoints[strings.ToLower(service)]
+	if !ok {
+		return cybr.Endpoint{}, &cybr.EndpointNotFoundError{
+			Err: fmt.Errorf("unknown service %q", service),
+		}
+	}
+
+	if hostname, ok := discoveredHostname(endpoint, options.Discovery); ok {
+		return cybr.Endpoint{
+			URL:    scheme(options) + "://" + hostname + endpoint.Path,
+			Source: cybr.EndpointSourceServiceMetadata,
+		}, nil
+	}
+
+	if len(options.TenantName) == 0 {
+		return cybr.Endpoint{}, &cybr.EndpointNotFoundError{
+			Err: fmt.Errorf("tenant name is required to resolve %s endpoint", service),
+		}
+	}
+	if !validTenantName.MatchString(options.TenantName) {
+		return cybr.Endpoint{}, &cybr.EndpointNotFoundError{
+			Err: fmt.Errorf("invalid tenant name %q", options.TenantName),
+		}
+	}
+
+	hostname := strings.Replace(endpoint.Hostname, tenantPlaceholder, strings.ToLower(options.TenantName), 1)
+
+	return cybr.Endpoint{
+		URL:    scheme(options) + "://" + hostname + endpoint.Path,
+		Source: cybr.EndpointSourceServiceMetadata,
+	}, nil
+}
+
+// discoveredHostname returns the hostname of the service's API from the
+// discovery result, if the service was discovered.
+func discoveredHostname(endpoint Endpoint, result *discovery.Result) (string, bool) {
+	if len(endpoint.DiscoveryKey) == 0 {
+		return "", false
+	}
+
+	service, ok := result.ServiceEndpoint(endpoint.DiscoveryKey)
+	if !ok {
+		return "", false
+	}
+
+	u, err := url.Parse(service.API)
+	if err != nil || len(u.Host) == 0 {
+		return "", false
+	}
+	return u.Host, true
+}
+
+func scheme(options Options) string {
+	if options.DisableHTTPS {
+		return "http"
+	}
+	return "https"
+}
+
+// ResolveWithFallback resolves the service's endpoint with the custom
+// resolver if one is provided. If the custom resolver is nil, or returns an
+// EndpointNotFoundError, the endpoint will be resolved by the default
+// resolver. Options.BaseEndpoint takes precedence over both resolvers.
+//
+// Used by the service clients to wrap the cybr.Config's
+// EndpointResolverWithOptions.
+func ResolveWithFallback(
+	custom cybr.EndpointResolverWithOptions, fallback *Resolver, service string, options Options,
+) (cybr.Endpoint, error) {
+	if options.BaseEndpoint == nil || len(*options.BaseEndpoint) == 0 {
+		if custom != nil {
+			endpoint, err := custom.ResolveEndpoint(service, options)
+			if err == nil {
+				return endpoint, nil
+			}
+
+			var nfe *cybr.EndpointNotFoundError
+			if !errors.As(err, &nfe) {
+				return endpoint, err
+			}
+		}
+	}
+
+	return fallback.ResolveEndpoint(service, options)
+}
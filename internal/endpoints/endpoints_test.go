@@ -0,0 +1,218 @@
+package endpoints
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/discovery"
+)
+
+func TestResolver_ResolveEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		Service   string
+		Options   Options
+		Expect    cybr.Endpoint
+		ExpectErr bool
+	}{
+		"dpa": {
+			Service: ServiceDPA,
+			Options: Options{TenantName: "example"},
+			Expect: cybr.Endpoint{
+				URL:    "https://example.dpa.cyberark.cloud",
+				Source: cybr.EndpointSourceServiceMetadata,
+			},
+		},
+		"pcloud": {
+			Service: ServicePCloud,
+			Options: Options{TenantName: "example"},
+			Expect: cybr.Endpoint{
+				URL:    "https://example.privilegecloud.cyberark.cloud",
+				Source: cybr.EndpointSourceServiceMetadata,
+			},
+		},
+		"secretshub": {
+			Service: ServiceSecretsHub,
+			Options: Options{TenantName: "example"},
+			Expect: cybr.Endpoint{
+				URL:    "https://example.secretshub.cyberark.cloud",
+				Source: cybr.EndpointSourceServiceMetadata,
+			},
+		},
+		"idadmin": {
+			Service: ServiceIDAdmin,
+			Options: Options{TenantName: "example"},
+			Expect: cybr.Endpoint{
+				URL:    "https://example.cyberark.cloud/api/idadmin",
+				Source: cybr.EndpointSourceServiceMetadata,
+			},
+		},
+		"mixed case tenant": {
+			Service: ServiceDPA,
+			Options: Options{TenantName: "Example-Corp"},
+			Expect: cybr.Endpoint{
+				URL:    "https://example-corp.dpa.cyberark.cloud",
+				Source: cybr.EndpointSourceServiceMetadata,
+			},
+		},
+		"disable https": {
+			Service: ServiceDPA,
+			Options: Options{TenantName: "example", DisableHTTPS: true},
+			Expect: cybr.Endpoint{
+				URL:    "http://example.dpa.cyberark.cloud",
+				Source: cybr.EndpointSourceServiceMetadata,
+			},
+		},
+		"base endpoint": {
+			Service: ServiceDPA,
+			Options: Options{TenantName: "example", BaseEndpoint: cybr.String("https://localhost:8443")},
+			Expect: cybr.Endpoint{
+				URL:               "https://localhost:8443",
+				HostnameImmutable: true,
+				Source:            cybr.EndpointSourceCustom,
+			},
+		},
+		"base endpoint unknown service": {
+			Service: "unknown",
+			Options: Options{BaseEndpoint: cybr.String("https://localhost:8443")},
+			Expect: cybr.Endpoint{
+				URL:               "https://localhost:8443",
+				HostnameImmutable: true,
+				Source:            cybr.EndpointSourceCustom,
+			},
+		},
+		"discovered": {
+			Service: ServiceDPA,
+			Options: Options{
+				TenantName: "example",
+				Discovery: &discovery.Result{
+					Endpoints: map[string]discovery.ServiceEndpoint{
+						discovery.ServiceKeyDPA: {API: "https://example.dpa.cyberark.cloud.eu/api"},
+					},
+				},
+			},
+			Expect: cybr.Endpoint{
+				URL:    "https://example.dpa.cyberark.cloud.eu",
+				Source: cybr.EndpointSourceServiceMetadata,
+			},
+		},
+		"not discovered": {
+			Service: ServicePCloud,
+			Options: Options{
+				TenantName: "example",
+				Discovery: &discovery.Result{
+					Endpoints: map[string]discovery.ServiceEndpoint{
+						discovery.ServiceKeyDPA: {API: "https://example.dpa.cyberark.cloud.eu/api"},
+					},
+				},
+			},
+			Expect: cybr.Endpoint{
+				URL:    "https://example.privilegecloud.cyberark.cloud",
+				Source: cybr.EndpointSourceServiceMetadata,
+			},
+		},
+		"unknown service": {
+			Service:   "unknown",
+			Options:   Options{TenantName: "example"},
+			ExpectErr: true,
+		},
+		"no tenant": {
+			Service:   ServiceDPA,
+			ExpectErr: true,
+		},
+		"invalid tenant": {
+			Service:   ServiceDPA,
+			Options:   Options{TenantName: "example.evil.com/"},
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			endpoint, err := New().ResolveEndpoint(c.Service, c.Options)
+			if c.ExpectErr {
+				var nfe *cybr.EndpointNotFoundError
+				if !errors.As(err, &nfe) {
+					t.Fatalf("expect %T error, got %v", nfe, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+
+			if e, a := c.Expect, endpoint; e != a {
+				t.Errorf("expect %v endpoint, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestResolveWithFallback(t *testing.T) {
+	custom := cybr.EndpointResolverWithOptionsFunc(func(service string, options ...interface{}) (cybr.Endpoint, error) {
+		switch service {
+		case ServiceDPA:
+			return cybr.Endpoint{URL: "https://dpa.example.com", Source: cybr.EndpointSourceCustom}, nil
+		case ServicePCloud:
+			return cybr.Endpoint{}, &cybr.EndpointNotFoundError{Err: fmt.Errorf("not found")}
+		default:
+			return cybr.Endpoint{}, fmt.Errorf("resolver failed")
+		}
+	})
+
+	cases := map[string]struct {
+		Custom    cybr.EndpointResolverWithOptions
+		Service   string
+		Options   Options
+		ExpectURL string
+		ExpectErr bool
+	}{
+		"custom resolver": {
+			Custom:    custom,
+			Service:   ServiceDPA,
+			Options:   Options{TenantName: "example"},
+			ExpectURL: "https://dpa.example.com",
+		},
+		"fallback on not found": {
+			Custom:    custom,
+			Service:   ServicePCloud,
+			Options:   Options{TenantName: "example"},
+			ExpectURL: "https://example.privilegecloud.cyberark.cloud",
+		},
+		"custom resolver error": {
+			Custom:    custom,
+			Service:   ServiceSecretsHub,
+			Options:   Options{TenantName: "example"},
+			ExpectErr: true,
+		},
+		"service id": {
+			Service:   "PCloud",
+			Options:   Options{TenantName: "example"},
+			ExpectURL: "https://example.privilegecloud.cyberark.cloud",
+		},
+		"no custom resolver": {
+			Service:   ServiceDPA,
+			Options:   Options{TenantName: "example"},
+			ExpectURL: "https://example.dpa.cyberark.cloud",
+		},
+		"base endpoint precedence": {
+			Custom:    custom,
+			Service:   ServiceDPA,
+			Options:   Options{TenantName: "example", BaseEndpoint: cybr.String("https://localhost:8443")},
+			ExpectURL: "https://localhost:8443",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			endpoint, err := ResolveWithFallback(c.Custom, New(), c.Service, c.Options)
+			if (err != nil) != c.ExpectErr {
+				t.Fatalf("expect error %v, got %v", c.ExpectErr, err)
+			}
+			if e, a := c.ExpectURL, endpoint.URL; e != a {
+				t.Errorf("expect %v URL, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Package ini implements parsing of the shared config and credentials files
+// used by the SDK.
+//
+// The supported format is a subset of INI. Sections are declared with square
+// brackets, and contain key value pairs separated by an equal sign. Lines
+// starting with '#' or ';' are treated as comments.
+//
+//	[profile dev]
+//	tenant_name = example-dev
+//	retry_mode  = standard
+package ini
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// OpenFile parses the file at path into Sections.
+func OpenFile(path string) (Sections, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Sections{}, &UnableToReadFile{Err: err}
+	}
+	defer f.Close()
+
+	return Parse(f, path)
+}
+
+// Parse parses the ini document read from r into Sections. The path is used
+// for error messages, and may be empty.
+func Parse(r io.Reader, path string) (Sections, error) {
+	sections := NewSections()
+
+	var current *Section
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || isComment(line) {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return Sections{}, &ParseError{Path: path, Line: lineNum, Msg: "unterminated section name"}
+			}
+			name := normalizeSectionName(line[1 : len(line)-1])
+			if len(name) == 0 {
+				return Sections{}, &ParseError{Path: path, Line: lineNum, Msg: "empty section name"}
+			}
+
+			// Duplicate sections are merged, with later values taking
+			// precedence.
+			s, ok := sections.container[name]
+			if !ok {
+				s = NewSection(name)
+				sections.container[name] = s
+			}
+			current = &s
+			continue
+		}
+
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return Sections{}, &ParseError{Path: path, Line: lineNum, Msg: "expected key = value"}
+		}
+		if current == nil {
+			return Sections{}, &ParseError{Path: path, Line: lineNum, Msg: "value defined outside of a section"}
+		}
+
+		k = strings.ToLower(strings.TrimSpace(k))
+		if len(k) == 0 {
+			return Sections{}, &ParseError{Path: path, Line: lineNum, Msg: "empty key"}
+		}
+		current.values[k] = trimValue(v)
+	}
+	if err := scanner.Err(); err != nil {
+		return Sections{}, &UnableToReadFile{Err: err}
+	}
+
+	return sections, nil
+}
+
+// Sections is a map of Section structures that represent a configuration.
+type Sections struct {
+	container map[string]Section
+}
+
+// NewSections returns empty ini Sections
+func NewSections() Sections {
+	return Sections{
+		container: make(map[string]Section),
+	}
+}
+
+// GetSection will return section p. If section p does not exist,
+// false will be returned in the second parameter.
+func (t Sections) GetSection(p string) (Section, bool) {
+	v, ok := t.container[p]
+	return v, ok
+}
+
+// HasSection denotes if Sections consist of a section with
+// provided name.
+func (t Sections) HasSection(p string) bool {
+	_, ok := t.container[p]
+	return ok
+}
+
+// List will return a list of all sections that were successfully
+// parsed.
+func (t Sections) List() []string {
+	keys := make([]string, 0, len(t.container))
+	for k := range t.container {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Section contains a name and values. This represent
+// a sectioned entry in a configuration file.
+type Section struct {
+	// Name is the Section profile name
+	Name string
+
+	values map[string]string
+}
+
+// NewSection returns an initialize section for the name
+func NewSection(name string) Section {
+	return Section{
+		Name:   name,
+		values: map[string]string{},
+	}
+}
+
+// Has will return whether or not an entry exists in a given section
+func (t Section) Has(k string) bool {
+	_, ok := t.values[strings.ToLower(k)]
+	return ok
+}
+
+// String returns the string value of the key k. An empty string is returned
+// if the key does not exist.
+func (t Section) String(k string) string {
+	return t.values[strings.ToLower(k)]
+}
+
+// Keys returns the keys set within the section.
+func (t Section) Keys() []string {
+	keys := make([]string, 0, len(t.values))
+	for k := range t.values {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// UnableToReadFile is an error indicating that a ini file could not be read
+type UnableToReadFile struct {
+	Err error
+}
+
+// Error returns an error message and the underlying error message if present
+func (e *UnableToReadFile) Error() string {
+	base := "unable to read file"
+	if e.Err == nil {
+		return base
+	}
+	return fmt.Sprintf("%s, %v", base, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *UnableToReadFile) Unwrap() error {
+	return e.Err
+}
+
+// ParseError is an error which is returned during any part of
+// the parsing process.
+type ParseError struct {
+	Path string
+	Line int
+	Msg  string
+}
+
+// Error returns the parse error message with its location.
+func (e *ParseError) Error() string {
+	if len(e.Path) == 0 {
+		return fmt.Sprintf("ini: line %d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("ini: %s:%d: %s", e.Path, e.Line, e.Msg)
+}
+
+func isComment(line string) bool {
+	return line[0] == '#' || line[0] == ';'
+}
+
+// normalizeSectionName trims the section name, and collapses whitespace
+// between a section's prefix and name, e.g. "profile   dev" becomes
+// "profile dev".
+func normalizeSectionName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// trimValue removes surrounding whitespace, and a single pair of surrounding
+// quotes from the value. Comments are only supported on their own line, so
+// '#' and ';' within a value, e.g. in a client secret, are preserved.
+func trimValue(v string) string {
+	v = strings.TrimSpace(v)
+	if len(v) >= 2 && (v[0] == '"' && v[len(v)-1] == '"' || v[0] == '\'' && v[len(v)-1] == '\'') {
+		v = v[1 : len(v)-1]
+	}
+	return v
+}
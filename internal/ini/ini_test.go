@@ -0,0 +1,94 @@
+package ini
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	const doc = `
+# comment
+[default]
+tenant_name = example
+
+[profile   dev]
+Tenant_Name = example-dev
+; comment
+app_id      = "quoted value"
+retry_mode=standard
+client_secret = s3cr3t #not a comment ; nor this
+bearer_token  = 'abc ;def'
+
+[default]
+tenant_id = ABC1234
+`
+
+	sections, err := Parse(strings.NewReader(doc), "")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	def, ok := sections.GetSection("default")
+	if !ok {
+		t.Fatalf("expect default section")
+	}
+	if e, a := "example", def.String("tenant_name"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "ABC1234", def.String("tenant_id"); e != a {
+		t.Errorf("expect merged duplicate section value %v, got %v", e, a)
+	}
+
+	dev, ok := sections.GetSection("profile dev")
+	if !ok {
+		t.Fatalf("expect profile dev section, got %v", sections.List())
+	}
+	if e, a := "example-dev", dev.String("tenant_name"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "quoted value", dev.String("app_id"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "standard", dev.String("retry_mode"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "s3cr3t #not a comment ; nor this", dev.String("client_secret"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if e, a := "abc ;def", dev.String("bearer_token"); e != a {
+		t.Errorf("expect %v, got %v", e, a)
+	}
+	if dev.Has("tenant_id") {
+		t.Errorf("expect tenant_id to not be set on dev profile")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := map[string]string{
+		"unterminated section": "[default\n",
+		"empty section":        "[ ]\n",
+		"missing equals":       "[default]\ntenant_name\n",
+		"outside section":      "tenant_name = example\n",
+		"empty key":            "[default]\n = value\n",
+	}
+
+	for name, doc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(doc), "config")
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expect %T error, got %v", parseErr, err)
+			}
+		})
+	}
+}
+
+func TestOpenFile_NotExist(t *testing.T) {
+	_, err := OpenFile(filepath.Join(t.TempDir(), "missing"))
+	var readErr *UnableToReadFile
+	if !errors.As(err, &readErr) {
+		t.Fatalf("expect %T error, got %v", readErr, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 func init() {
 	NowTime = time.Now
 	Sleep = time.Sleep
+	SleepWithContext = sleepWithContext
 }
 
 // NowTime is a value for getting the current time. This value can be overridden
@@ -1 +1,379 @@
 package dpa
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/aws/smithy-go"
+	smithydocument "github.com/aws/smithy-go/document"
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/defaults"
+	cybrmiddleware "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+	"github.com/strick-j/cybr-sdk-go/cybr/retry"
+	"github.com/strick-j/cybr-sdk-go/cybr/signer/bearer"
+	cybrhttp "github.com/strick-j/cybr-sdk-go/cybr/transport/http"
+)
+
+// ServiceID is the identifier of the Dynamic Privileged Access service.
+const ServiceID = "DPA"
+
+// ServiceAPIVersion is the version of the Dynamic Privileged Access API the
+// client implements.
+const ServiceAPIVersion = "v2"
+
+// Client provides the API client to make operations call for CyberArk Dynamic
+// Privileged Access.
+type Client struct {
+	options Options
+}
+
+// New returns an initialized Client based on the functional options. Provide
+// additional functional options to further configure the behavior of the client,
+// such as changing the client's endpoint or adding custom middleware behavior.
+func New(options Options, optFns ...func(*Options)) *Client {
+	options = options.Copy()
+
+	resolveDefaultLogger(&options)
+
+	setResolvedDefaultsMode(&options)
+
+	resolveRetryer(&options)
+
+	resolveHTTPClient(&options)
+
+	resolveDefaultEndpointConfiguration(&options)
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	finalizeRetryMaxAttempts(&options)
+
+	resolveDiscoveryClient(&options)
+
+	client := &Client{
+		options: options,
+	}
+
+	return client
+}
+
+// Options returns a copy of the client configuration.
+//
+// Callers SHOULD NOT perform mutations on any inner structures within client
+// config. Config overrides should instead be made on a per-operation basis through
+// functional options.
+func (c *Client) Options() Options {
+	return c.options.Copy()
+}
+
+func (c *Client) invokeOperation(ctx context.Context, opID string, params interface{}, optFns []func(*Options), stackFns ...func(*middleware.Stack, Options) error) (result interface{}, metadata middleware.Metadata, err error) {
+	ctx = middleware.ClearStackValues(ctx)
+	stack := middleware.NewStack(opID, smithyhttp.NewStackRequest)
+	options := c.options.Copy()
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	finalizeOperationRetryMaxAttempts(&options, *c)
+
+	for _, fn := range stackFns {
+		if err := fn(stack, options); err != nil {
+			return nil, metadata, err
+		}
+	}
+
+	for _, fn := range options.APIOptions {
+		if err := fn(stack); err != nil {
+			return nil, metadata, err
+		}
+	}
+
+	handler := middleware.DecorateHandler(smithyhttp.NewClientHandler(options.HTTPClient), stack)
+	result, metadata, err = handler.Handle(ctx, params)
+	if err != nil {
+		err = &smithy.OperationError{
+			ServiceID:     ServiceID,
+			OperationName: opID,
+			Err:           err,
+		}
+	}
+	return result, metadata, err
+}
+
+type noSmithyDocumentSerde = smithydocument.NoSerde
+
+// NewFromConfig returns a new client from the provided config.
+func NewFromConfig(cfg cybr.Config, optFns ...func(*Options)) *Client {
+	opts := Options{
+		TenantName:         cfg.TenantName,
+		DefaultsMode:       cfg.DefaultsMode,
+		RuntimeEnvironment: cfg.RuntimeEnvironment,
+		HTTPClient:         cfg.HTTPClient,
+		Credentials:        cfg.Credentials,
+		APIOptions:         cfg.APIOptions,
+		Logger:             cfg.Logger,
+		ClientLogMode:      cfg.ClientLogMode,
+		AppID:              cfg.AppID,
+	}
+	resolveCYBRRetryerProvider(cfg, &opts)
+	resolveCYBRRetryMaxAttempts(cfg, &opts)
+	resolveCYBRRetryMode(cfg, &opts)
+	resolveCYBREndpointResolver(cfg, &opts)
+	resolveBaseEndpoint(cfg, &opts)
+	return New(opts, optFns...)
+}
+
+func resolveDefaultLogger(o *Options) {
+	if o.Logger != nil {
+		return
+	}
+	o.Logger = logging.Nop{}
+}
+
+func addSetLoggerMiddleware(stack *middleware.Stack, o Options) error {
+	return middleware.AddSetLoggerMiddleware(stack, o.Logger)
+}
+
+func setResolvedDefaultsMode(o *Options) {
+	if len(o.resolvedDefaultsMode) > 0 {
+		return
+	}
+
+	var mode cybr.DefaultsMode
+	mode.SetFromString(string(o.DefaultsMode))
+
+	if mode == cybr.DefaultsModeAuto {
+		mode = defaults.ResolveDefaultsModeAuto(o.RuntimeEnvironment)
+	}
+
+	o.resolvedDefaultsMode = mode
+}
+
+func resolveHTTPClient(o *Options) {
+	var buildable *cybrhttp.BuildableClient
+
+	if o.HTTPClient != nil {
+		var ok bool
+		buildable, ok = o.HTTPClient.(*cybrhttp.BuildableClient)
+		if !ok {
+			return
+		}
+	} else {
+		buildable = cybrhttp.NewBuildableClient()
+	}
+
+	modeConfig, err := defaults.GetModeConfiguration(o.resolvedDefaultsMode)
+	if err == nil {
+		buildable = buildable.WithDialerOptions(func(dialer *net.Dialer) {
+			if dialerTimeout, ok := modeConfig.GetConnectTimeout(); ok {
+				dialer.Timeout = dialerTimeout
+			}
+		})
+
+		buildable = buildable.WithTransportOptions(func(transport *http.Transport) {
+			if tlsHandshakeTimeout, ok := modeConfig.GetTLSNegotiationTimeout(); ok {
+				transport.TLSHandshakeTimeout = tlsHandshakeTimeout
+			}
+		})
+	}
+
+	o.HTTPClient = buildable
+}
+
+func resolveRetryer(o *Options) {
+	if o.Retryer != nil {
+		return
+	}
+
+	if len(o.RetryMode) == 0 {
+		modeConfig, err := defaults.GetModeConfiguration(o.resolvedDefaultsMode)
+		if err == nil {
+			o.RetryMode = modeConfig.RetryMode
+		}
+	}
+	if len(o.RetryMode) == 0 {
+		o.RetryMode = cybr.RetryModeStandard
+	}
+
+	var standardOptions []func(*retry.StandardOptions)
+	if v := o.RetryMaxAttempts; v != 0 {
+		standardOptions = append(standardOptions, func(so *retry.StandardOptions) {
+			so.MaxAttempts = v
+		})
+	}
+
+	switch o.RetryMode {
+	case cybr.RetryModeAdaptive:
+		var adaptiveOptions []func(*retry.AdaptiveModeOptions)
+		if len(standardOptions) != 0 {
+			adaptiveOptions = append(adaptiveOptions, func(ao *retry.AdaptiveModeOptions) {
+				ao.StandardOptions = append(ao.StandardOptions, standardOptions...)
+			})
+		}
+		o.Retryer = retry.NewAdaptiveMode(adaptiveOptions...)
+
+	default:
+		o.Retryer = retry.NewStandard(standardOptions...)
+	}
+}
+
+func resolveCYBRRetryerProvider(cfg cybr.Config, o *Options) {
+	if cfg.Retryer == nil {
+		return
+	}
+	o.Retryer = cfg.Retryer()
+}
+
+func resolveCYBRRetryMode(cfg cybr.Config, o *Options) {
+	if len(cfg.RetryMode) == 0 {
+		return
+	}
+	o.RetryMode = cfg.RetryMode
+}
+
+func resolveCYBRRetryMaxAttempts(cfg cybr.Config, o *Options) {
+	if cfg.RetryMaxAttempts == 0 {
+		return
+	}
+	o.RetryMaxAttempts = cfg.RetryMaxAttempts
+}
+
+func finalizeRetryMaxAttempts(o *Options) {
+	if o.RetryMaxAttempts == 0 {
+		return
+	}
+
+	o.Retryer = retry.AddWithMaxAttempts(o.Retryer, o.RetryMaxAttempts)
+}
+
+func finalizeOperationRetryMaxAttempts(o *Options, client Client) {
+	if v := o.RetryMaxAttempts; v == 0 || v == client.options.RetryMaxAttempts {
+		return
+	}
+
+	o.Retryer = retry.AddWithMaxAttempts(o.Retryer, o.RetryMaxAttempts)
+}
+
+func resolveBaseEndpoint(cfg cybr.Config, o *Options) {
+	if cfg.BaseEndpoint != nil {
+		o.BaseEndpoint = cfg.BaseEndpoint
+	}
+}
+
+func addClientUserAgent(stack *middleware.Stack, options Options) error {
+	if err := cybrmiddleware.AddSDKAgentKeyValue(cybrmiddleware.APIMetadata, "dpa", goModuleVersion)(stack); err != nil {
+		return err
+	}
+
+	if len(options.AppID) > 0 {
+		return cybrmiddleware.AddSDKAgentKey(cybrmiddleware.ApplicationIdentifier, options.AppID)(stack)
+	}
+
+	return nil
+}
+
+func addBearerSigningMiddleware(stack *middleware.Stack, o Options) error {
+	return bearer.AddSignHTTPRequestMiddleware(stack, bearer.SignHTTPRequestMiddlewareOptions{
+		CredentialsProvider: o.Credentials,
+		LogSigning:          o.ClientLogMode.IsSigning(),
+	})
+}
+
+func addRetryMiddlewares(stack *middleware.Stack, o Options) error {
+	return retry.AddRetryMiddlewares(stack, retry.AddRetryMiddlewaresOptions{
+		Retryer:          o.Retryer,
+		LogRetryAttempts: o.ClientLogMode.IsRetries(),
+	})
+}
+
+func addRequestIDRetrieverMiddleware(stack *middleware.Stack) error {
+	return cybrmiddleware.AddRequestIDRetrieverMiddleware(stack)
+}
+
+func addResponseErrorMiddleware(stack *middleware.Stack) error {
+	return cybrhttp.AddResponseErrorMiddleware(stack)
+}
+
+func addRequestResponseLogging(stack *middleware.Stack, o Options) error {
+	return cybrmiddleware.AddRequestResponseLogMiddleware(stack, cybrmiddleware.AddRequestResponseLogMiddlewareOptions{
+		ClientLogMode:  o.ClientLogMode,
+		RedactionRules: redactionRules(),
+	})
+}
+
+// redactionRules returns the SDK's default redaction rules, with the members
+// of the short-lived credentials, strong account secrets, and connector setup
+// scripts, sent to and returned by the service. The setup script's URL and
+// command carry the connector's one-time registration token.
+func redactionRules() *cybrmiddleware.RedactionRules {
+	rules := cybrmiddleware.DefaultRedactionRules()
+	rules.Fields = append(rules.Fields, "private_key", "rdp_file", "iam_secret_access_key",
+		"script_url", "bash_cmd")
+	return &rules
+}
+
+// addOperationMiddlewares adds the middleware shared by all of the client's
+// operations to the stack. The operation's serializer, and deserializer must
+// already be added to the stack, as other middleware are positioned relative
+// to them.
+func addOperationMiddlewares(stack *middleware.Stack, options Options, operationName string) (err error) {
+	if err = addSetLoggerMiddleware(stack, options); err != nil {
+		return err
+	}
+	if err = stack.Initialize.Add(newServiceMetadataMiddleware(operationName), middleware.Before); err != nil {
+		return err
+	}
+	if err = addResolveEndpointMiddleware(stack, options); err != nil {
+		return err
+	}
+	if err = cybrmiddleware.AddClientRequestIDMiddleware(stack); err != nil {
+		return err
+	}
+	if err = smithyhttp.AddComputeContentLengthMiddleware(stack); err != nil {
+		return err
+	}
+	if err = addClientUserAgent(stack, options); err != nil {
+		return err
+	}
+	if err = addRetryMiddlewares(stack, options); err != nil {
+		return err
+	}
+	if err = addBearerSigningMiddleware(stack, options); err != nil {
+		return err
+	}
+	if err = cybrmiddleware.AddRawResponseToMetadata(stack); err != nil {
+		return err
+	}
+	if err = cybrmiddleware.AddRecordResponseTiming(stack); err != nil {
+		return err
+	}
+	if err = smithyhttp.AddErrorCloseResponseBodyMiddleware(stack); err != nil {
+		return err
+	}
+	if err = smithyhttp.AddCloseResponseBodyMiddleware(stack); err != nil {
+		return err
+	}
+	if err = addRequestIDRetrieverMiddleware(stack); err != nil {
+		return err
+	}
+	if err = addResponseErrorMiddleware(stack); err != nil {
+		return err
+	}
+	if err = addRequestResponseLogging(stack, options); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newServiceMetadataMiddleware(operationName string) *cybrmiddleware.RegisterServiceMetadata {
+	return &cybrmiddleware.RegisterServiceMetadata{
+		ServiceID:     ServiceID,
+		OperationName: operationName,
+	}
+}
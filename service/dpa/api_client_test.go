@@ -0,0 +1,466 @@
+package dpa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/discovery"
+	cybrmiddleware "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+	"github.com/strick-j/cybr-sdk-go/cybr/retry"
+)
+
+type mockOperationOutput struct {
+	Name string
+
+	ResultMetadata middleware.Metadata
+}
+
+type mockOperationSerializer struct{}
+
+func (*mockOperationSerializer) ID() string { return "OperationSerializer" }
+
+func (*mockOperationSerializer) HandleSerialize(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+	out middleware.SerializeOutput, metadata middleware.Metadata, err error,
+) {
+	request := in.Request.(*smithyhttp.Request)
+	request.Method = http.MethodGet
+	request.URL.Path = smithyhttp.JoinPath(request.URL.Path, "/api/mock")
+	return next.HandleSerialize(ctx, in)
+}
+
+type mockOperationDeserializer struct{}
+
+func (*mockOperationDeserializer) ID() string { return "OperationDeserializer" }
+
+func (*mockOperationDeserializer) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	response := out.RawResponse.(*smithyhttp.Response)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return out, metadata, restjson_deserializeOpError(response, &metadata)
+	}
+
+	output := &mockOperationOutput{}
+	if err := json.NewDecoder(response.Body).Decode(output); err != nil {
+		return out, metadata, &smithy.DeserializationError{Err: err}
+	}
+	out.Result = output
+
+	return out, metadata, nil
+}
+
+func (c *Client) mockOperation(ctx context.Context, optFns ...func(*Options)) (*mockOperationOutput, error) {
+	result, metadata, err := c.invokeOperation(ctx, "MockOperation", struct{}{}, optFns,
+		func(stack *middleware.Stack, options Options) error {
+			if err := stack.Serialize.Add(&mockOperationSerializer{}, middleware.After); err != nil {
+				return err
+			}
+			if err := stack.Deserialize.Add(&mockOperationDeserializer{}, middleware.After); err != nil {
+				return err
+			}
+			return addOperationMiddlewares(stack, options, "MockOperation")
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*mockOperationOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, optFns ...func(*Options)) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	options := Options{
+		BaseEndpoint: cybr.String(server.URL),
+		Credentials: cybr.CredentialsProviderFunc(func(context.Context) (cybr.Credentials, error) {
+			return cybr.Credentials{BearerToken: "token"}, nil
+		}),
+		Retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+			o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+				return 0, nil
+			})
+		}),
+	}
+
+	return New(options, optFns...)
+}
+
+func TestClient_InvokeOperation(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "/api/mock", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		if e, a := "Bearer token", r.Header.Get("Authorization"); e != a {
+			t.Errorf("expect %v authorization, got %v", e, a)
+		}
+		if v := r.Header.Get("Cybr-Sdk-Invocation-Id"); len(v) == 0 {
+			t.Errorf("expect invocation ID header")
+		}
+		if e, a := "api/dpa#"+goModuleVersion, r.Header.Get("X-Cybr-User-Agent")+r.Header.Get("User-Agent"); !strings.Contains(a, e) {
+			t.Errorf("expect user agent to contain %v, got %v", e, a)
+		}
+
+		w.Header().Set("X-Request-Id", "request-id")
+		fmt.Fprint(w, `{"Name":"mock"}`)
+	})
+
+	out, err := client.mockOperation(context.Background())
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "mock", out.Name; e != a {
+		t.Errorf("expect %v name, got %v", e, a)
+	}
+
+	requestID, _ := cybrmiddleware.GetRequestIDMetadata(out.ResultMetadata)
+	if e, a := "request-id", requestID; e != a {
+		t.Errorf("expect %v request ID, got %v", e, a)
+	}
+	results, _ := retry.GetAttemptResults(out.ResultMetadata)
+	if e, a := 1, len(results.Results); e != a {
+		t.Errorf("expect %v attempts, got %v", e, a)
+	}
+}
+
+func TestClient_InvokeOperationError(t *testing.T) {
+	cases := map[string]struct {
+		StatusCodes     []int
+		Body            string
+		ExpectAttempts  int
+		ExpectCode      string
+		ExpectMessage   string
+		ExpectDetails   string
+		ExpectFault     smithy.ErrorFault
+		ExpectRequestID string
+	}{
+		"not found": {
+			StatusCodes:     []int{404},
+			Body:            `{"code":"DPA_NOT_FOUND","message":"Resource not found","description":"target set example.com"}`,
+			ExpectAttempts:  1,
+			ExpectCode:      "DPA_NOT_FOUND",
+			ExpectMessage:   "Resource not found",
+			ExpectDetails:   "target set example.com",
+			ExpectFault:     smithy.FaultClient,
+			ExpectRequestID: "request-id",
+		},
+		"retried server error": {
+			StatusCodes:     []int{500, 503},
+			Body:            `{"message":"Internal error"}`,
+			ExpectAttempts:  3,
+			ExpectCode:      "UnknownError",
+			ExpectMessage:   "Internal error",
+			ExpectFault:     smithy.FaultServer,
+			ExpectRequestID: "request-id",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var attempts int
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				statusCode := c.StatusCodes[attempts%len(c.StatusCodes)]
+				attempts++
+
+				w.Header().Set("X-Request-Id", "request-id")
+				w.WriteHeader(statusCode)
+				fmt.Fprint(w, c.Body)
+			})
+
+			_, err := client.mockOperation(context.Background())
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+			if e, a := c.ExpectAttempts, attempts; e != a {
+				t.Errorf("expect %v attempts, got %v", e, a)
+			}
+
+			var opErr *smithy.OperationError
+			if !errors.As(err, &opErr) {
+				t.Fatalf("expect %T error, got %v", opErr, err)
+			}
+			if e, a := ServiceID, opErr.Service(); e != a {
+				t.Errorf("expect %v service, got %v", e, a)
+			}
+			if e, a := "MockOperation", opErr.Operation(); e != a {
+				t.Errorf("expect %v operation, got %v", e, a)
+			}
+
+			var respErr *cybr.ResponseError
+			if !errors.As(err, &respErr) {
+				t.Fatalf("expect %T error, got %v", respErr, err)
+			}
+			if e, a := c.ExpectRequestID, respErr.ServiceRequestID(); e != a {
+				t.Errorf("expect %v request ID, got %v", e, a)
+			}
+			if e, a := "RequestID: "+c.ExpectRequestID, err.Error(); !strings.Contains(a, e) {
+				t.Errorf("expect error to contain %v, got %v", e, a)
+			}
+
+			var apiErr *cybr.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expect %T error, got %v", apiErr, err)
+			}
+			if e, a := c.ExpectCode, apiErr.Code; e != a {
+				t.Errorf("expect %v code, got %v", e, a)
+			}
+			if e, a := c.ExpectMessage, apiErr.Message; e != a {
+				t.Errorf("expect %v message, got %v", e, a)
+			}
+			if e, a := c.ExpectDetails, apiErr.Details; e != a {
+				t.Errorf("expect %v details, got %v", e, a)
+			}
+			if e, a := c.ExpectFault, apiErr.ErrorFault(); e != a {
+				t.Errorf("expect %v fault, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestClient_ResolveEndpoint(t *testing.T) {
+	var discoveryRequests int
+	discoveryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		discoveryRequests++
+		switch r.URL.Path {
+		case "/api/v2/services/subdomain/example":
+			fmt.Fprint(w, `{
+				"identity_administration": {"api": "https://aa1234.id.cyberark.cloud", "region": "eu-central-1"},
+				"dpa": {"api": "https://example.dpa.cyberark.cloud.eu/api"}
+			}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer discoveryServer.Close()
+
+	discoveryClient := discovery.New(func(o *discovery.Options) {
+		o.Endpoint = discoveryServer.URL
+	})
+
+	cases := map[string]struct {
+		Options                 Options
+		ExpectURL               string
+		ExpectErr               bool
+		ExpectDiscoveryRequests int
+	}{
+		"tenant": {
+			Options:   Options{TenantName: "Example", DisableEndpointDiscovery: true},
+			ExpectURL: "https://example.dpa.cyberark.cloud/api/mock",
+		},
+		"discovered tenant": {
+			Options:                 Options{TenantName: "Example", DiscoveryClient: discoveryClient},
+			ExpectURL:               "https://example.dpa.cyberark.cloud.eu/api/mock",
+			ExpectDiscoveryRequests: 1,
+		},
+		"discovery fallback": {
+			Options:                 Options{TenantName: "Unknown", DiscoveryClient: discoveryClient},
+			ExpectURL:               "https://unknown.dpa.cyberark.cloud/api/mock",
+			ExpectDiscoveryRequests: 1,
+		},
+		"base endpoint": {
+			Options: Options{
+				TenantName:      "example",
+				BaseEndpoint:    cybr.String("https://dpa.example.com/base"),
+				DiscoveryClient: discoveryClient,
+			},
+			ExpectURL: "https://dpa.example.com/base/api/mock",
+		},
+		"endpoint resolver": {
+			Options: Options{
+				EndpointResolver: EndpointResolverFromURL("https://resolved.example.com"),
+			},
+			ExpectURL: "https://resolved.example.com/api/mock",
+		},
+		"no tenant": {
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			discoveryRequests = 0
+			discoveryClient.Invalidate("example")
+
+			var actualURL string
+			client := New(c.Options, WithAPIOptions(func(stack *middleware.Stack) error {
+				return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("captureURL",
+					func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+						middleware.FinalizeOutput, middleware.Metadata, error,
+					) {
+						actualURL = in.Request.(*smithyhttp.Request).URL.String()
+						return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("stop")
+					}), middleware.After)
+			}))
+
+			_, err := client.mockOperation(context.Background(), func(o *Options) {
+				o.Retryer = cybr.NopRetryer{}
+			})
+			if c.ExpectErr {
+				if !strings.Contains(err.Error(), "failed to resolve service endpoint") {
+					t.Fatalf("expect endpoint error, got %v", err)
+				}
+				return
+			}
+			if e, a := c.ExpectURL, actualURL; e != a {
+				t.Errorf("expect %v URL, got %v", e, a)
+			}
+			if e, a := c.ExpectDiscoveryRequests, discoveryRequests; e != a {
+				t.Errorf("expect %v discovery requests, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestClient_ResolveEndpointDiscoveryCached(t *testing.T) {
+	var discoveryRequests int
+	discoveryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		discoveryRequests++
+		fmt.Fprint(w, `{
+			"identity_administration": {"api": "https://aa1234.id.cyberark.cloud"},
+			"dpa": {"api": "https://example.dpa.cyberark.cloud.eu"}
+		}`)
+	}))
+	defer discoveryServer.Close()
+
+	var urls []string
+	client := New(Options{
+		TenantName: "example",
+		DiscoveryClient: discovery.New(func(o *discovery.Options) {
+			o.Endpoint = discoveryServer.URL
+		}),
+		Retryer: cybr.NopRetryer{},
+	}, WithAPIOptions(func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("captureURL",
+			func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+				middleware.FinalizeOutput, middleware.Metadata, error,
+			) {
+				urls = append(urls, in.Request.(*smithyhttp.Request).URL.String())
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("stop")
+			}), middleware.After)
+	}))
+
+	for i := 0; i < 2; i++ {
+		client.mockOperation(context.Background())
+	}
+
+	expect := []string{
+		"https://example.dpa.cyberark.cloud.eu/api/mock",
+		"https://example.dpa.cyberark.cloud.eu/api/mock",
+	}
+	if e, a := expect, urls; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v URLs, got %v", e, a)
+	}
+	if e, a := 1, discoveryRequests; e != a {
+		t.Errorf("expect %v discovery requests, got %v", e, a)
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	cfg := cybr.Config{
+		TenantName:       "example",
+		AppID:            "app",
+		BaseEndpoint:     cybr.String("https://dpa.example.com"),
+		RetryMaxAttempts: 5,
+		RetryMode:        cybr.RetryModeAdaptive,
+		ClientLogMode:    cybr.LogRequest,
+		DefaultsMode:     cybr.DefaultsModeStandard,
+	}
+
+	options := NewFromConfig(cfg).Options()
+
+	if e, a := "example", options.TenantName; e != a {
+		t.Errorf("expect %v tenant name, got %v", e, a)
+	}
+	if e, a := "app", options.AppID; e != a {
+		t.Errorf("expect %v app ID, got %v", e, a)
+	}
+	if e, a := "https://dpa.example.com", cybr.ToString(options.BaseEndpoint); e != a {
+		t.Errorf("expect %v base endpoint, got %v", e, a)
+	}
+	if e, a := cybr.LogRequest, options.ClientLogMode; e != a {
+		t.Errorf("expect %v client log mode, got %v", e, a)
+	}
+	if e, a := cybr.DefaultsModeStandard, options.resolvedDefaultsMode; e != a {
+		t.Errorf("expect %v defaults mode, got %v", e, a)
+	}
+	if e, a := 5, options.Retryer.MaxAttempts(); e != a {
+		t.Errorf("expect %v max attempts, got %v", e, a)
+	}
+	if options.HTTPClient == nil {
+		t.Errorf("expect HTTP client to be set")
+	}
+	if options.Logger == nil {
+		t.Errorf("expect logger to be set")
+	}
+}
+
+func TestNewFromConfig_EndpointResolverWithOptions(t *testing.T) {
+	cases := map[string]struct {
+		Err       error
+		ExpectURL string
+		ExpectErr bool
+	}{
+		"custom endpoint": {
+			ExpectURL: "https://dpa.example.com",
+		},
+		"fallback on not found": {
+			Err:       &cybr.EndpointNotFoundError{Err: fmt.Errorf("not found")},
+			ExpectURL: "https://example.dpa.cyberark.cloud",
+		},
+		"resolver error": {
+			Err:       fmt.Errorf("resolver failed"),
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := cybr.Config{
+				TenantName: "example",
+				EndpointResolverWithOptions: cybr.EndpointResolverWithOptionsFunc(
+					func(service string, options ...interface{}) (cybr.Endpoint, error) {
+						if e, a := ServiceID, service; e != a {
+							t.Errorf("expect %v service, got %v", e, a)
+						}
+						if c.Err != nil {
+							return cybr.Endpoint{}, c.Err
+						}
+						return cybr.Endpoint{URL: "https://dpa.example.com", Source: cybr.EndpointSourceCustom}, nil
+					}),
+			}
+
+			options := NewFromConfig(cfg).Options()
+			endpoint, err := options.EndpointResolver.ResolveEndpoint(EndpointResolverOptions{
+				TenantName: options.TenantName,
+			})
+			if (err != nil) != c.ExpectErr {
+				t.Fatalf("expect error %v, got %v", c.ExpectErr, err)
+			}
+			if e, a := c.ExpectURL, endpoint.URL; e != a {
+				t.Errorf("expect %v URL, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Adds a database strong account's secret, used by DPA to provision ephemeral
+// users on the databases the secret is bound to.
+func (c *Client) AddDBSecret(ctx context.Context, params *AddDBSecretInput, optFns ...func(*Options)) (*AddDBSecretOutput, error) {
+	if params == nil {
+		params = &AddDBSecretInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "AddDBSecret", params, optFns, c.addOperationAddDBSecretMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*AddDBSecretOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type AddDBSecretInput struct {
+	// The name of the secret.
+	//
+	// This member is required.
+	SecretName *string `json:"secret_name,omitempty"`
+
+	// The type of the secret.
+	//
+	// This member is required.
+	SecretType types.DBSecretType `json:"secret_type,omitempty"`
+
+	// The data of the secret. The members required depend on the type of the
+	// secret.
+	//
+	// This member is required.
+	SecretData *types.DBSecretData `json:"secret_data,omitempty"`
+
+	// The description of the secret.
+	Description *string `json:"description,omitempty"`
+
+	// The purpose of the secret.
+	Purpose *string `json:"purpose,omitempty"`
+
+	// The tags of the secret.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+type AddDBSecretOutput struct {
+	// The secret added.
+	Secret *types.DBSecret
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationAddDBSecretMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPost,
+		URI:    "/api/adb/secretsmgmt/secrets",
+		Body:   restjson_serializeOpDocumentAddDBSecretInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpAddDBSecret,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "AddDBSecret"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpAddDBSecretInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Onboards a database to DPA. The database's strong account secret, bound with
+// SecretID, is used to provision ephemeral users on the database.
+func (c *Client) AddDatabase(ctx context.Context, params *AddDatabaseInput, optFns ...func(*Options)) (*AddDatabaseOutput, error) {
+	if params == nil {
+		params = &AddDatabaseInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "AddDatabase", params, optFns, c.addOperationAddDatabaseMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*AddDatabaseOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type AddDatabaseInput struct {
+	// The database to add.
+	//
+	// This member is required.
+	Database *types.Database
+
+	noSmithyDocumentSerde
+}
+
+type AddDatabaseOutput struct {
+	// The database added.
+	Database *types.Database
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationAddDatabaseMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPost,
+		URI:    "/api/adb/resources",
+		Body:   restjson_serializeOpDocumentAddDatabaseInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpAddDatabase,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "AddDatabase"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpAddDatabaseInput); err != nil {
+		return err
+	}
+	return nil
+}
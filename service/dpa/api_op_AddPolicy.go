@@ -0,0 +1,69 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Adds an access policy. The identifier of the policy is set by DPA, and returned
+// with the added policy.
+func (c *Client) AddPolicy(ctx context.Context, params *AddPolicyInput, optFns ...func(*Options)) (*AddPolicyOutput, error) {
+	if params == nil {
+		params = &AddPolicyInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "AddPolicy", params, optFns, c.addOperationAddPolicyMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*AddPolicyOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type AddPolicyInput struct {
+	// The policy to add.
+	//
+	// This member is required.
+	Policy *types.Policy
+
+	noSmithyDocumentSerde
+}
+
+type AddPolicyOutput struct {
+	// The policy added.
+	Policy *types.Policy
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationAddPolicyMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPost,
+		URI:    "/api/access-policies",
+		Body:   restjson_serializeOpDocumentAddPolicyInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpAddPolicy,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "AddPolicy"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpAddPolicyInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Adds a target set, matching the targets DPA users can connect to, and the secret used
+// to provision ephemeral users on them.
+func (c *Client) AddTargetSet(ctx context.Context, params *AddTargetSetInput, optFns ...func(*Options)) (*AddTargetSetOutput, error) {
+	if params == nil {
+		params = &AddTargetSetInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "AddTargetSet", params, optFns, c.addOperationAddTargetSetMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*AddTargetSetOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type AddTargetSetInput struct {
+	// The target set to add.
+	//
+	// This member is required.
+	TargetSet *types.TargetSet
+
+	noSmithyDocumentSerde
+}
+
+type AddTargetSetOutput struct {
+	// The target set added.
+	TargetSet *types.TargetSet
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationAddTargetSetMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPost,
+		URI:    "/api/targetsets",
+		Body:   restjson_serializeOpDocumentAddTargetSetInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpAddTargetSet,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "AddTargetSet"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpAddTargetSetInput); err != nil {
+		return err
+	}
+	return nil
+}
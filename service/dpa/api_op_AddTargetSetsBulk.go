@@ -0,0 +1,70 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Adds target sets in bulk, associated with the strong accounts they are mapped to.
+// The result of adding each target set is returned, as target sets may be added
+// partially.
+func (c *Client) AddTargetSetsBulk(ctx context.Context, params *AddTargetSetsBulkInput, optFns ...func(*Options)) (*AddTargetSetsBulkOutput, error) {
+	if params == nil {
+		params = &AddTargetSetsBulkInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "AddTargetSetsBulk", params, optFns, c.addOperationAddTargetSetsBulkMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*AddTargetSetsBulkOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type AddTargetSetsBulkInput struct {
+	// The target sets to add, mapped to their strong accounts.
+	//
+	// This member is required.
+	TargetSetsMapping []types.TargetSetMapping `json:"target_sets_mapping"`
+
+	noSmithyDocumentSerde
+}
+
+type AddTargetSetsBulkOutput struct {
+	// The result of adding each target set.
+	Results []types.TargetSetOperationResult `json:"results"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationAddTargetSetsBulkMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPost,
+		URI:    "/api/targetsets/bulk",
+		Body:   restjson_serializeOpDocumentAddTargetSetsBulkInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpAddTargetSetsBulk,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "AddTargetSetsBulk"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpAddTargetSetsBulkInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package dpa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+const testDBSecretResponse = `{"secret_id":"secret-1","secret_name":"orders-admin","secret_type":"username_password","secret_details":{"username":"admin"},"is_active":true,"tags":{"team":"orders"}}`
+
+var testDBSecret = &types.DBSecret{
+	SecretID:      cybr.String("secret-1"),
+	SecretName:    cybr.String("orders-admin"),
+	SecretType:    types.DBSecretTypeUsernamePassword,
+	SecretDetails: map[string]string{"username": "admin"},
+	IsActive:      cybr.Bool(true),
+	Tags:          map[string]string{"team": "orders"},
+}
+
+func TestClient_DBSecretOperations(t *testing.T) {
+	cases := map[string]struct {
+		Invoke       func(context.Context, *Client) (interface{}, error)
+		ExpectMethod string
+		ExpectPath   string
+		ExpectBody   string
+		ResponseBody string
+		Expect       interface{}
+	}{
+		"AddDBSecret": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.AddDBSecret(ctx, &AddDBSecretInput{
+					SecretName: cybr.String("orders-admin"),
+					SecretType: types.DBSecretTypeUsernamePassword,
+					SecretData: &types.DBSecretData{
+						Username: cybr.String("admin"),
+						Password: cybr.String("password"),
+					},
+					Tags: map[string]string{"team": "orders"},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.Secret, nil
+			},
+			ExpectMethod: http.MethodPost,
+			ExpectPath:   "/api/adb/secretsmgmt/secrets",
+			ExpectBody:   `{"secret_name":"orders-admin","secret_type":"username_password","secret_data":{"username":"admin","password":"password"},"tags":{"team":"orders"}}`,
+			ResponseBody: testDBSecretResponse,
+			Expect:       testDBSecret,
+		},
+		"ListDBSecrets": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.ListDBSecrets(ctx, nil)
+				if err != nil {
+					return nil, err
+				}
+				return out.Secrets, nil
+			},
+			ExpectMethod: http.MethodGet,
+			ExpectPath:   "/api/adb/secretsmgmt/secrets",
+			ResponseBody: `{"secrets":[` + testDBSecretResponse + `],"total_count":1}`,
+			Expect:       []types.DBSecret{*testDBSecret},
+		},
+		"GetDBSecret": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.GetDBSecret(ctx, &GetDBSecretInput{SecretID: cybr.String("secret-1")})
+				if err != nil {
+					return nil, err
+				}
+				return out.Secret, nil
+			},
+			ExpectMethod: http.MethodGet,
+			ExpectPath:   "/api/adb/secretsmgmt/secrets/secret-1",
+			ResponseBody: testDBSecretResponse,
+			Expect:       testDBSecret,
+		},
+		"UpdateDBSecret": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.UpdateDBSecret(ctx, &UpdateDBSecretInput{
+					SecretID: cybr.String("secret-1"),
+					SecretData: &types.DBSecretData{
+						Username: cybr.String("admin"),
+						Password: cybr.String("rotated"),
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.Secret, nil
+			},
+			ExpectMethod: http.MethodPatch,
+			ExpectPath:   "/api/adb/secretsmgmt/secrets/secret-1",
+			ExpectBody:   `{"secret_data":{"username":"admin","password":"rotated"}}`,
+			ResponseBody: testDBSecretResponse,
+			Expect:       testDBSecret,
+		},
+		"EnableDBSecret": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.EnableDBSecret(ctx, &EnableDBSecretInput{SecretID: cybr.String("secret-1")})
+				if err != nil {
+					return nil, err
+				}
+				return out.Secret, nil
+			},
+			ExpectMethod: http.MethodPost,
+			ExpectPath:   "/api/adb/secretsmgmt/secrets/secret-1/enable",
+			ResponseBody: testDBSecretResponse,
+			Expect:       testDBSecret,
+		},
+		"DisableDBSecret": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.DisableDBSecret(ctx, &DisableDBSecretInput{SecretID: cybr.String("secret-1")})
+				if err != nil {
+					return nil, err
+				}
+				return out.Secret.IsActive, nil
+			},
+			ExpectMethod: http.MethodPost,
+			ExpectPath:   "/api/adb/secretsmgmt/secrets/secret-1/disable",
+			ResponseBody: `{"secret_id":"secret-1","is_active":false}`,
+			Expect:       cybr.Bool(false),
+		},
+		"DeleteDBSecret": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				_, err := c.DeleteDBSecret(ctx, &DeleteDBSecretInput{SecretID: cybr.String("secret-1")})
+				return nil, err
+			},
+			ExpectMethod: http.MethodDelete,
+			ExpectPath:   "/api/adb/secretsmgmt/secrets/secret-1",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if e, a := c.ExpectMethod, r.Method; e != a {
+					t.Errorf("expect %v method, got %v", e, a)
+				}
+				if e, a := c.ExpectPath, r.URL.Path; e != a {
+					t.Errorf("expect %v path, got %v", e, a)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("expect no error reading body, got %v", err)
+				}
+				if len(c.ExpectBody) != 0 {
+					assertJSONEqual(t, c.ExpectBody, string(body))
+				} else if len(body) != 0 {
+					t.Errorf("expect no body, got %s", body)
+				}
+
+				if len(c.ResponseBody) == 0 {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				fmt.Fprint(w, c.ResponseBody)
+			})
+
+			actual, err := c.Invoke(context.Background(), client)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if c.Expect == nil {
+				return
+			}
+			if !reflect.DeepEqual(c.Expect, actual) {
+				t.Errorf("expect %#v, got %#v", c.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestClient_AddDBSecretValidation(t *testing.T) {
+	cases := map[string]struct {
+		Input        *AddDBSecretInput
+		ExpectFields []string
+	}{
+		"missing members": {
+			Input: &AddDBSecretInput{},
+			ExpectFields: []string{
+				"AddDBSecretInput.SecretName",
+				"AddDBSecretInput.SecretType",
+				"AddDBSecretInput.SecretData",
+			},
+		},
+		"username password": {
+			Input: &AddDBSecretInput{
+				SecretName: cybr.String("secret"),
+				SecretType: types.DBSecretTypeUsernamePassword,
+				SecretData: &types.DBSecretData{Username: cybr.String("admin")},
+			},
+			ExpectFields: []string{"AddDBSecretInput.SecretData.Password"},
+		},
+		"iam user": {
+			Input: &AddDBSecretInput{
+				SecretName: cybr.String("secret"),
+				SecretType: types.DBSecretTypeIAMUser,
+				SecretData: &types.DBSecretData{
+					Account:  cybr.String("123456789012"),
+					Username: cybr.String("dpa"),
+				},
+			},
+			ExpectFields: []string{
+				"AddDBSecretInput.SecretData.IAMAccessKeyID",
+				"AddDBSecretInput.SecretData.IAMSecretAccessKey",
+			},
+		},
+		"atlas access keys": {
+			Input: &AddDBSecretInput{
+				SecretName: cybr.String("secret"),
+				SecretType: types.DBSecretTypeAtlasAccessKeys,
+				SecretData: &types.DBSecretData{},
+			},
+			ExpectFields: []string{
+				"AddDBSecretInput.SecretData.PublicKey",
+				"AddDBSecretInput.SecretData.PrivateKey",
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("expect no request to be sent")
+			})
+
+			_, err := client.AddDBSecret(context.Background(), c.Input)
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+
+			var invalidParams cybr.InvalidParamsError
+			if !errors.As(err, &invalidParams) {
+				t.Fatalf("expect %T error, got %v", invalidParams, err)
+			}
+
+			var fields []string
+			for _, paramErr := range invalidParams.Errs() {
+				fields = append(fields, paramErr.(cybr.InvalidParamError).Field())
+			}
+			if e, a := c.ExpectFields, fields; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v fields, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package dpa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+const testDatabaseJSON = `{
+	"id": 42,
+	"name": "orders-db",
+	"provider_engine": "postgres",
+	"platform": "AWS",
+	"port": 5432,
+	"read_write_endpoint": "orders.cluster-abc.us-east-1.rds.amazonaws.com",
+	"read_only_endpoint": "orders.cluster-ro-abc.us-east-1.rds.amazonaws.com",
+	"region": "us-east-1",
+	"network_name": "prod-vpc",
+	"configured_auth_method_type": "local_ephemeral_user",
+	"secret_id": "secret-1",
+	"enable_certificate_validation": true,
+	"tags": {"team": "orders"}
+}`
+
+var testDatabase = &types.Database{
+	ID:                          cybr.Int64(42),
+	Name:                        cybr.String("orders-db"),
+	Engine:                      types.DatabaseEnginePostgres,
+	Platform:                    types.DatabasePlatformAWS,
+	Port:                        cybr.Int32(5432),
+	ReadWriteEndpoint:           cybr.String("orders.cluster-abc.us-east-1.rds.amazonaws.com"),
+	ReadOnlyEndpoint:            cybr.String("orders.cluster-ro-abc.us-east-1.rds.amazonaws.com"),
+	Region:                      cybr.String("us-east-1"),
+	NetworkName:                 cybr.String("prod-vpc"),
+	AuthMethod:                  types.DatabaseAuthMethodLocalEphemeralUser,
+	SecretID:                    cybr.String("secret-1"),
+	EnableCertificateValidation: cybr.Bool(true),
+	Tags:                        map[string]string{"team": "orders"},
+}
+
+func TestClient_DatabaseOperations(t *testing.T) {
+	cases := map[string]struct {
+		Invoke       func(context.Context, *Client) (interface{}, error)
+		ExpectMethod string
+		ExpectPath   string
+		ExpectBody   string
+		ResponseBody string
+		Expect       interface{}
+	}{
+		"AddDatabase": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.AddDatabase(ctx, &AddDatabaseInput{Database: testDatabase})
+				if err != nil {
+					return nil, err
+				}
+				return out.Database, nil
+			},
+			ExpectMethod: http.MethodPost,
+			ExpectPath:   "/api/adb/resources",
+			ExpectBody:   testDatabaseJSON,
+			ResponseBody: testDatabaseJSON,
+			Expect:       testDatabase,
+		},
+		"ListDatabases": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.ListDatabases(ctx, nil)
+				if err != nil {
+					return nil, err
+				}
+				return out.Items, nil
+			},
+			ExpectMethod: http.MethodGet,
+			ExpectPath:   "/api/adb/resources",
+			ResponseBody: `{"items":[` + testDatabaseJSON + `],"total_count":1}`,
+			Expect:       []types.Database{*testDatabase},
+		},
+		"GetDatabase": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.GetDatabase(ctx, &GetDatabaseInput{ID: cybr.Int64(42)})
+				if err != nil {
+					return nil, err
+				}
+				return out.Database, nil
+			},
+			ExpectMethod: http.MethodGet,
+			ExpectPath:   "/api/adb/resources/42",
+			ResponseBody: testDatabaseJSON,
+			Expect:       testDatabase,
+		},
+		"UpdateDatabase": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.UpdateDatabase(ctx, &UpdateDatabaseInput{
+					ID:       cybr.Int64(42),
+					Database: testDatabase,
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.Database, nil
+			},
+			ExpectMethod: http.MethodPut,
+			ExpectPath:   "/api/adb/resources/42",
+			ExpectBody:   testDatabaseJSON,
+			ResponseBody: testDatabaseJSON,
+			Expect:       testDatabase,
+		},
+		"DeleteDatabase": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				_, err := c.DeleteDatabase(ctx, &DeleteDatabaseInput{ID: cybr.Int64(42)})
+				return nil, err
+			},
+			ExpectMethod: http.MethodDelete,
+			ExpectPath:   "/api/adb/resources/42",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if e, a := c.ExpectMethod, r.Method; e != a {
+					t.Errorf("expect %v method, got %v", e, a)
+				}
+				if e, a := c.ExpectPath, r.URL.Path; e != a {
+					t.Errorf("expect %v path, got %v", e, a)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("expect no error reading body, got %v", err)
+				}
+				if len(c.ExpectBody) != 0 {
+					assertJSONEqual(t, c.ExpectBody, string(body))
+				} else if len(body) != 0 {
+					t.Errorf("expect no body, got %s", body)
+				}
+
+				if len(c.ResponseBody) == 0 {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				fmt.Fprint(w, c.ResponseBody)
+			})
+
+			actual, err := c.Invoke(context.Background(), client)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if c.Expect == nil {
+				return
+			}
+			if !reflect.DeepEqual(c.Expect, actual) {
+				t.Errorf("expect %#v, got %#v", c.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestClient_AddDatabaseValidation(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expect no request to be sent")
+	})
+
+	_, err := client.AddDatabase(context.Background(), &AddDatabaseInput{
+		Database: &types.Database{Name: cybr.String("orders-db")},
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	var invalidParams cybr.InvalidParamsError
+	if !errors.As(err, &invalidParams) {
+		t.Fatalf("expect %T error, got %v", invalidParams, err)
+	}
+
+	var fields []string
+	for _, paramErr := range invalidParams.Errs() {
+		fields = append(fields, paramErr.(cybr.InvalidParamError).Field())
+	}
+	expect := []string{
+		"AddDatabaseInput.Database.Engine",
+		"AddDatabaseInput.Database.ReadWriteEndpoint",
+	}
+	if e, a := expect, fields; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v fields, got %v", e, a)
+	}
+}
@@ -0,0 +1,64 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Deletes the database strong account's secret with the identifier.
+func (c *Client) DeleteDBSecret(ctx context.Context, params *DeleteDBSecretInput, optFns ...func(*Options)) (*DeleteDBSecretOutput, error) {
+	if params == nil {
+		params = &DeleteDBSecretInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "DeleteDBSecret", params, optFns, c.addOperationDeleteDBSecretMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*DeleteDBSecretOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type DeleteDBSecretInput struct {
+	// The identifier of the secret.
+	//
+	// This member is required.
+	SecretID *string `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+type DeleteDBSecretOutput struct {
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationDeleteDBSecretMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodDelete,
+		URI:       "/api/adb/secretsmgmt/secrets/{secretId}",
+		BindInput: restjson_serializeOpHttpBindingsDeleteDBSecretInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpDeleteDBSecret,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "DeleteDBSecret"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpDeleteDBSecretInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Deletes the onboarded database with the identifier.
+func (c *Client) DeleteDatabase(ctx context.Context, params *DeleteDatabaseInput, optFns ...func(*Options)) (*DeleteDatabaseOutput, error) {
+	if params == nil {
+		params = &DeleteDatabaseInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "DeleteDatabase", params, optFns, c.addOperationDeleteDatabaseMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*DeleteDatabaseOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type DeleteDatabaseInput struct {
+	// The identifier of the database.
+	//
+	// This member is required.
+	ID *int64
+
+	noSmithyDocumentSerde
+}
+
+type DeleteDatabaseOutput struct {
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationDeleteDatabaseMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodDelete,
+		URI:       "/api/adb/resources/{id}",
+		BindInput: restjson_serializeOpHttpBindingsDeleteDatabaseInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpDeleteDatabase,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "DeleteDatabase"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpDeleteDatabaseInput); err != nil {
+		return err
+	}
+	return nil
+}
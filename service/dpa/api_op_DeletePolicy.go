@@ -0,0 +1,64 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Deletes the access policy with the identifier.
+func (c *Client) DeletePolicy(ctx context.Context, params *DeletePolicyInput, optFns ...func(*Options)) (*DeletePolicyOutput, error) {
+	if params == nil {
+		params = &DeletePolicyInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "DeletePolicy", params, optFns, c.addOperationDeletePolicyMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*DeletePolicyOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type DeletePolicyInput struct {
+	// The identifier of the policy.
+	//
+	// This member is required.
+	PolicyID *string
+
+	noSmithyDocumentSerde
+}
+
+type DeletePolicyOutput struct {
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationDeletePolicyMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodDelete,
+		URI:       "/api/access-policies/{policyId}",
+		BindInput: restjson_serializeOpHttpBindingsDeletePolicyInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpDeletePolicy,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "DeletePolicy"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpDeletePolicyInput); err != nil {
+		return err
+	}
+	return nil
+}
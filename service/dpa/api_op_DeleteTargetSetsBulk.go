@@ -0,0 +1,69 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Deletes target sets in bulk. The result of deleting each target set is returned, as
+// target sets may be deleted partially.
+func (c *Client) DeleteTargetSetsBulk(ctx context.Context, params *DeleteTargetSetsBulkInput, optFns ...func(*Options)) (*DeleteTargetSetsBulkOutput, error) {
+	if params == nil {
+		params = &DeleteTargetSetsBulkInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "DeleteTargetSetsBulk", params, optFns, c.addOperationDeleteTargetSetsBulkMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*DeleteTargetSetsBulkOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type DeleteTargetSetsBulkInput struct {
+	// The names of the target sets to delete.
+	//
+	// This member is required.
+	Names []string
+
+	noSmithyDocumentSerde
+}
+
+type DeleteTargetSetsBulkOutput struct {
+	// The result of deleting each target set.
+	Results []types.TargetSetOperationResult `json:"results"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationDeleteTargetSetsBulkMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodDelete,
+		URI:    "/api/targetsets/bulk",
+		Body:   restjson_serializeOpDocumentDeleteTargetSetsBulkInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpDeleteTargetSetsBulk,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "DeleteTargetSetsBulk"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpDeleteTargetSetsBulkInput); err != nil {
+		return err
+	}
+	return nil
+}
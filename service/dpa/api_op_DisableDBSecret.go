@@ -0,0 +1,69 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Disables the database strong account's secret with the identifier. A disabled
+// secret is not used to provision ephemeral users.
+func (c *Client) DisableDBSecret(ctx context.Context, params *DisableDBSecretInput, optFns ...func(*Options)) (*DisableDBSecretOutput, error) {
+	if params == nil {
+		params = &DisableDBSecretInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "DisableDBSecret", params, optFns, c.addOperationDisableDBSecretMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*DisableDBSecretOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type DisableDBSecretInput struct {
+	// The identifier of the secret.
+	//
+	// This member is required.
+	SecretID *string `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+type DisableDBSecretOutput struct {
+	// The secret.
+	Secret *types.DBSecret
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationDisableDBSecretMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodPost,
+		URI:       "/api/adb/secretsmgmt/secrets/{secretId}/disable",
+		BindInput: restjson_serializeOpHttpBindingsDisableDBSecretInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpDisableDBSecret,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "DisableDBSecret"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpDisableDBSecretInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Enables the database strong account's secret with the identifier, so it can be
+// used to provision ephemeral users.
+func (c *Client) EnableDBSecret(ctx context.Context, params *EnableDBSecretInput, optFns ...func(*Options)) (*EnableDBSecretOutput, error) {
+	if params == nil {
+		params = &EnableDBSecretInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "EnableDBSecret", params, optFns, c.addOperationEnableDBSecretMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*EnableDBSecretOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type EnableDBSecretInput struct {
+	// The identifier of the secret.
+	//
+	// This member is required.
+	SecretID *string `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+type EnableDBSecretOutput struct {
+	// The secret.
+	Secret *types.DBSecret
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationEnableDBSecretMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodPost,
+		URI:       "/api/adb/secretsmgmt/secrets/{secretId}/enable",
+		BindInput: restjson_serializeOpHttpBindingsEnableDBSecretInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpEnableDBSecret,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "EnableDBSecret"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpEnableDBSecretInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Generates the script installing a connector on a host of the platform, and
+// registering it with the connector pool.
+func (c *Client) GenerateConnectorSetupScript(ctx context.Context, params *GenerateConnectorSetupScriptInput, optFns ...func(*Options)) (*GenerateConnectorSetupScriptOutput, error) {
+	if params == nil {
+		params = &GenerateConnectorSetupScriptInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GenerateConnectorSetupScript", params, optFns, c.addOperationGenerateConnectorSetupScriptMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GenerateConnectorSetupScriptOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GenerateConnectorSetupScriptInput struct {
+	// The platform the connector is installed on.
+	//
+	// This member is required.
+	ConnectorType types.ConnectorType `json:"connector_type,omitempty"`
+
+	// The operating system of the connector's host.
+	//
+	// This member is required.
+	ConnectorOS types.ConnectorOS `json:"connector_os,omitempty"`
+
+	// The identifier of the connector pool the connector is registered with.
+	//
+	// This member is required.
+	ConnectorPoolID *string `json:"connector_pool_id,omitempty"`
+
+	// The duration the script may be run for, in minutes.
+	ExpirationMinutes *int32 `json:"expiration_minutes,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+type GenerateConnectorSetupScriptOutput struct {
+	// The URL the script is downloaded from.
+	ScriptURL *string `json:"script_url"`
+
+	// The command downloading, and running, the script on the host.
+	BashCmd *string `json:"bash_cmd"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGenerateConnectorSetupScriptMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPost,
+		URI:    "/api/connectors/setup-script",
+		Body:   restjson_serializeOpDocumentGenerateConnectorSetupScriptInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGenerateConnectorSetupScript,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GenerateConnectorSetupScript"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpGenerateConnectorSetupScriptInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package dpa
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+func TestClient_GenerateConnectorSetupScript(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := http.MethodPost, r.Method; e != a {
+			t.Errorf("expect %v method, got %v", e, a)
+		}
+		if e, a := "/api/connectors/setup-script", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		body, _ := io.ReadAll(r.Body)
+		assertJSONEqual(t, `{"connector_type":"AWS","connector_os":"linux","connector_pool_id":"pool-1","expiration_minutes":15}`, string(body))
+
+		fmt.Fprint(w, `{"script_url":"https://example.com/setup.sh","bash_cmd":"curl -fsSL https://example.com/setup.sh | bash"}`)
+	})
+
+	out, err := client.GenerateConnectorSetupScript(context.Background(), &GenerateConnectorSetupScriptInput{
+		ConnectorType:     types.ConnectorTypeAWS,
+		ConnectorOS:       types.ConnectorOSLinux,
+		ConnectorPoolID:   cybr.String("pool-1"),
+		ExpirationMinutes: cybr.Int32(15),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "https://example.com/setup.sh", cybr.ToString(out.ScriptURL); e != a {
+		t.Errorf("expect %v script URL, got %v", e, a)
+	}
+	if e, a := "curl -fsSL https://example.com/setup.sh | bash", cybr.ToString(out.BashCmd); e != a {
+		t.Errorf("expect %v command, got %v", e, a)
+	}
+}
+
+func TestClient_GenerateConnectorSetupScriptValidation(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expect no request to be sent")
+	})
+
+	_, err := client.GenerateConnectorSetupScript(context.Background(), &GenerateConnectorSetupScriptInput{
+		ConnectorType: types.ConnectorTypeOnPremise,
+	})
+
+	var invalidParams cybr.InvalidParamsError
+	if !errors.As(err, &invalidParams) {
+		t.Fatalf("expect %T error, got %v", invalidParams, err)
+	}
+	if e, a := 2, invalidParams.Len(); e != a {
+		t.Errorf("expect %v invalid params, got %v, %v", e, a, err)
+	}
+}
+
+func TestClient_GenerateConnectorSetupScriptLogRedaction(t *testing.T) {
+	var logs bytes.Buffer
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"script_url":"https://example.com/setup.sh?token=registration-token","bash_cmd":"curl -fsSL 'https://example.com/setup.sh?token=registration-token' | bash"}`)
+	}, func(o *Options) {
+		o.ClientLogMode = cybr.LogResponseWithBody
+		o.Logger = logging.NewStandardLogger(&logs)
+	})
+
+	_, err := client.GenerateConnectorSetupScript(context.Background(), &GenerateConnectorSetupScriptInput{
+		ConnectorType:   types.ConnectorTypeAWS,
+		ConnectorOS:     types.ConnectorOSLinux,
+		ConnectorPoolID: cybr.String("pool-1"),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if strings.Contains(logs.String(), "registration-token") {
+		t.Errorf("expect setup script to be redacted, got %v", logs.String())
+	}
+	if !strings.Contains(logs.String(), "[REDACTED]") {
+		t.Errorf("expect redacted response body to be logged, got %v", logs.String())
+	}
+}
@@ -0,0 +1,68 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Returns the database strong account's secret with the identifier.
+func (c *Client) GetDBSecret(ctx context.Context, params *GetDBSecretInput, optFns ...func(*Options)) (*GetDBSecretOutput, error) {
+	if params == nil {
+		params = &GetDBSecretInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GetDBSecret", params, optFns, c.addOperationGetDBSecretMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GetDBSecretOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GetDBSecretInput struct {
+	// The identifier of the secret.
+	//
+	// This member is required.
+	SecretID *string `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+type GetDBSecretOutput struct {
+	// The secret.
+	Secret *types.DBSecret
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGetDBSecretMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodGet,
+		URI:       "/api/adb/secretsmgmt/secrets/{secretId}",
+		BindInput: restjson_serializeOpHttpBindingsGetDBSecretInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGetDBSecret,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GetDBSecret"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpGetDBSecretInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Returns the onboarded database with the identifier.
+func (c *Client) GetDatabase(ctx context.Context, params *GetDatabaseInput, optFns ...func(*Options)) (*GetDatabaseOutput, error) {
+	if params == nil {
+		params = &GetDatabaseInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GetDatabase", params, optFns, c.addOperationGetDatabaseMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GetDatabaseOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GetDatabaseInput struct {
+	// The identifier of the database.
+	//
+	// This member is required.
+	ID *int64
+
+	noSmithyDocumentSerde
+}
+
+type GetDatabaseOutput struct {
+	// The database.
+	Database *types.Database
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGetDatabaseMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodGet,
+		URI:       "/api/adb/resources/{id}",
+		BindInput: restjson_serializeOpHttpBindingsGetDatabaseInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGetDatabase,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GetDatabase"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpGetDatabaseInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Returns the access policy with the identifier.
+func (c *Client) GetPolicy(ctx context.Context, params *GetPolicyInput, optFns ...func(*Options)) (*GetPolicyOutput, error) {
+	if params == nil {
+		params = &GetPolicyInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GetPolicy", params, optFns, c.addOperationGetPolicyMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GetPolicyOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GetPolicyInput struct {
+	// The identifier of the policy.
+	//
+	// This member is required.
+	PolicyID *string
+
+	noSmithyDocumentSerde
+}
+
+type GetPolicyOutput struct {
+	// The policy.
+	Policy *types.Policy
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGetPolicyMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodGet,
+		URI:       "/api/access-policies/{policyId}",
+		BindInput: restjson_serializeOpHttpBindingsGetPolicyInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGetPolicy,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GetPolicy"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpGetPolicyInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Returns the OpenSSH encoded public key of the active SSH certificate
+// authority. Targets trust the certificates DPA connects with by adding the key
+// to the sshd TrustedUserCAKeys file.
+func (c *Client) GetSSHCAPublicKey(ctx context.Context, params *GetSSHCAPublicKeyInput, optFns ...func(*Options)) (*GetSSHCAPublicKeyOutput, error) {
+	if params == nil {
+		params = &GetSSHCAPublicKeyInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GetSSHCAPublicKey", params, optFns, c.addOperationGetSSHCAPublicKeyMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GetSSHCAPublicKeyOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GetSSHCAPublicKeyInput struct {
+	noSmithyDocumentSerde
+}
+
+type GetSSHCAPublicKeyOutput struct {
+	// The OpenSSH encoded public key.
+	PublicKey *string
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGetSSHCAPublicKeyMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodGet,
+		URI:    "/api/public-keys",
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGetSSHCAPublicKey,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GetSSHCAPublicKey"); err != nil {
+		return err
+	}
+	return nil
+}
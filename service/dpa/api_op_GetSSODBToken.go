@@ -0,0 +1,73 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Returns a short-lived token, used as the password of a database client when
+// connecting to a database through DPA. Use Expiration for the time the token
+// expires.
+func (c *Client) GetSSODBToken(ctx context.Context, params *GetSSODBTokenInput, optFns ...func(*Options)) (*GetSSODBTokenOutput, error) {
+	if params == nil {
+		params = &GetSSODBTokenInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GetSSODBToken", params, optFns, c.addOperationGetSSODBTokenMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GetSSODBTokenOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GetSSODBTokenInput struct {
+	// The engine of the database the token is used for.
+	//
+	// This member is required.
+	Engine types.DatabaseEngine `json:"engine,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+type GetSSODBTokenOutput struct {
+	// The token.
+	Token *string `json:"token"`
+
+	// The lifetime of the credentials.
+	Metadata *types.SSOCredentialsMetadata `json:"metadata"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGetSSODBTokenMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPost,
+		URI:    "/api/db/sso/token",
+		Body:   restjson_serializeOpDocumentGetSSODBTokenInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGetSSODBToken,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GetSSODBToken"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpGetSSODBTokenInput); err != nil {
+		return err
+	}
+	return nil
+}
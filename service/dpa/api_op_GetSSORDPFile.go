@@ -0,0 +1,80 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Returns an RDP file for connecting to a target with a short-lived token,
+// without authenticating again while the MFA of the caller is cached. Use
+// WriteFile to write the RDP file to disk, and Expiration for the time the
+// token expires.
+func (c *Client) GetSSORDPFile(ctx context.Context, params *GetSSORDPFileInput, optFns ...func(*Options)) (*GetSSORDPFileOutput, error) {
+	if params == nil {
+		params = &GetSSORDPFileInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GetSSORDPFile", params, optFns, c.addOperationGetSSORDPFileMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GetSSORDPFileOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GetSSORDPFileInput struct {
+	// The FQDN or IP address of the target.
+	//
+	// This member is required.
+	TargetAddress *string `json:"target_address,omitempty"`
+
+	// The domain of the target.
+	TargetDomain *string `json:"target_domain,omitempty"`
+
+	// Whether the connection is made with elevated privileges.
+	ElevatedPrivileges *bool `json:"elevated_privileges,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+type GetSSORDPFileOutput struct {
+	// The content of the RDP file.
+	RDPFile *string `json:"rdp_file"`
+
+	// The lifetime of the credentials.
+	Metadata *types.SSOCredentialsMetadata `json:"metadata"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGetSSORDPFileMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPost,
+		URI:    "/api/rdp/sso/file",
+		Body:   restjson_serializeOpDocumentGetSSORDPFileInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGetSSORDPFile,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GetSSORDPFile"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpGetSSORDPFileInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Returns a short-lived SSH private key, used to connect to SSH targets without
+// authenticating again while the MFA of the caller is cached. Use WriteFile to
+// write the key to disk, and Expiration for the time the key expires.
+func (c *Client) GetSSOSSHKey(ctx context.Context, params *GetSSOSSHKeyInput, optFns ...func(*Options)) (*GetSSOSSHKeyOutput, error) {
+	if params == nil {
+		params = &GetSSOSSHKeyInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GetSSOSSHKey", params, optFns, c.addOperationGetSSOSSHKeyMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GetSSOSSHKeyOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GetSSOSSHKeyInput struct {
+	// The format of the private key. Defaults to the OpenSSH format if not set.
+	Format types.SSHKeyFormat
+
+	noSmithyDocumentSerde
+}
+
+type GetSSOSSHKeyOutput struct {
+	// The private key.
+	PrivateKey *string `json:"private_key"`
+
+	// The format of the private key.
+	Format types.SSHKeyFormat `json:"format"`
+
+	// The lifetime of the credentials.
+	Metadata *types.SSOCredentialsMetadata `json:"metadata"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGetSSOSSHKeyMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodGet,
+		URI:       "/api/ssh/sso/key",
+		BindInput: restjson_serializeOpHttpBindingsGetSSOSSHKeyInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGetSSOSSHKey,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GetSSOSSHKey"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Returns the target set with the name.
+func (c *Client) GetTargetSet(ctx context.Context, params *GetTargetSetInput, optFns ...func(*Options)) (*GetTargetSetOutput, error) {
+	if params == nil {
+		params = &GetTargetSetInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GetTargetSet", params, optFns, c.addOperationGetTargetSetMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GetTargetSetOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GetTargetSetInput struct {
+	// The name of the target set.
+	//
+	// This member is required.
+	Name *string
+
+	noSmithyDocumentSerde
+}
+
+type GetTargetSetOutput struct {
+	// The target set.
+	TargetSet *types.TargetSet
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGetTargetSetMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodGet,
+		URI:       "/api/targetsets/{name}",
+		BindInput: restjson_serializeOpHttpBindingsGetTargetSetInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGetTargetSet,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GetTargetSet"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpGetTargetSetInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Returns the DPA settings of the tenant.
+func (c *Client) GetTenantSettings(ctx context.Context, params *GetTenantSettingsInput, optFns ...func(*Options)) (*GetTenantSettingsOutput, error) {
+	if params == nil {
+		params = &GetTenantSettingsInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GetTenantSettings", params, optFns, c.addOperationGetTenantSettingsMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GetTenantSettingsOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GetTenantSettingsInput struct {
+	noSmithyDocumentSerde
+}
+
+type GetTenantSettingsOutput struct {
+	// The settings.
+	Settings *types.TenantSettings
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGetTenantSettingsMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodGet,
+		URI:    "/api/settings",
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGetTenantSettings,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GetTenantSettings"); err != nil {
+		return err
+	}
+	return nil
+}
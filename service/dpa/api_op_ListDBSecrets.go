@@ -0,0 +1,62 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Lists the database strong accounts' secrets of the tenant.
+func (c *Client) ListDBSecrets(ctx context.Context, params *ListDBSecretsInput, optFns ...func(*Options)) (*ListDBSecretsOutput, error) {
+	if params == nil {
+		params = &ListDBSecretsInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "ListDBSecrets", params, optFns, c.addOperationListDBSecretsMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*ListDBSecretsOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type ListDBSecretsInput struct {
+	noSmithyDocumentSerde
+}
+
+type ListDBSecretsOutput struct {
+	// The secrets.
+	Secrets []types.DBSecret `json:"secrets"`
+
+	// The total number of secrets.
+	TotalCount *int32 `json:"total_count"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationListDBSecretsMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodGet,
+		URI:    "/api/adb/secretsmgmt/secrets",
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpListDBSecrets,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "ListDBSecrets"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Lists the databases onboarded to DPA.
+func (c *Client) ListDatabases(ctx context.Context, params *ListDatabasesInput, optFns ...func(*Options)) (*ListDatabasesOutput, error) {
+	if params == nil {
+		params = &ListDatabasesInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "ListDatabases", params, optFns, c.addOperationListDatabasesMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*ListDatabasesOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type ListDatabasesInput struct {
+	noSmithyDocumentSerde
+}
+
+type ListDatabasesOutput struct {
+	// The databases.
+	Items []types.Database `json:"items"`
+
+	// The total number of databases.
+	TotalCount *int32 `json:"total_count"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationListDatabasesMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodGet,
+		URI:    "/api/adb/resources",
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpListDatabases,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "ListDatabases"); err != nil {
+		return err
+	}
+	return nil
+}
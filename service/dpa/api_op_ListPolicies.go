@@ -0,0 +1,164 @@
+package dpa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Lists the VM and DB access policies of the tenant. The policies are returned in
+// pages, use the ListPoliciesPaginator to iterate over all pages.
+func (c *Client) ListPolicies(ctx context.Context, params *ListPoliciesInput, optFns ...func(*Options)) (*ListPoliciesOutput, error) {
+	if params == nil {
+		params = &ListPoliciesInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "ListPolicies", params, optFns, c.addOperationListPoliciesMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*ListPoliciesOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type ListPoliciesInput struct {
+	// The maximum number of policies to return.
+	Limit *int32
+
+	// The token of the page to return, returned by a previous call.
+	NextToken *string
+
+	noSmithyDocumentSerde
+}
+
+type ListPoliciesOutput struct {
+	// The policies of the page.
+	Items []types.Policy `json:"items"`
+
+	// The token of the next page, or nil if there are no more pages.
+	NextToken *string `json:"nextToken"`
+
+	// The total number of policies.
+	TotalCount *int32 `json:"totalCount"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationListPoliciesMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodGet,
+		URI:       "/api/access-policies",
+		BindInput: restjson_serializeOpHttpBindingsListPoliciesInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpListPolicies,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "ListPolicies"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListPoliciesAPIClient is a client that implements the ListPolicies operation.
+type ListPoliciesAPIClient interface {
+	ListPolicies(context.Context, *ListPoliciesInput, ...func(*Options)) (*ListPoliciesOutput, error)
+}
+
+var _ ListPoliciesAPIClient = (*Client)(nil)
+
+// ListPoliciesPaginatorOptions is the paginator options for ListPolicies
+type ListPoliciesPaginatorOptions struct {
+	// The maximum number of policies to return in a page.
+	Limit int32
+
+	// Set to true if pagination should stop if the service returns a
+	// pagination token that matches the most recent token provided to the
+	// service.
+	StopOnDuplicateToken bool
+}
+
+// ListPoliciesPaginator is a paginator for ListPolicies
+type ListPoliciesPaginator struct {
+	options   ListPoliciesPaginatorOptions
+	client    ListPoliciesAPIClient
+	params    *ListPoliciesInput
+	nextToken *string
+	firstPage bool
+}
+
+// NewListPoliciesPaginator returns a new ListPoliciesPaginator
+func NewListPoliciesPaginator(client ListPoliciesAPIClient, params *ListPoliciesInput, optFns ...func(*ListPoliciesPaginatorOptions)) *ListPoliciesPaginator {
+	if params == nil {
+		params = &ListPoliciesInput{}
+	}
+
+	options := ListPoliciesPaginatorOptions{}
+	if params.Limit != nil {
+		options.Limit = *params.Limit
+	}
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	return &ListPoliciesPaginator{
+		options:   options,
+		client:    client,
+		params:    params,
+		firstPage: true,
+		nextToken: params.NextToken,
+	}
+}
+
+// HasMorePages returns a boolean indicating whether more pages are available
+func (p *ListPoliciesPaginator) HasMorePages() bool {
+	return p.firstPage || (p.nextToken != nil && len(*p.nextToken) != 0)
+}
+
+// NextPage retrieves the next ListPolicies page.
+func (p *ListPoliciesPaginator) NextPage(ctx context.Context, optFns ...func(*Options)) (*ListPoliciesOutput, error) {
+	if !p.HasMorePages() {
+		return nil, fmt.Errorf("no more pages available")
+	}
+
+	params := *p.params
+	params.NextToken = p.nextToken
+
+	var limit *int32
+	if p.options.Limit > 0 {
+		limit = &p.options.Limit
+	}
+	params.Limit = limit
+
+	result, err := p.client.ListPolicies(ctx, &params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	p.firstPage = false
+
+	prevToken := p.nextToken
+	p.nextToken = result.NextToken
+
+	if p.options.StopOnDuplicateToken &&
+		prevToken != nil &&
+		p.nextToken != nil &&
+		*prevToken == *p.nextToken {
+		p.nextToken = nil
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Lists the versions of the SSH certificate authority.
+func (c *Client) ListSSHCAVersions(ctx context.Context, params *ListSSHCAVersionsInput, optFns ...func(*Options)) (*ListSSHCAVersionsOutput, error) {
+	if params == nil {
+		params = &ListSSHCAVersionsInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "ListSSHCAVersions", params, optFns, c.addOperationListSSHCAVersionsMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*ListSSHCAVersionsOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type ListSSHCAVersionsInput struct {
+	noSmithyDocumentSerde
+}
+
+type ListSSHCAVersionsOutput struct {
+	// The versions of the certificate authority.
+	Versions []types.SSHCAVersion `json:"versions"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationListSSHCAVersionsMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodGet,
+		URI:    "/api/ssh-ca/versions",
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpListSSHCAVersions,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "ListSSHCAVersions"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Lists the target sets of the tenant.
+func (c *Client) ListTargetSets(ctx context.Context, params *ListTargetSetsInput, optFns ...func(*Options)) (*ListTargetSetsOutput, error) {
+	if params == nil {
+		params = &ListTargetSetsInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "ListTargetSets", params, optFns, c.addOperationListTargetSetsMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*ListTargetSetsOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type ListTargetSetsInput struct {
+	noSmithyDocumentSerde
+}
+
+type ListTargetSetsOutput struct {
+	// The target sets of the tenant.
+	TargetSets []types.TargetSet `json:"target_sets"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationListTargetSetsMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodGet,
+		URI:    "/api/targetsets",
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpListTargetSets,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "ListTargetSets"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+package dpa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+func loadPolicyFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", "policies", name))
+	if err != nil {
+		t.Fatalf("expect no error reading fixture, got %v", err)
+	}
+	return b
+}
+
+func TestPolicy_RoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		Fixture string
+		Assert  func(*testing.T, types.Policy)
+	}{
+		"vm policy": {
+			Fixture: "vm_policy.json",
+			Assert: func(t *testing.T, p types.Policy) {
+				if e, a := types.PolicyStatusEnabled, p.Status; e != a {
+					t.Errorf("expect %v status, got %v", e, a)
+				}
+				aws := p.ProvidersData.AWS
+				if e, a := []string{"us-east-1", "eu-west-1"}, aws.Regions; !reflect.DeepEqual(e, a) {
+					t.Errorf("expect %v regions, got %v", e, a)
+				}
+				if e, a := []string{"vpc-0a1b2c3d"}, aws.VPCIDs; !reflect.DeepEqual(e, a) {
+					t.Errorf("expect %v VPC IDs, got %v", e, a)
+				}
+				if e, a := "env", cybr.ToString(aws.Tags[0].Key); e != a {
+					t.Errorf("expect %v tag key, got %v", e, a)
+				}
+				if e, a := types.RuleOperatorWildcard, p.ProvidersData.FQDNIP.FQDNRules[0].Operator; e != a {
+					t.Errorf("expect %v operator, got %v", e, a)
+				}
+
+				rule := p.UserAccessRules[0]
+				if e, a := "jane@example.com", cybr.ToString(rule.UserData.Users[0].Name); e != a {
+					t.Errorf("expect %v user, got %v", e, a)
+				}
+				info := rule.ConnectionInformation
+				if e, a := int32(3), cybr.ToInt32(info.MaxSessionDuration); e != a {
+					t.Errorf("expect %v max session duration, got %v", e, a)
+				}
+				if e, a := int32(10), cybr.ToInt32(info.IdleTime); e != a {
+					t.Errorf("expect %v idle time, got %v", e, a)
+				}
+				if e, a := "America/New_York", cybr.ToString(info.TimeZone); e != a {
+					t.Errorf("expect %v time zone, got %v", e, a)
+				}
+				if e, a := types.DayOfWeekFriday, info.DaysOfWeek[4]; e != a {
+					t.Errorf("expect %v day, got %v", e, a)
+				}
+				if e, a := "ec2-user", cybr.ToString(info.ConnectAs.AWS.SSH); e != a {
+					t.Errorf("expect %v SSH user, got %v", e, a)
+				}
+			},
+		},
+		"db policy": {
+			Fixture: "db_policy.json",
+			Assert: func(t *testing.T, p types.Policy) {
+				if e, a := []string{"orders-db", "billing-db"}, p.ProvidersData.Postgres.Resources; !reflect.DeepEqual(e, a) {
+					t.Errorf("expect %v resources, got %v", e, a)
+				}
+				if p.ProvidersData.AWS != nil {
+					t.Errorf("expect no AWS providers data, got %v", p.ProvidersData.AWS)
+				}
+
+				connectAs := p.UserAccessRules[0].ConnectionInformation.ConnectAs
+				if e, a := []string{"rds_superuser"}, connectAs.DBAuth[0].Roles; !reflect.DeepEqual(e, a) {
+					t.Errorf("expect %v roles, got %v", e, a)
+				}
+				if e, a := "reporting-db", cybr.ToString(connectAs.LDAPAuth[0].ApplyTo[0].Name); e != a {
+					t.Errorf("expect %v database, got %v", e, a)
+				}
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			fixture := loadPolicyFixture(t, c.Fixture)
+
+			var policy types.Policy
+			if err := json.Unmarshal(fixture, &policy); err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			c.Assert(t, policy)
+
+			b, err := json.Marshal(policy)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			assertJSONEqual(t, string(fixture), string(b))
+		})
+	}
+}
+
+func TestClient_PolicyOperations(t *testing.T) {
+	fixture := loadPolicyFixture(t, "vm_policy.json")
+
+	var policy types.Policy
+	if err := json.Unmarshal(fixture, &policy); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	cases := map[string]struct {
+		Invoke       func(context.Context, *Client) (interface{}, error)
+		ExpectMethod string
+		ExpectPath   string
+		ExpectBody   string
+		ResponseBody string
+		Expect       interface{}
+	}{
+		"GetPolicy": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.GetPolicy(ctx, &GetPolicyInput{PolicyID: policy.PolicyID})
+				if err != nil {
+					return nil, err
+				}
+				return out.Policy, nil
+			},
+			ExpectMethod: http.MethodGet,
+			ExpectPath:   "/api/access-policies/" + *policy.PolicyID,
+			ResponseBody: string(fixture),
+			Expect:       &policy,
+		},
+		"AddPolicy": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.AddPolicy(ctx, &AddPolicyInput{Policy: &policy})
+				if err != nil {
+					return nil, err
+				}
+				return out.Policy, nil
+			},
+			ExpectMethod: http.MethodPost,
+			ExpectPath:   "/api/access-policies",
+			ExpectBody:   string(fixture),
+			ResponseBody: string(fixture),
+			Expect:       &policy,
+		},
+		"UpdatePolicy": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.UpdatePolicy(ctx, &UpdatePolicyInput{
+					PolicyID: policy.PolicyID,
+					Policy:   &policy,
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.Policy, nil
+			},
+			ExpectMethod: http.MethodPut,
+			ExpectPath:   "/api/access-policies/" + *policy.PolicyID,
+			ExpectBody:   string(fixture),
+			ResponseBody: string(fixture),
+			Expect:       &policy,
+		},
+		"DeletePolicy": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				_, err := c.DeletePolicy(ctx, &DeletePolicyInput{PolicyID: policy.PolicyID})
+				return nil, err
+			},
+			ExpectMethod: http.MethodDelete,
+			ExpectPath:   "/api/access-policies/" + *policy.PolicyID,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if e, a := c.ExpectMethod, r.Method; e != a {
+					t.Errorf("expect %v method, got %v", e, a)
+				}
+				if e, a := c.ExpectPath, r.URL.Path; e != a {
+					t.Errorf("expect %v path, got %v", e, a)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("expect no error reading body, got %v", err)
+				}
+				if len(c.ExpectBody) != 0 {
+					assertJSONEqual(t, c.ExpectBody, string(body))
+				} else if len(body) != 0 {
+					t.Errorf("expect no body, got %s", body)
+				}
+
+				if len(c.ResponseBody) == 0 {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				fmt.Fprint(w, c.ResponseBody)
+			})
+
+			actual, err := c.Invoke(context.Background(), client)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if c.Expect == nil {
+				return
+			}
+			if !reflect.DeepEqual(c.Expect, actual) {
+				t.Errorf("expect %#v, got %#v", c.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestClient_PolicyOperationsValidation(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expect no request to be sent")
+	})
+
+	_, err := client.AddPolicy(context.Background(), &AddPolicyInput{
+		Policy: &types.Policy{
+			PolicyName:      cybr.String("example"),
+			UserAccessRules: []types.UserAccessRule{{RuleName: cybr.String("rule")}},
+		},
+	})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	var invalidParams cybr.InvalidParamsError
+	if !errors.As(err, &invalidParams) {
+		t.Fatalf("expect %T error, got %v", invalidParams, err)
+	}
+
+	var fields []string
+	for _, paramErr := range invalidParams.Errs() {
+		fields = append(fields, paramErr.(cybr.InvalidParamError).Field())
+	}
+	expect := []string{
+		"AddPolicyInput.Policy.ProvidersData",
+		"AddPolicyInput.Policy.UserAccessRules[0].UserData",
+		"AddPolicyInput.Policy.UserAccessRules[0].ConnectionInformation",
+	}
+	if e, a := expect, fields; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v fields, got %v", e, a)
+	}
+}
+
+func TestListPoliciesPaginator(t *testing.T) {
+	pages := map[string]string{
+		"":      `{"items":[{"policyId":"1","policyName":"one"},{"policyId":"2","policyName":"two"}],"nextToken":"page2","totalCount":3}`,
+		"page2": `{"items":[{"policyId":"3","policyName":"three"}],"totalCount":3}`,
+	}
+
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if e, a := "/api/access-policies", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		if e, a := "2", r.URL.Query().Get("limit"); e != a {
+			t.Errorf("expect %v limit, got %v", e, a)
+		}
+
+		page, ok := pages[r.URL.Query().Get("nextToken")]
+		if !ok {
+			t.Fatalf("unexpected next token %v", r.URL.Query().Get("nextToken"))
+		}
+		fmt.Fprint(w, page)
+	})
+
+	paginator := NewListPoliciesPaginator(client, nil, func(o *ListPoliciesPaginatorOptions) {
+		o.Limit = 2
+	})
+
+	var ids []string
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		for _, p := range page.Items {
+			ids = append(ids, cybr.ToString(p.PolicyID))
+		}
+	}
+
+	if e, a := []string{"1", "2", "3"}, ids; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v policies, got %v", e, a)
+	}
+	if e, a := 2, requests; e != a {
+		t.Errorf("expect %v requests, got %v", e, a)
+	}
+	if _, err := paginator.NextPage(context.Background()); err == nil {
+		t.Errorf("expect error for no more pages, got none")
+	}
+}
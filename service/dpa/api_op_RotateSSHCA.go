@@ -0,0 +1,61 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Rotates the SSH certificate authority. A new version of the certificate
+// authority is created pending, so its public key can be trusted by targets
+// before it is activated.
+func (c *Client) RotateSSHCA(ctx context.Context, params *RotateSSHCAInput, optFns ...func(*Options)) (*RotateSSHCAOutput, error) {
+	if params == nil {
+		params = &RotateSSHCAInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "RotateSSHCA", params, optFns, c.addOperationRotateSSHCAMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*RotateSSHCAOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type RotateSSHCAInput struct {
+	noSmithyDocumentSerde
+}
+
+type RotateSSHCAOutput struct {
+	// The version of the certificate authority created.
+	Version *types.SSHCAVersion
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationRotateSSHCAMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPost,
+		URI:    "/api/ssh-ca/rotate",
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpRotateSSHCA,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "RotateSSHCA"); err != nil {
+		return err
+	}
+	return nil
+}
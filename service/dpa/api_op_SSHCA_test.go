@@ -0,0 +1,86 @@
+package dpa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+func TestClient_GetSSHCAPublicKey(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := http.MethodGet, r.Method; e != a {
+			t.Errorf("expect %v method, got %v", e, a)
+		}
+		if e, a := "/api/public-keys", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "ssh-rsa AAAAB3NzaC1yc2E example\n")
+	})
+
+	out, err := client.GetSSHCAPublicKey(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "ssh-rsa AAAAB3NzaC1yc2E example", cybr.ToString(out.PublicKey); e != a {
+		t.Errorf("expect %q public key, got %q", e, a)
+	}
+}
+
+func TestClient_RotateSSHCA(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := http.MethodPost, r.Method; e != a {
+			t.Errorf("expect %v method, got %v", e, a)
+		}
+		if e, a := "/api/ssh-ca/rotate", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+
+		fmt.Fprint(w, `{"version":2,"public_key":"ssh-rsa AAAA2","status":"pending","created_at":"2024-05-01T10:00:00Z"}`)
+	})
+
+	out, err := client.RotateSSHCA(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := &types.SSHCAVersion{
+		Version:   cybr.Int32(2),
+		PublicKey: cybr.String("ssh-rsa AAAA2"),
+		Status:    types.SSHCAStatusPending,
+		CreatedAt: cybr.Time(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)),
+	}
+	if !reflect.DeepEqual(expect, out.Version) {
+		t.Errorf("expect %#v, got %#v", expect, out.Version)
+	}
+}
+
+func TestClient_ListSSHCAVersions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "/api/ssh-ca/versions", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+
+		fmt.Fprint(w, `{"versions":[{"version":1,"status":"inactive"},{"version":2,"status":"active"}]}`)
+	})
+
+	out, err := client.ListSSHCAVersions(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var statuses []types.SSHCAStatus
+	for _, v := range out.Versions {
+		statuses = append(statuses, v.Status)
+	}
+	if e, a := []types.SSHCAStatus{types.SSHCAStatusInactive, types.SSHCAStatusActive}, statuses; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v statuses, got %v", e, a)
+	}
+}
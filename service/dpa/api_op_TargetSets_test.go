@@ -0,0 +1,291 @@
+package dpa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+func TestClient_TargetSetOperations(t *testing.T) {
+	cases := map[string]struct {
+		Invoke       func(context.Context, *Client) (interface{}, error)
+		ExpectMethod string
+		ExpectPath   string
+		ExpectBody   string
+		ResponseBody string
+		Expect       interface{}
+	}{
+		"ListTargetSets": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.ListTargetSets(ctx, nil)
+				if err != nil {
+					return nil, err
+				}
+				return out.TargetSets, nil
+			},
+			ExpectMethod: http.MethodGet,
+			ExpectPath:   "/api/targetsets",
+			ResponseBody: `{"target_sets":[{"name":"example.com","type":"Domain","secret_type":"ProvisionerUser","secret_id":"abc"}]}`,
+			Expect: []types.TargetSet{{
+				Name:       cybr.String("example.com"),
+				Type:       types.TargetSetTypeDomain,
+				SecretType: types.SecretTypeProvisionerUser,
+				SecretID:   cybr.String("abc"),
+			}},
+		},
+		"AddTargetSet": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.AddTargetSet(ctx, &AddTargetSetInput{
+					TargetSet: &types.TargetSet{
+						Name: cybr.String("example.com"),
+						Type: types.TargetSetTypeDomain,
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.TargetSet, nil
+			},
+			ExpectMethod: http.MethodPost,
+			ExpectPath:   "/api/targetsets",
+			ExpectBody:   `{"name":"example.com","type":"Domain"}`,
+			ResponseBody: `{"name":"example.com","type":"Domain"}`,
+			Expect: &types.TargetSet{
+				Name: cybr.String("example.com"),
+				Type: types.TargetSetTypeDomain,
+			},
+		},
+		"GetTargetSet": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.GetTargetSet(ctx, &GetTargetSetInput{
+					Name: cybr.String("example.com"),
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.TargetSet, nil
+			},
+			ExpectMethod: http.MethodGet,
+			ExpectPath:   "/api/targetsets/example.com",
+			ResponseBody: `{"name":"example.com","type":"Suffix","enable_certificate_validation":true}`,
+			Expect: &types.TargetSet{
+				Name:                        cybr.String("example.com"),
+				Type:                        types.TargetSetTypeSuffix,
+				EnableCertificateValidation: cybr.Bool(true),
+			},
+		},
+		"UpdateTargetSet": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.UpdateTargetSet(ctx, &UpdateTargetSetInput{
+					Name: cybr.String("example.com"),
+					TargetSet: &types.TargetSet{
+						Name:        cybr.String("example.com"),
+						Description: cybr.String("updated"),
+						Type:        types.TargetSetTypeDomain,
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.TargetSet, nil
+			},
+			ExpectMethod: http.MethodPut,
+			ExpectPath:   "/api/targetsets/example.com",
+			ExpectBody:   `{"name":"example.com","description":"updated","type":"Domain"}`,
+			ResponseBody: `{"name":"example.com","description":"updated","type":"Domain"}`,
+			Expect: &types.TargetSet{
+				Name:        cybr.String("example.com"),
+				Description: cybr.String("updated"),
+				Type:        types.TargetSetTypeDomain,
+			},
+		},
+		"AddTargetSetsBulk": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.AddTargetSetsBulk(ctx, &AddTargetSetsBulkInput{
+					TargetSetsMapping: []types.TargetSetMapping{{
+						StrongAccountID: cybr.String("account-id"),
+						TargetSets: []types.TargetSet{{
+							Name: cybr.String("example.com"),
+							Type: types.TargetSetTypeDomain,
+						}},
+					}},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.Results, nil
+			},
+			ExpectMethod: http.MethodPost,
+			ExpectPath:   "/api/targetsets/bulk",
+			ExpectBody:   `{"target_sets_mapping":[{"strong_account_id":"account-id","target_sets":[{"name":"example.com","type":"Domain"}]}]}`,
+			ResponseBody: `{"results":[{"strong_account_id":"account-id","target_set_name":"example.com","success":true}]}`,
+			Expect: []types.TargetSetOperationResult{{
+				StrongAccountID: cybr.String("account-id"),
+				TargetSetName:   cybr.String("example.com"),
+				Success:         cybr.Bool(true),
+			}},
+		},
+		"DeleteTargetSetsBulk": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.DeleteTargetSetsBulk(ctx, &DeleteTargetSetsBulkInput{
+					Names: []string{"example.com", "example.org"},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.Results, nil
+			},
+			ExpectMethod: http.MethodDelete,
+			ExpectPath:   "/api/targetsets/bulk",
+			ExpectBody:   `["example.com","example.org"]`,
+			ResponseBody: `{"results":[{"target_set_name":"example.com","success":true},{"target_set_name":"example.org","success":false}]}`,
+			Expect: []types.TargetSetOperationResult{
+				{TargetSetName: cybr.String("example.com"), Success: cybr.Bool(true)},
+				{TargetSetName: cybr.String("example.org"), Success: cybr.Bool(false)},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if e, a := c.ExpectMethod, r.Method; e != a {
+					t.Errorf("expect %v method, got %v", e, a)
+				}
+				if e, a := c.ExpectPath, r.URL.Path; e != a {
+					t.Errorf("expect %v path, got %v", e, a)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("expect no error reading body, got %v", err)
+				}
+				if len(c.ExpectBody) != 0 {
+					if e, a := "application/json", r.Header.Get("Content-Type"); e != a {
+						t.Errorf("expect %v content type, got %v", e, a)
+					}
+					assertJSONEqual(t, c.ExpectBody, string(body))
+				} else if len(body) != 0 {
+					t.Errorf("expect no body, got %s", body)
+				}
+
+				fmt.Fprint(w, c.ResponseBody)
+			})
+
+			actual, err := c.Invoke(context.Background(), client)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if !reflect.DeepEqual(c.Expect, actual) {
+				t.Errorf("expect %#v, got %#v", c.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestClient_TargetSetOperationsValidation(t *testing.T) {
+	cases := map[string]struct {
+		Invoke       func(context.Context, *Client) error
+		ExpectFields []string
+	}{
+		"AddTargetSet missing target set": {
+			Invoke: func(ctx context.Context, c *Client) error {
+				_, err := c.AddTargetSet(ctx, &AddTargetSetInput{})
+				return err
+			},
+			ExpectFields: []string{"AddTargetSetInput.TargetSet"},
+		},
+		"AddTargetSet missing nested members": {
+			Invoke: func(ctx context.Context, c *Client) error {
+				_, err := c.AddTargetSet(ctx, &AddTargetSetInput{TargetSet: &types.TargetSet{}})
+				return err
+			},
+			ExpectFields: []string{"AddTargetSetInput.TargetSet.Name", "AddTargetSetInput.TargetSet.Type"},
+		},
+		"GetTargetSet missing name": {
+			Invoke: func(ctx context.Context, c *Client) error {
+				_, err := c.GetTargetSet(ctx, &GetTargetSetInput{})
+				return err
+			},
+			ExpectFields: []string{"GetTargetSetInput.Name"},
+		},
+		"UpdateTargetSet missing members": {
+			Invoke: func(ctx context.Context, c *Client) error {
+				_, err := c.UpdateTargetSet(ctx, nil)
+				return err
+			},
+			ExpectFields: []string{"UpdateTargetSetInput.Name", "UpdateTargetSetInput.TargetSet"},
+		},
+		"AddTargetSetsBulk missing nested members": {
+			Invoke: func(ctx context.Context, c *Client) error {
+				_, err := c.AddTargetSetsBulk(ctx, &AddTargetSetsBulkInput{
+					TargetSetsMapping: []types.TargetSetMapping{{
+						TargetSets: []types.TargetSet{{Name: cybr.String("example.com")}},
+					}},
+				})
+				return err
+			},
+			ExpectFields: []string{
+				"AddTargetSetsBulkInput.TargetSetsMapping[0].StrongAccountID",
+				"AddTargetSetsBulkInput.TargetSetsMapping[0].TargetSets[0].Type",
+			},
+		},
+		"DeleteTargetSetsBulk missing names": {
+			Invoke: func(ctx context.Context, c *Client) error {
+				_, err := c.DeleteTargetSetsBulk(ctx, &DeleteTargetSetsBulkInput{})
+				return err
+			},
+			ExpectFields: []string{"DeleteTargetSetsBulkInput.Names"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("expect no request to be sent")
+			})
+
+			err := c.Invoke(context.Background(), client)
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+
+			var invalidParams cybr.InvalidParamsError
+			if !errors.As(err, &invalidParams) {
+				t.Fatalf("expect %T error, got %v", invalidParams, err)
+			}
+
+			var fields []string
+			for _, paramErr := range invalidParams.Errs() {
+				fields = append(fields, paramErr.(cybr.InvalidParamError).Field())
+			}
+			if e, a := c.ExpectFields, fields; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v fields, got %v", e, a)
+			}
+		})
+	}
+}
+
+func assertJSONEqual(t *testing.T, expect, actual string) {
+	t.Helper()
+
+	var e, a interface{}
+	if err := json.Unmarshal([]byte(expect), &e); err != nil {
+		t.Fatalf("expect valid JSON %v, got %v", expect, err)
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(actual)), &a); err != nil {
+		t.Fatalf("expect valid JSON body, got %v, %v", actual, err)
+	}
+	if !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v JSON, got %v", expect, actual)
+	}
+}
@@ -0,0 +1,87 @@
+package dpa
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+const testTenantSettingsJSON = `{
+	"mfa_caching": {"is_mfa_caching_enabled": true, "key_expiration_time_sec": 14400, "client_ip_enforced": false},
+	"ssh_command_audit": {"is_command_parsing_for_audit_enabled": true, "shell_prompt_for_audit": "(.*)[>#\\$]$"},
+	"certificate_validation": {"enabled": true},
+	"rdp_recording": {"enabled": false}
+}`
+
+func TestClient_GetTenantSettings(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := http.MethodGet, r.Method; e != a {
+			t.Errorf("expect %v method, got %v", e, a)
+		}
+		if e, a := "/api/settings", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+
+		fmt.Fprint(w, testTenantSettingsJSON)
+	})
+
+	out, err := client.GetTenantSettings(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	expect := &types.TenantSettings{
+		MFACaching: &types.MFACachingSettings{
+			Enabled:           cybr.Bool(true),
+			KeyExpirationTime: cybr.Int32(14400),
+			ClientIPEnforced:  cybr.Bool(false),
+		},
+		SSHCommandAudit: &types.SSHCommandAuditSettings{
+			Enabled:     cybr.Bool(true),
+			ShellPrompt: cybr.String(`(.*)[>#\$]$`),
+		},
+		CertificateValidation: &types.CertificateValidationSettings{Enabled: cybr.Bool(true)},
+		RDPRecording:          &types.RDPRecordingSettings{Enabled: cybr.Bool(false)},
+	}
+	if !reflect.DeepEqual(expect, out.Settings) {
+		t.Errorf("expect %#v, got %#v", expect, out.Settings)
+	}
+}
+
+func TestClient_UpdateTenantSettings(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := http.MethodPatch, r.Method; e != a {
+			t.Errorf("expect %v method, got %v", e, a)
+		}
+		if e, a := "/api/settings", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		// Only the settings set are sent.
+		body, _ := io.ReadAll(r.Body)
+		assertJSONEqual(t, `{"rdp_recording":{"enabled":true}}`, string(body))
+
+		fmt.Fprint(w, `{"rdp_recording":{"enabled":true}}`)
+	})
+
+	out, err := client.UpdateTenantSettings(context.Background(), &UpdateTenantSettingsInput{
+		Settings: &types.TenantSettings{
+			RDPRecording: &types.RDPRecordingSettings{Enabled: cybr.Bool(true)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := true, cybr.ToBool(out.Settings.RDPRecording.Enabled); e != a {
+		t.Errorf("expect %v RDP recording, got %v", e, a)
+	}
+
+	if _, err := client.UpdateTenantSettings(context.Background(), nil); err == nil {
+		t.Errorf("expect validation error, got none")
+	}
+}
@@ -0,0 +1,86 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Updates the database strong account's secret with the identifier. Only the
+// members set are updated.
+func (c *Client) UpdateDBSecret(ctx context.Context, params *UpdateDBSecretInput, optFns ...func(*Options)) (*UpdateDBSecretOutput, error) {
+	if params == nil {
+		params = &UpdateDBSecretInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "UpdateDBSecret", params, optFns, c.addOperationUpdateDBSecretMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*UpdateDBSecretOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type UpdateDBSecretInput struct {
+	// The identifier of the secret.
+	//
+	// This member is required.
+	SecretID *string `json:"-"`
+
+	// The new name of the secret.
+	SecretName *string `json:"secret_name,omitempty"`
+
+	// The new data of the secret, (e.g. a rotated password). The members
+	// required depend on the type of the secret.
+	SecretData *types.DBSecretData `json:"secret_data,omitempty"`
+
+	// The new description of the secret.
+	Description *string `json:"description,omitempty"`
+
+	// The new purpose of the secret.
+	Purpose *string `json:"purpose,omitempty"`
+
+	// The new tags of the secret, replacing the secret's tags.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+type UpdateDBSecretOutput struct {
+	// The updated secret.
+	Secret *types.DBSecret
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationUpdateDBSecretMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodPatch,
+		URI:       "/api/adb/secretsmgmt/secrets/{secretId}",
+		BindInput: restjson_serializeOpHttpBindingsUpdateDBSecretInput,
+		Body:      restjson_serializeOpDocumentUpdateDBSecretInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpUpdateDBSecret,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "UpdateDBSecret"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpUpdateDBSecretInput); err != nil {
+		return err
+	}
+	return nil
+}
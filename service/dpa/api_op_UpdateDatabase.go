@@ -0,0 +1,75 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Updates the onboarded database with the identifier. The database is replaced
+// by the updated database.
+func (c *Client) UpdateDatabase(ctx context.Context, params *UpdateDatabaseInput, optFns ...func(*Options)) (*UpdateDatabaseOutput, error) {
+	if params == nil {
+		params = &UpdateDatabaseInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "UpdateDatabase", params, optFns, c.addOperationUpdateDatabaseMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*UpdateDatabaseOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type UpdateDatabaseInput struct {
+	// The identifier of the database.
+	//
+	// This member is required.
+	ID *int64
+
+	// The updated database.
+	//
+	// This member is required.
+	Database *types.Database
+
+	noSmithyDocumentSerde
+}
+
+type UpdateDatabaseOutput struct {
+	// The updated database.
+	Database *types.Database
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationUpdateDatabaseMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodPut,
+		URI:       "/api/adb/resources/{id}",
+		BindInput: restjson_serializeOpHttpBindingsUpdateDatabaseInput,
+		Body:      restjson_serializeOpDocumentUpdateDatabaseInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpUpdateDatabase,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "UpdateDatabase"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpUpdateDatabaseInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Updates the access policy with the identifier. The policy is replaced by the
+// updated policy.
+func (c *Client) UpdatePolicy(ctx context.Context, params *UpdatePolicyInput, optFns ...func(*Options)) (*UpdatePolicyOutput, error) {
+	if params == nil {
+		params = &UpdatePolicyInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "UpdatePolicy", params, optFns, c.addOperationUpdatePolicyMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*UpdatePolicyOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type UpdatePolicyInput struct {
+	// The identifier of the policy.
+	//
+	// This member is required.
+	PolicyID *string
+
+	// The updated policy.
+	//
+	// This member is required.
+	Policy *types.Policy
+
+	noSmithyDocumentSerde
+}
+
+type UpdatePolicyOutput struct {
+	// The updated policy.
+	Policy *types.Policy
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationUpdatePolicyMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodPut,
+		URI:       "/api/access-policies/{policyId}",
+		BindInput: restjson_serializeOpHttpBindingsUpdatePolicyInput,
+		Body:      restjson_serializeOpDocumentUpdatePolicyInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpUpdatePolicy,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "UpdatePolicy"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpUpdatePolicyInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Updates the target set with the name. The target set is renamed if the name of the
+// updated target set differs.
+func (c *Client) UpdateTargetSet(ctx context.Context, params *UpdateTargetSetInput, optFns ...func(*Options)) (*UpdateTargetSetOutput, error) {
+	if params == nil {
+		params = &UpdateTargetSetInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "UpdateTargetSet", params, optFns, c.addOperationUpdateTargetSetMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*UpdateTargetSetOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type UpdateTargetSetInput struct {
+	// The name of the target set to update.
+	//
+	// This member is required.
+	Name *string
+
+	// The updated target set.
+	//
+	// This member is required.
+	TargetSet *types.TargetSet
+
+	noSmithyDocumentSerde
+}
+
+type UpdateTargetSetOutput struct {
+	// The updated target set.
+	TargetSet *types.TargetSet
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationUpdateTargetSetMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodPut,
+		URI:       "/api/targetsets/{name}",
+		BindInput: restjson_serializeOpHttpBindingsUpdateTargetSetInput,
+		Body:      restjson_serializeOpDocumentUpdateTargetSetInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpUpdateTargetSet,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "UpdateTargetSet"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpUpdateTargetSetInput); err != nil {
+		return err
+	}
+	return nil
+}
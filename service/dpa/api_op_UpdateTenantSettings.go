@@ -0,0 +1,68 @@
+package dpa
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// Updates the DPA settings of the tenant. Only the settings set are updated.
+func (c *Client) UpdateTenantSettings(ctx context.Context, params *UpdateTenantSettingsInput, optFns ...func(*Options)) (*UpdateTenantSettingsOutput, error) {
+	if params == nil {
+		params = &UpdateTenantSettingsInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "UpdateTenantSettings", params, optFns, c.addOperationUpdateTenantSettingsMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*UpdateTenantSettingsOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type UpdateTenantSettingsInput struct {
+	// The settings to update.
+	//
+	// This member is required.
+	Settings *types.TenantSettings
+
+	noSmithyDocumentSerde
+}
+
+type UpdateTenantSettingsOutput struct {
+	// The updated settings.
+	Settings *types.TenantSettings
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationUpdateTenantSettingsMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPatch,
+		URI:    "/api/settings",
+		Body:   restjson_serializeOpDocumentUpdateTenantSettingsInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpUpdateTenantSettings,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "UpdateTenantSettings"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpUpdateTenantSettingsInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package dpa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrmiddleware "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+	"github.com/strick-j/cybr-sdk-go/cybr/protocol/restjson"
+)
+
+// restjson_deserializeOp is the OperationDeserializer of the client's REST
+// JSON operations. Error responses are deserialized into a *cybr.APIError,
+// otherwise the response body is deserialized into the operation's output by
+// Output.
+type restjson_deserializeOp struct {
+	// Output returns the operation's output deserialized from the response
+	// body. The body is empty if the response did not include one.
+	Output func(body []byte) (interface{}, error)
+}
+
+// ID returns the middleware identifier.
+func (*restjson_deserializeOp) ID() string {
+	return "OperationDeserializer"
+}
+
+// HandleDeserialize deserializes the HTTP response into the operation's
+// output, or error.
+func (m *restjson_deserializeOp) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	response, ok := out.RawResponse.(*smithyhttp.Response)
+	if !ok {
+		return out, metadata, &smithy.DeserializationError{Err: fmt.Errorf("unknown transport type %T", out.RawResponse)}
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return out, metadata, restjson_deserializeOpError(response, &metadata)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return out, metadata, &smithy.DeserializationError{Err: fmt.Errorf("failed to read response body, %w", err)}
+	}
+
+	out.Result, err = m.Output(body)
+	if err != nil {
+		return out, metadata, &smithy.DeserializationError{
+			Err:      fmt.Errorf("failed to decode response body, %w", err),
+			Snapshot: body,
+		}
+	}
+
+	return out, metadata, nil
+}
+
+// restjson_deserializeDocument decodes the JSON document of the response body
+// into v. An empty body leaves v unmodified.
+func restjson_deserializeDocument(body []byte, v interface{}) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, v)
+}
+
+// restjson_deserializeOpError returns the error for the operation's error
+// response, decoded from the JSON error response body.
+func restjson_deserializeOpError(response *smithyhttp.Response, metadata *middleware.Metadata) error {
+	var errorBuffer bytes.Buffer
+	if _, err := io.Copy(&errorBuffer, response.Body); err != nil {
+		return &smithy.DeserializationError{Err: fmt.Errorf("failed to copy error response body, %w", err)}
+	}
+	errorBody := bytes.NewReader(errorBuffer.Bytes())
+
+	decoder := json.NewDecoder(errorBody)
+	decoder.UseNumber()
+	info, err := restjson.DecodeErrorInfo(decoder)
+	if err != nil {
+		return &smithy.DeserializationError{
+			Err:      fmt.Errorf("failed to decode response body, %w", err),
+			Snapshot: errorBuffer.Bytes(),
+		}
+	}
+
+	// The request ID found in the body is only used if the request ID was not
+	// returned in the response headers.
+	if len(info.RequestID) != 0 {
+		if _, ok := cybrmiddleware.GetRequestIDMetadata(*metadata); !ok {
+			cybrmiddleware.SetRequestIDMetadata(metadata, info.RequestID)
+		}
+	}
+
+	errorCode := "UnknownError"
+	if len(info.Code) != 0 {
+		errorCode = info.Code
+	}
+	errorMessage := errorCode
+	if len(info.Message) != 0 {
+		errorMessage = info.Message
+	}
+
+	fault := smithy.FaultClient
+	if response.StatusCode >= 500 {
+		fault = smithy.FaultServer
+	}
+
+	return &cybr.APIError{
+		Code:    errorCode,
+		Message: errorMessage,
+		Details: info.Details,
+		Fault:   fault,
+	}
+}
+
+func restjson_deserializeOpListTargetSets(body []byte) (interface{}, error) {
+	output := &ListTargetSetsOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpAddTargetSet(body []byte) (interface{}, error) {
+	output := &AddTargetSetOutput{}
+	return output, restjson_deserializeDocument(body, &output.TargetSet)
+}
+
+func restjson_deserializeOpGetTargetSet(body []byte) (interface{}, error) {
+	output := &GetTargetSetOutput{}
+	return output, restjson_deserializeDocument(body, &output.TargetSet)
+}
+
+func restjson_deserializeOpUpdateTargetSet(body []byte) (interface{}, error) {
+	output := &UpdateTargetSetOutput{}
+	return output, restjson_deserializeDocument(body, &output.TargetSet)
+}
+
+func restjson_deserializeOpAddTargetSetsBulk(body []byte) (interface{}, error) {
+	output := &AddTargetSetsBulkOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpDeleteTargetSetsBulk(body []byte) (interface{}, error) {
+	output := &DeleteTargetSetsBulkOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpListPolicies(body []byte) (interface{}, error) {
+	output := &ListPoliciesOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpGetPolicy(body []byte) (interface{}, error) {
+	output := &GetPolicyOutput{}
+	return output, restjson_deserializeDocument(body, &output.Policy)
+}
+
+func restjson_deserializeOpAddPolicy(body []byte) (interface{}, error) {
+	output := &AddPolicyOutput{}
+	return output, restjson_deserializeDocument(body, &output.Policy)
+}
+
+func restjson_deserializeOpUpdatePolicy(body []byte) (interface{}, error) {
+	output := &UpdatePolicyOutput{}
+	return output, restjson_deserializeDocument(body, &output.Policy)
+}
+
+func restjson_deserializeOpDeletePolicy(body []byte) (interface{}, error) {
+	output := &DeletePolicyOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpGetSSOSSHKey(body []byte) (interface{}, error) {
+	output := &GetSSOSSHKeyOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpGetSSORDPFile(body []byte) (interface{}, error) {
+	output := &GetSSORDPFileOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpGetSSODBToken(body []byte) (interface{}, error) {
+	output := &GetSSODBTokenOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpAddDBSecret(body []byte) (interface{}, error) {
+	output := &AddDBSecretOutput{}
+	return output, restjson_deserializeDocument(body, &output.Secret)
+}
+
+func restjson_deserializeOpListDBSecrets(body []byte) (interface{}, error) {
+	output := &ListDBSecretsOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpGetDBSecret(body []byte) (interface{}, error) {
+	output := &GetDBSecretOutput{}
+	return output, restjson_deserializeDocument(body, &output.Secret)
+}
+
+func restjson_deserializeOpUpdateDBSecret(body []byte) (interface{}, error) {
+	output := &UpdateDBSecretOutput{}
+	return output, restjson_deserializeDocument(body, &output.Secret)
+}
+
+func restjson_deserializeOpEnableDBSecret(body []byte) (interface{}, error) {
+	output := &EnableDBSecretOutput{}
+	return output, restjson_deserializeDocument(body, &output.Secret)
+}
+
+func restjson_deserializeOpDisableDBSecret(body []byte) (interface{}, error) {
+	output := &DisableDBSecretOutput{}
+	return output, restjson_deserializeDocument(body, &output.Secret)
+}
+
+func restjson_deserializeOpDeleteDBSecret(body []byte) (interface{}, error) {
+	output := &DeleteDBSecretOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpAddDatabase(body []byte) (interface{}, error) {
+	output := &AddDatabaseOutput{}
+	return output, restjson_deserializeDocument(body, &output.Database)
+}
+
+func restjson_deserializeOpListDatabases(body []byte) (interface{}, error) {
+	output := &ListDatabasesOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpGetDatabase(body []byte) (interface{}, error) {
+	output := &GetDatabaseOutput{}
+	return output, restjson_deserializeDocument(body, &output.Database)
+}
+
+func restjson_deserializeOpUpdateDatabase(body []byte) (interface{}, error) {
+	output := &UpdateDatabaseOutput{}
+	return output, restjson_deserializeDocument(body, &output.Database)
+}
+
+func restjson_deserializeOpDeleteDatabase(body []byte) (interface{}, error) {
+	output := &DeleteDatabaseOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpGetSSHCAPublicKey(body []byte) (interface{}, error) {
+	output := &GetSSHCAPublicKeyOutput{}
+	// The public key is returned as plain text, not as a JSON document.
+	if v := strings.TrimSpace(string(body)); len(v) != 0 {
+		output.PublicKey = cybr.String(v)
+	}
+	return output, nil
+}
+
+func restjson_deserializeOpRotateSSHCA(body []byte) (interface{}, error) {
+	output := &RotateSSHCAOutput{}
+	return output, restjson_deserializeDocument(body, &output.Version)
+}
+
+func restjson_deserializeOpListSSHCAVersions(body []byte) (interface{}, error) {
+	output := &ListSSHCAVersionsOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpGenerateConnectorSetupScript(body []byte) (interface{}, error) {
+	output := &GenerateConnectorSetupScriptOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpGetTenantSettings(body []byte) (interface{}, error) {
+	output := &GetTenantSettingsOutput{}
+	return output, restjson_deserializeDocument(body, &output.Settings)
+}
+
+func restjson_deserializeOpUpdateTenantSettings(body []byte) (interface{}, error) {
+	output := &UpdateTenantSettingsOutput{}
+	return output, restjson_deserializeDocument(body, &output.Settings)
+}
@@ -0,0 +1,24 @@
+// Package dpa provides the API client, operations, and parameter types for
+// CyberArk Dynamic Privileged Access.
+//
+// Create a client from the SDK's shared configuration, with the tenant name
+// used to resolve the service's endpoint.
+//
+//	cfg, err := config.LoadDefaultConfig(context.TODO(),
+//		config.WithTenantName("example"),
+//	)
+//	if err != nil {
+//		log.Fatalf("failed to load configuration, %v", err)
+//	}
+//
+//	client := dpa.NewFromConfig(cfg)
+//
+// The service's endpoint is resolved for the tenant with the platform
+// discovery API, and the result is cached by the client. If the tenant can
+// not be discovered the endpoint is derived from the tenant name. Set
+// Options.DisableEndpointDiscovery to always derive it from the tenant name.
+//
+// Errors returned by the service are returned as a *cybr.ResponseError
+// wrapping a *cybr.APIError, with the service's error code, message, and
+// details.
+package dpa
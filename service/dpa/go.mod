@@ -1,3 +1,10 @@
 module github.com/strick-j/cybr-sdk-go/service/dpa
 
 go 1.21.4
+
+require (
+	github.com/aws/smithy-go v1.19.0
+	github.com/strick-j/cybr-sdk-go v1.0.0
+)
+
+replace github.com/strick-j/cybr-sdk-go => ../../
@@ -0,0 +1,350 @@
+package dpa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/encoding/httpbinding"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// restjson_serializeOp is the OperationSerializer of the client's REST JSON
+// operations. The operation's input is bound to the request's URI labels,
+// query string, and headers by BindInput, and the document returned by Body
+// is serialized as the request's JSON body.
+type restjson_serializeOp struct {
+	// The HTTP method of the operation.
+	Method string
+
+	// The operation's URI template, joined to the endpoint's path. Labels,
+	// (e.g. {name}), are replaced by BindInput.
+	URI string
+
+	// BindInput binds the input's members to the request. Optional.
+	BindInput func(input interface{}, encoder *httpbinding.Encoder) error
+
+	// Body returns the document serialized as the request body, or nil if
+	// the request has no body. Optional.
+	Body func(input interface{}) interface{}
+}
+
+// ID returns the middleware identifier.
+func (*restjson_serializeOp) ID() string {
+	return "OperationSerializer"
+}
+
+// HandleSerialize serializes the operation's input into the HTTP request.
+func (m *restjson_serializeOp) HandleSerialize(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+	out middleware.SerializeOutput, metadata middleware.Metadata, err error,
+) {
+	request, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return out, metadata, &smithy.SerializationError{Err: fmt.Errorf("unknown transport type %T", in.Request)}
+	}
+
+	opPath, opQuery := httpbinding.SplitURI(m.URI)
+	request.URL.Path = smithyhttp.JoinPath(request.URL.Path, opPath)
+	request.URL.RawQuery = smithyhttp.JoinRawQuery(request.URL.RawQuery, opQuery)
+	request.Method = m.Method
+
+	var restEncoder *httpbinding.Encoder
+	if request.URL.RawPath == "" {
+		restEncoder, err = httpbinding.NewEncoder(request.URL.Path, request.URL.RawQuery, request.Header)
+	} else {
+		request.URL.RawPath = smithyhttp.JoinPath(request.URL.RawPath, opPath)
+		restEncoder, err = httpbinding.NewEncoderWithRawPath(request.URL.Path, request.URL.RawPath, request.URL.RawQuery, request.Header)
+	}
+	if err != nil {
+		return out, metadata, &smithy.SerializationError{Err: err}
+	}
+
+	if m.BindInput != nil {
+		if err := m.BindInput(in.Parameters, restEncoder); err != nil {
+			return out, metadata, &smithy.SerializationError{Err: err}
+		}
+	}
+
+	if m.Body != nil {
+		if document := m.Body(in.Parameters); document != nil {
+			restEncoder.SetHeader("Content-Type").String("application/json")
+
+			body, err := json.Marshal(document)
+			if err != nil {
+				return out, metadata, &smithy.SerializationError{Err: err}
+			}
+			if request, err = request.SetStream(bytes.NewReader(body)); err != nil {
+				return out, metadata, &smithy.SerializationError{Err: err}
+			}
+		}
+	}
+
+	if request.Request, err = restEncoder.Encode(request.Request); err != nil {
+		return out, metadata, &smithy.SerializationError{Err: err}
+	}
+	in.Request = request
+
+	return next.HandleSerialize(ctx, in)
+}
+
+// bindURILabel binds the value of the URI label. Returns an error if the value
+// is not set, as the label can not be left in the request URI.
+func bindURILabel(encoder *httpbinding.Encoder, label string, v *string) error {
+	if v == nil || len(*v) == 0 {
+		return &smithy.SerializationError{Err: fmt.Errorf("input member %s must not be empty", label)}
+	}
+	return encoder.SetURI(label).String(*v)
+}
+
+// bindURILabelInt64 binds the integer value of the URI label. Returns an error
+// if the value is not set.
+func bindURILabelInt64(encoder *httpbinding.Encoder, label string, v *int64) error {
+	if v == nil {
+		return &smithy.SerializationError{Err: fmt.Errorf("input member %s must not be empty", label)}
+	}
+	return encoder.SetURI(label).Long(*v)
+}
+
+func restjson_serializeOpDocumentAddTargetSetInput(input interface{}) interface{} {
+	v, ok := input.(*AddTargetSetInput)
+	if !ok {
+		return nil
+	}
+	return v.TargetSet
+}
+
+func restjson_serializeOpHttpBindingsGetTargetSetInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*GetTargetSetInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "name", v.Name)
+}
+
+func restjson_serializeOpHttpBindingsUpdateTargetSetInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*UpdateTargetSetInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "name", v.Name)
+}
+
+func restjson_serializeOpDocumentUpdateTargetSetInput(input interface{}) interface{} {
+	v, ok := input.(*UpdateTargetSetInput)
+	if !ok {
+		return nil
+	}
+	return v.TargetSet
+}
+
+func restjson_serializeOpDocumentAddTargetSetsBulkInput(input interface{}) interface{} {
+	v, ok := input.(*AddTargetSetsBulkInput)
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func restjson_serializeOpDocumentDeleteTargetSetsBulkInput(input interface{}) interface{} {
+	v, ok := input.(*DeleteTargetSetsBulkInput)
+	if !ok {
+		return nil
+	}
+	return v.Names
+}
+
+func restjson_serializeOpHttpBindingsListPoliciesInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*ListPoliciesInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	if v.Limit != nil {
+		encoder.SetQuery("limit").Integer(*v.Limit)
+	}
+	if v.NextToken != nil {
+		encoder.SetQuery("nextToken").String(*v.NextToken)
+	}
+	return nil
+}
+
+func restjson_serializeOpHttpBindingsGetPolicyInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*GetPolicyInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "policyId", v.PolicyID)
+}
+
+func restjson_serializeOpDocumentAddPolicyInput(input interface{}) interface{} {
+	v, ok := input.(*AddPolicyInput)
+	if !ok {
+		return nil
+	}
+	return v.Policy
+}
+
+func restjson_serializeOpHttpBindingsUpdatePolicyInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*UpdatePolicyInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "policyId", v.PolicyID)
+}
+
+func restjson_serializeOpDocumentUpdatePolicyInput(input interface{}) interface{} {
+	v, ok := input.(*UpdatePolicyInput)
+	if !ok {
+		return nil
+	}
+	return v.Policy
+}
+
+func restjson_serializeOpHttpBindingsDeletePolicyInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*DeletePolicyInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "policyId", v.PolicyID)
+}
+
+func restjson_serializeOpHttpBindingsGetSSOSSHKeyInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*GetSSOSSHKeyInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	if len(v.Format) != 0 {
+		encoder.SetQuery("format").String(string(v.Format))
+	}
+	return nil
+}
+
+func restjson_serializeOpDocumentGetSSORDPFileInput(input interface{}) interface{} {
+	v, ok := input.(*GetSSORDPFileInput)
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func restjson_serializeOpDocumentGetSSODBTokenInput(input interface{}) interface{} {
+	v, ok := input.(*GetSSODBTokenInput)
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func restjson_serializeOpDocumentAddDBSecretInput(input interface{}) interface{} {
+	v, ok := input.(*AddDBSecretInput)
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func restjson_serializeOpHttpBindingsGetDBSecretInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*GetDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "secretId", v.SecretID)
+}
+
+func restjson_serializeOpHttpBindingsUpdateDBSecretInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*UpdateDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "secretId", v.SecretID)
+}
+
+func restjson_serializeOpDocumentUpdateDBSecretInput(input interface{}) interface{} {
+	v, ok := input.(*UpdateDBSecretInput)
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func restjson_serializeOpHttpBindingsEnableDBSecretInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*EnableDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "secretId", v.SecretID)
+}
+
+func restjson_serializeOpHttpBindingsDisableDBSecretInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*DisableDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "secretId", v.SecretID)
+}
+
+func restjson_serializeOpHttpBindingsDeleteDBSecretInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*DeleteDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "secretId", v.SecretID)
+}
+
+func restjson_serializeOpDocumentAddDatabaseInput(input interface{}) interface{} {
+	v, ok := input.(*AddDatabaseInput)
+	if !ok {
+		return nil
+	}
+	return v.Database
+}
+
+func restjson_serializeOpHttpBindingsGetDatabaseInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*GetDatabaseInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabelInt64(encoder, "id", v.ID)
+}
+
+func restjson_serializeOpHttpBindingsUpdateDatabaseInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*UpdateDatabaseInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabelInt64(encoder, "id", v.ID)
+}
+
+func restjson_serializeOpDocumentUpdateDatabaseInput(input interface{}) interface{} {
+	v, ok := input.(*UpdateDatabaseInput)
+	if !ok {
+		return nil
+	}
+	return v.Database
+}
+
+func restjson_serializeOpHttpBindingsDeleteDatabaseInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*DeleteDatabaseInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabelInt64(encoder, "id", v.ID)
+}
+
+func restjson_serializeOpDocumentGenerateConnectorSetupScriptInput(input interface{}) interface{} {
+	v, ok := input.(*GenerateConnectorSetupScriptInput)
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func restjson_serializeOpDocumentUpdateTenantSettingsInput(input interface{}) interface{} {
+	v, ok := input.(*UpdateTenantSettingsInput)
+	if !ok {
+		return nil
+	}
+	return v.Settings
+}
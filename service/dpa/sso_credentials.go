@@ -0,0 +1,130 @@
+package dpa
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+	cybrmiddleware "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// ssoCredentialsFileMode is the mode of the files short-lived credentials are
+// written to, readable and writable only by the owner.
+const ssoCredentialsFileMode os.FileMode = 0600
+
+// Expiration returns the time the SSH private key expires, and true, or false
+// if the service did not return the lifetime of the key.
+func (o *GetSSOSSHKeyOutput) Expiration() (time.Time, bool) {
+	return ssoCredentialsExpiration(o.Metadata, o.ResultMetadata)
+}
+
+// WriteFile writes the SSH private key to the file, readable and writable
+// only by the owner, as required by SSH clients. The file's directory is
+// created if it does not exist.
+func (o *GetSSOSSHKeyOutput) WriteFile(filename string) error {
+	if o.PrivateKey == nil || len(*o.PrivateKey) == 0 {
+		return fmt.Errorf("SSH private key is empty")
+	}
+	return writeSSOCredentialsFile(filename, []byte(*o.PrivateKey))
+}
+
+// Expiration returns the time the RDP file's token expires, and true, or
+// false if the service did not return the lifetime of the token.
+func (o *GetSSORDPFileOutput) Expiration() (time.Time, bool) {
+	return ssoCredentialsExpiration(o.Metadata, o.ResultMetadata)
+}
+
+// WriteFile writes the RDP file to the file, readable and writable only by
+// the owner. The file's directory is created if it does not exist.
+func (o *GetSSORDPFileOutput) WriteFile(filename string) error {
+	if o.RDPFile == nil || len(*o.RDPFile) == 0 {
+		return fmt.Errorf("RDP file is empty")
+	}
+	return writeSSOCredentialsFile(filename, []byte(*o.RDPFile))
+}
+
+// Expiration returns the time the database token expires, and true, or false
+// if the service did not return the lifetime of the token.
+func (o *GetSSODBTokenOutput) Expiration() (time.Time, bool) {
+	return ssoCredentialsExpiration(o.Metadata, o.ResultMetadata)
+}
+
+// WriteFile writes the database token to the file, readable and writable only
+// by the owner, (e.g. for a client reading its password from a file). The
+// file's directory is created if it does not exist.
+func (o *GetSSODBTokenOutput) WriteFile(filename string) error {
+	if o.Token == nil || len(*o.Token) == 0 {
+		return fmt.Errorf("database token is empty")
+	}
+	return writeSSOCredentialsFile(filename, []byte(*o.Token))
+}
+
+// ssoCredentialsExpiration returns the time short-lived credentials expire,
+// relative to the time they were created. If the service did not return the
+// creation time, the time the response was received at is used instead.
+func ssoCredentialsExpiration(v *types.SSOCredentialsMetadata, metadata middleware.Metadata) (time.Time, bool) {
+	if v == nil || v.ExpiresIn == nil {
+		return time.Time{}, false
+	}
+
+	var createdAt time.Time
+	if v.CreatedAt != nil {
+		createdAt = *v.CreatedAt
+	} else if responseAt, ok := cybrmiddleware.GetResponseAt(metadata); ok {
+		createdAt = responseAt
+	} else {
+		createdAt = sdk.NowTime()
+	}
+
+	return createdAt.Add(time.Duration(*v.ExpiresIn) * time.Second).UTC(), true
+}
+
+// writeSSOCredentialsFile writes short-lived credentials to the file. The
+// credentials are written to a temporary file, with permissions restricted
+// to the owner, which replaces the file once complete. Readers never observe
+// partially written credentials, or credentials readable by other users.
+func writeSSOCredentialsFile(filename string, b []byte) (err error) {
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create credentials file directory, %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(dir, filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary credentials file, %w", err)
+	}
+	tmpFilename := tmpFile.Name()
+
+	defer func() {
+		if err != nil {
+			tmpFile.Close()
+			os.Remove(tmpFilename)
+		}
+	}()
+
+	if err := tmpFile.Chmod(ssoCredentialsFileMode); err != nil && runtime.GOOS != "windows" {
+		return fmt.Errorf("failed to set credentials file permissions, %w", err)
+	}
+
+	if _, err := tmpFile.Write(b); err != nil {
+		return fmt.Errorf("failed to write credentials file, %w", err)
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temporary credentials file, %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary credentials file, %w", err)
+	}
+
+	if err := os.Rename(tmpFilename, filename); err != nil {
+		return fmt.Errorf("failed to replace credentials file, %w", err)
+	}
+
+	return nil
+}
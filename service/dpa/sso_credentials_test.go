@@ -0,0 +1,206 @@
+package dpa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/internal/sdk"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+func TestClient_GetSSOSSHKey(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := http.MethodGet, r.Method; e != a {
+			t.Errorf("expect %v method, got %v", e, a)
+		}
+		if e, a := "/api/ssh/sso/key", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		if e, a := "PPK", r.URL.Query().Get("format"); e != a {
+			t.Errorf("expect %v format, got %v", e, a)
+		}
+
+		fmt.Fprint(w, `{"private_key":"PuTTY-User-Key-File-3: ssh-rsa","format":"PPK","metadata":{"created_at":"2024-05-01T10:00:00Z","expires_in":3600}}`)
+	})
+
+	out, err := client.GetSSOSSHKey(context.Background(), &GetSSOSSHKeyInput{
+		Format: types.SSHKeyFormatPPK,
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "PuTTY-User-Key-File-3: ssh-rsa", cybr.ToString(out.PrivateKey); e != a {
+		t.Errorf("expect %v key, got %v", e, a)
+	}
+	if e, a := types.SSHKeyFormatPPK, out.Format; e != a {
+		t.Errorf("expect %v format, got %v", e, a)
+	}
+
+	expires, ok := out.Expiration()
+	if !ok {
+		t.Fatalf("expect expiration")
+	}
+	if e, a := time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC), expires; !e.Equal(a) {
+		t.Errorf("expect %v expiration, got %v", e, a)
+	}
+}
+
+func TestClient_GetSSORDPFile(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := http.MethodPost, r.Method; e != a {
+			t.Errorf("expect %v method, got %v", e, a)
+		}
+		if e, a := "/api/rdp/sso/file", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		body, _ := io.ReadAll(r.Body)
+		assertJSONEqual(t, `{"target_address":"10.0.0.10","target_domain":"example.com","elevated_privileges":true}`, string(body))
+
+		fmt.Fprint(w, `{"rdp_file":"full address:s:10.0.0.10","metadata":{"expires_in":300}}`)
+	})
+
+	out, err := client.GetSSORDPFile(context.Background(), &GetSSORDPFileInput{
+		TargetAddress:      cybr.String("10.0.0.10"),
+		TargetDomain:       cybr.String("example.com"),
+		ElevatedPrivileges: cybr.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "full address:s:10.0.0.10", cybr.ToString(out.RDPFile); e != a {
+		t.Errorf("expect %v RDP file, got %v", e, a)
+	}
+
+	// Without the creation time the expiration is relative to the response.
+	expires, ok := out.Expiration()
+	if !ok {
+		t.Fatalf("expect expiration")
+	}
+	if d := time.Until(expires); d <= 0 || d > 300*time.Second {
+		t.Errorf("expect expiration within 300s, got %v", d)
+	}
+}
+
+func TestClient_GetSSODBToken(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "/api/db/sso/token", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+		body, _ := io.ReadAll(r.Body)
+		assertJSONEqual(t, `{"engine":"postgres"}`, string(body))
+
+		fmt.Fprint(w, `{"token":"db-token","metadata":{"created_at":"2024-05-01T10:00:00Z","expires_in":900}}`)
+	})
+
+	out, err := client.GetSSODBToken(context.Background(), &GetSSODBTokenInput{
+		Engine: types.DatabaseEnginePostgres,
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "db-token", cybr.ToString(out.Token); e != a {
+		t.Errorf("expect %v token, got %v", e, a)
+	}
+
+	expires, _ := out.Expiration()
+	if e, a := time.Date(2024, 5, 1, 10, 15, 0, 0, time.UTC), expires; !e.Equal(a) {
+		t.Errorf("expect %v expiration, got %v", e, a)
+	}
+
+	if _, err := client.GetSSODBToken(context.Background(), nil); err == nil {
+		t.Errorf("expect validation error, got none")
+	}
+}
+
+func TestClient_SSOCredentialsLogRedaction(t *testing.T) {
+	var logs bytes.Buffer
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"private_key":"private-key-material","format":"OpenSSH"}`)
+	}, func(o *Options) {
+		o.ClientLogMode = cybr.LogResponseWithBody
+		o.Logger = logging.NewStandardLogger(&logs)
+	})
+
+	if _, err := client.GetSSOSSHKey(context.Background(), nil); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if strings.Contains(logs.String(), "private-key-material") {
+		t.Errorf("expect private key to be redacted, got %v", logs.String())
+	}
+	if !strings.Contains(logs.String(), "[REDACTED]") {
+		t.Errorf("expect redacted response body to be logged, got %v", logs.String())
+	}
+}
+
+func TestSSOCredentialsExpiration(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	orig := sdk.NowTime
+	sdk.NowTime = func() time.Time { return now }
+	defer func() { sdk.NowTime = orig }()
+
+	if _, ok := (&GetSSODBTokenOutput{}).Expiration(); ok {
+		t.Errorf("expect no expiration without metadata")
+	}
+
+	out := &GetSSODBTokenOutput{
+		Metadata: &types.SSOCredentialsMetadata{ExpiresIn: cybr.Int32(60)},
+	}
+	expires, ok := out.Expiration()
+	if !ok {
+		t.Fatalf("expect expiration")
+	}
+	if e, a := now.Add(time.Minute), expires; !e.Equal(a) {
+		t.Errorf("expect %v expiration, got %v", e, a)
+	}
+}
+
+func TestSSOCredentialsWriteFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "keys", "dpa_key.ppk")
+
+	out := &GetSSOSSHKeyOutput{PrivateKey: cybr.String("private-key")}
+	if err := out.WriteFile(filename); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := "private-key", string(b); e != a {
+		t.Errorf("expect %v content, got %v", e, a)
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if e, a := os.FileMode(0600), info.Mode().Perm(); e != a {
+			t.Errorf("expect %v mode, got %v", e, a)
+		}
+	}
+
+	// Existing files are replaced.
+	out.PrivateKey = cybr.String("rotated-key")
+	if err := out.WriteFile(filename); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if b, _ := os.ReadFile(filename); string(b) != "rotated-key" {
+		t.Errorf("expect rotated key, got %s", b)
+	}
+
+	if err := (&GetSSORDPFileOutput{}).WriteFile(filename); err == nil {
+		t.Errorf("expect error for empty RDP file, got none")
+	}
+}
@@ -0,0 +1,345 @@
+package types
+
+// TargetSetType is the type of the targets matched by a target set.
+type TargetSetType string
+
+// Enum values for TargetSetType
+const (
+	// Matches the targets of a domain.
+	TargetSetTypeDomain TargetSetType = "Domain"
+
+	// Matches the targets with a DNS suffix.
+	TargetSetTypeSuffix TargetSetType = "Suffix"
+
+	// Matches a single target by FQDN or IP address.
+	TargetSetTypeTarget TargetSetType = "Target"
+)
+
+// Values returns all known values for TargetSetType. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (TargetSetType) Values() []TargetSetType {
+	return []TargetSetType{
+		"Domain",
+		"Suffix",
+		"Target",
+	}
+}
+
+// SecretType is the type of the secret associated with a target set, used to
+// provision ephemeral users on the target set's targets.
+type SecretType string
+
+// Enum values for SecretType
+const (
+	// A provisioner user's credentials stored by DPA.
+	SecretTypeProvisionerUser SecretType = "ProvisionerUser"
+
+	// An account's credentials stored in Privilege Cloud.
+	SecretTypePCloudAccount SecretType = "PCloudAccount"
+)
+
+// Values returns all known values for SecretType. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (SecretType) Values() []SecretType {
+	return []SecretType{
+		"ProvisionerUser",
+		"PCloudAccount",
+	}
+}
+
+// PolicyStatus is the status of an access policy.
+type PolicyStatus string
+
+// Enum values for PolicyStatus
+const (
+	// The policy is enabled, and grants access.
+	PolicyStatusEnabled PolicyStatus = "Enabled"
+
+	// The policy is disabled, and does not grant access.
+	PolicyStatusDisabled PolicyStatus = "Disabled"
+
+	// The policy's end date has passed.
+	PolicyStatusExpired PolicyStatus = "Expired"
+
+	// The policy is being validated.
+	PolicyStatusValidating PolicyStatus = "Validating"
+
+	// The policy failed validation.
+	PolicyStatusError PolicyStatus = "Error"
+)
+
+// Values returns all known values for PolicyStatus. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (PolicyStatus) Values() []PolicyStatus {
+	return []PolicyStatus{
+		"Enabled",
+		"Disabled",
+		"Expired",
+		"Validating",
+		"Error",
+	}
+}
+
+// DayOfWeek is a day of the week access is granted on.
+type DayOfWeek string
+
+// Enum values for DayOfWeek
+const (
+	DayOfWeekMonday    DayOfWeek = "Mon"
+	DayOfWeekTuesday   DayOfWeek = "Tue"
+	DayOfWeekWednesday DayOfWeek = "Wed"
+	DayOfWeekThursday  DayOfWeek = "Thu"
+	DayOfWeekFriday    DayOfWeek = "Fri"
+	DayOfWeekSaturday  DayOfWeek = "Sat"
+	DayOfWeekSunday    DayOfWeek = "Sun"
+)
+
+// Values returns all known values for DayOfWeek. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (DayOfWeek) Values() []DayOfWeek {
+	return []DayOfWeek{
+		"Mon",
+		"Tue",
+		"Wed",
+		"Thu",
+		"Fri",
+		"Sat",
+		"Sun",
+	}
+}
+
+// RuleOperator is the operator an FQDN or IP rule matches targets with.
+type RuleOperator string
+
+// Enum values for RuleOperator
+const (
+	// Matches targets equal to the rule's value.
+	RuleOperatorExactly RuleOperator = "EXACTLY"
+
+	// Matches targets with the rule's wildcard pattern, (e.g. *.example.com).
+	RuleOperatorWildcard RuleOperator = "WILDCARD"
+
+	// Matches targets starting with the rule's value.
+	RuleOperatorPrefix RuleOperator = "PREFIX"
+)
+
+// Values returns all known values for RuleOperator. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (RuleOperator) Values() []RuleOperator {
+	return []RuleOperator{
+		"EXACTLY",
+		"WILDCARD",
+		"PREFIX",
+	}
+}
+
+// SSHKeyFormat is the format of a short-lived SSH private key.
+type SSHKeyFormat string
+
+// Enum values for SSHKeyFormat
+const (
+	// The PuTTY private key format.
+	SSHKeyFormatPPK SSHKeyFormat = "PPK"
+
+	// The OpenSSH private key format.
+	SSHKeyFormatOpenSSH SSHKeyFormat = "OpenSSH"
+
+	// The PEM encoded PKCS #1 private key format.
+	SSHKeyFormatPEM SSHKeyFormat = "PEM"
+)
+
+// Values returns all known values for SSHKeyFormat. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (SSHKeyFormat) Values() []SSHKeyFormat {
+	return []SSHKeyFormat{
+		"PPK",
+		"OpenSSH",
+		"PEM",
+	}
+}
+
+// DatabaseEngine is the engine of a database.
+type DatabaseEngine string
+
+// Enum values for DatabaseEngine
+const (
+	DatabaseEngineOracle   DatabaseEngine = "oracle"
+	DatabaseEnginePostgres DatabaseEngine = "postgres"
+	DatabaseEngineMySQL    DatabaseEngine = "mysql"
+	DatabaseEngineMariaDB  DatabaseEngine = "mariadb"
+	DatabaseEngineMSSQL    DatabaseEngine = "mssql"
+	DatabaseEngineMongo    DatabaseEngine = "mongo"
+)
+
+// Values returns all known values for DatabaseEngine. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (DatabaseEngine) Values() []DatabaseEngine {
+	return []DatabaseEngine{
+		"oracle",
+		"postgres",
+		"mysql",
+		"mariadb",
+		"mssql",
+		"mongo",
+	}
+}
+
+// DBSecretType is the type of a database strong account's secret.
+type DBSecretType string
+
+// Enum values for DBSecretType
+const (
+	// A database user's username and password.
+	DBSecretTypeUsernamePassword DBSecretType = "username_password"
+
+	// An AWS IAM user's access key, used for RDS IAM authentication.
+	DBSecretTypeIAMUser DBSecretType = "iam_user"
+
+	// A MongoDB Atlas API public and private key.
+	DBSecretTypeAtlasAccessKeys DBSecretType = "atlas_access_keys"
+)
+
+// Values returns all known values for DBSecretType. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (DBSecretType) Values() []DBSecretType {
+	return []DBSecretType{
+		"username_password",
+		"iam_user",
+		"atlas_access_keys",
+	}
+}
+
+// DatabasePlatform is the platform a database is hosted on.
+type DatabasePlatform string
+
+// Enum values for DatabasePlatform
+const (
+	DatabasePlatformAWS       DatabasePlatform = "AWS"
+	DatabasePlatformAzure     DatabasePlatform = "AZURE"
+	DatabasePlatformGCP       DatabasePlatform = "GCP"
+	DatabasePlatformOnPremise DatabasePlatform = "ON-PREMISE"
+	DatabasePlatformAtlas     DatabasePlatform = "ATLAS"
+)
+
+// Values returns all known values for DatabasePlatform. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (DatabasePlatform) Values() []DatabasePlatform {
+	return []DatabasePlatform{
+		"AWS",
+		"AZURE",
+		"GCP",
+		"ON-PREMISE",
+		"ATLAS",
+	}
+}
+
+// DatabaseAuthMethod is the method DPA authenticates users to a database
+// with.
+type DatabaseAuthMethod string
+
+// Enum values for DatabaseAuthMethod
+const (
+	// Users connect as ephemeral users of the database, provisioned with the
+	// database's strong account.
+	DatabaseAuthMethodLocalEphemeralUser DatabaseAuthMethod = "local_ephemeral_user"
+
+	// Users connect as ephemeral Active Directory users.
+	DatabaseAuthMethodADEphemeralUser DatabaseAuthMethod = "ad_ephemeral_user"
+
+	// Users connect with RDS IAM authentication tokens.
+	DatabaseAuthMethodRDSIAMAuthentication DatabaseAuthMethod = "rds_iam_authentication"
+
+	// Users connect as ephemeral MongoDB Atlas database users.
+	DatabaseAuthMethodAtlasEphemeralUser DatabaseAuthMethod = "atlas_ephemeral_user"
+)
+
+// Values returns all known values for DatabaseAuthMethod. Note that this can
+// be expanded in the future, and so it is only as up to date as the client.
+// The ordering of this slice is not guaranteed to be stable across updates.
+func (DatabaseAuthMethod) Values() []DatabaseAuthMethod {
+	return []DatabaseAuthMethod{
+		"local_ephemeral_user",
+		"ad_ephemeral_user",
+		"rds_iam_authentication",
+		"atlas_ephemeral_user",
+	}
+}
+
+// SSHCAStatus is the status of a version of the SSH certificate authority.
+type SSHCAStatus string
+
+// Enum values for SSHCAStatus
+const (
+	// The version signs the certificates of SSH connections.
+	SSHCAStatusActive SSHCAStatus = "active"
+
+	// The version was created by a rotation, and is trusted, but does not
+	// sign certificates yet.
+	SSHCAStatusPending SSHCAStatus = "pending"
+
+	// The version was replaced by a rotation, and no longer signs
+	// certificates.
+	SSHCAStatusInactive SSHCAStatus = "inactive"
+)
+
+// Values returns all known values for SSHCAStatus. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (SSHCAStatus) Values() []SSHCAStatus {
+	return []SSHCAStatus{
+		"active",
+		"pending",
+		"inactive",
+	}
+}
+
+// ConnectorType is the platform a connector is installed on.
+type ConnectorType string
+
+// Enum values for ConnectorType
+const (
+	ConnectorTypeAWS       ConnectorType = "AWS"
+	ConnectorTypeAzure     ConnectorType = "AZURE"
+	ConnectorTypeGCP       ConnectorType = "GCP"
+	ConnectorTypeOnPremise ConnectorType = "ON-PREMISE"
+)
+
+// Values returns all known values for ConnectorType. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (ConnectorType) Values() []ConnectorType {
+	return []ConnectorType{
+		"AWS",
+		"AZURE",
+		"GCP",
+		"ON-PREMISE",
+	}
+}
+
+// ConnectorOS is the operating system of a connector's host.
+type ConnectorOS string
+
+// Enum values for ConnectorOS
+const (
+	ConnectorOSLinux   ConnectorOS = "linux"
+	ConnectorOSWindows ConnectorOS = "windows"
+)
+
+// Values returns all known values for ConnectorOS. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (ConnectorOS) Values() []ConnectorOS {
+	return []ConnectorOS{
+		"linux",
+		"windows",
+	}
+}
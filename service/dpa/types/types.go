@@ -0,0 +1,688 @@
+package types
+
+import (
+	"time"
+
+	smithydocument "github.com/aws/smithy-go/document"
+)
+
+type noSmithyDocumentSerde = smithydocument.NoSerde
+
+// AWSProviderData are the conditions matching the AWS instances a policy
+// grants access to.
+type AWSProviderData struct {
+
+	// The regions of the instances, (e.g. us-east-1).
+	Regions []string `json:"regions,omitempty"`
+
+	// The tags of the instances.
+	Tags []Tag `json:"tags,omitempty"`
+
+	// The identifiers of the VPCs of the instances.
+	VPCIDs []string `json:"vpcIds,omitempty"`
+
+	// The identifiers of the AWS accounts of the instances.
+	AccountIDs []string `json:"accountIds,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// AzureProviderData are the conditions matching the Azure virtual machines a
+// policy grants access to.
+type AzureProviderData struct {
+
+	// The regions of the virtual machines, (e.g. eastus).
+	Regions []string `json:"regions,omitempty"`
+
+	// The tags of the virtual machines.
+	Tags []Tag `json:"tags,omitempty"`
+
+	// The resource groups of the virtual machines.
+	ResourceGroups []string `json:"resourceGroups,omitempty"`
+
+	// The identifiers of the virtual networks of the virtual machines.
+	VNetIDs []string `json:"vnetIds,omitempty"`
+
+	// The identifiers of the subscriptions of the virtual machines.
+	Subscriptions []string `json:"subscriptions,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// CertificateValidationSettings are the tenant settings of the validation of
+// targets' certificates.
+type CertificateValidationSettings struct {
+
+	// Whether the certificates of targets are validated.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// ConnectAs is the identity users connect to targets as. The provider members
+// are used by VM policies, and the authentication members by DB policies.
+type ConnectAs struct {
+
+	// The identity users connect to AWS instances as.
+	AWS *ProviderConnectAs `json:"AWS,omitempty"`
+
+	// The identity users connect to Azure virtual machines as.
+	Azure *ProviderConnectAs `json:"Azure,omitempty"`
+
+	// The identity users connect to GCP instances as.
+	GCP *ProviderConnectAs `json:"GCP,omitempty"`
+
+	// The identity users connect to FQDN and IP targets as.
+	FQDNIP *ProviderConnectAs `json:"FQDN/IP,omitempty"`
+
+	// The database roles assigned to the ephemeral users of databases
+	// authenticating users with database authentication.
+	DBAuth []DBAuth `json:"db_auth,omitempty"`
+
+	// The groups assigned to the ephemeral users of databases authenticating
+	// users with LDAP authentication.
+	LDAPAuth []LDAPAuth `json:"ldap_auth,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// ConnectionInformation describes how, and when, the users of an access rule
+// connect to the policy's targets.
+type ConnectionInformation struct {
+
+	// The identity users connect to targets as.
+	//
+	// This member is required.
+	ConnectAs *ConnectAs `json:"connectAs,omitempty"`
+
+	// The maximum duration of a session, in hours.
+	MaxSessionDuration *int32 `json:"grantAccess,omitempty"`
+
+	// The duration a session may be idle before it is disconnected, in
+	// minutes.
+	IdleTime *int32 `json:"idleTime,omitempty"`
+
+	// The days of the week access is granted on.
+	DaysOfWeek []DayOfWeek `json:"daysOfWeek,omitempty"`
+
+	// Whether access is granted for the full day. The access window is
+	// between HoursFrom and HoursTo if false.
+	FullDays *bool `json:"fullDays,omitempty"`
+
+	// The time of day access is granted from, in the HH:MM format.
+	HoursFrom *string `json:"hoursFrom,omitempty"`
+
+	// The time of day access is granted until, in the HH:MM format.
+	HoursTo *string `json:"hoursTo,omitempty"`
+
+	// The IANA name of the time zone of the access window, (e.g.
+	// America/New_York).
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// DBAuth assigns database roles to the ephemeral users of databases.
+type DBAuth struct {
+
+	// The database roles assigned to the ephemeral users.
+	Roles []string `json:"roles,omitempty"`
+
+	// The databases the roles are assigned on.
+	ApplyTo []DBResource `json:"applyTo,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// DBProviderData are the databases of a database engine a policy grants access
+// to.
+type DBProviderData struct {
+
+	// The names of the databases.
+	Resources []string `json:"resources,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// DBResource identifies a database of a DB policy.
+type DBResource struct {
+
+	// The name of the database.
+	Name *string `json:"name,omitempty"`
+
+	// The database engine of the database, (e.g. postgres).
+	Type *string `json:"type,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// DBSecret is a database strong account's secret, used by DPA to provision
+// ephemeral users on databases. The sensitive members of the secret's data
+// are never returned.
+type DBSecret struct {
+
+	// The identifier of the secret.
+	SecretID *string `json:"secret_id,omitempty"`
+
+	// The name of the secret.
+	SecretName *string `json:"secret_name,omitempty"`
+
+	// The description of the secret.
+	Description *string `json:"description,omitempty"`
+
+	// The purpose of the secret.
+	Purpose *string `json:"purpose,omitempty"`
+
+	// The type of the secret.
+	SecretType DBSecretType `json:"secret_type,omitempty"`
+
+	// The details of the secret's data which are not sensitive, (e.g. the
+	// username).
+	SecretDetails map[string]string `json:"secret_details,omitempty"`
+
+	// Whether the secret is enabled, and can be used to provision ephemeral
+	// users.
+	IsActive *bool `json:"is_active,omitempty"`
+
+	// The tags of the secret.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// DBSecretData is the data of a database strong account's secret. The members
+// set depend on the type of the secret:
+//
+//   - username_password: Username, and Password.
+//   - iam_user: Account, Username, IAMAccessKeyID, and IAMSecretAccessKey.
+//   - atlas_access_keys: PublicKey, and PrivateKey.
+type DBSecretData struct {
+
+	// The username of the database user, or IAM user.
+	Username *string `json:"username,omitempty"`
+
+	// The password of the database user.
+	Password *string `json:"password,omitempty"`
+
+	// The identifier of the AWS account of the IAM user.
+	Account *string `json:"account,omitempty"`
+
+	// The access key ID of the IAM user.
+	IAMAccessKeyID *string `json:"iam_access_key_id,omitempty"`
+
+	// The secret access key of the IAM user.
+	IAMSecretAccessKey *string `json:"iam_secret_access_key,omitempty"`
+
+	// The public key of the MongoDB Atlas API key.
+	PublicKey *string `json:"public_key,omitempty"`
+
+	// The private key of the MongoDB Atlas API key.
+	PrivateKey *string `json:"private_key,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// Database is a database onboarded to DPA, that users connect to through DPA.
+type Database struct {
+
+	// The identifier of the database. Set by DPA when the database is added.
+	ID *int64 `json:"id,omitempty"`
+
+	// The name of the database.
+	//
+	// This member is required.
+	Name *string `json:"name,omitempty"`
+
+	// The engine of the database.
+	//
+	// This member is required.
+	Engine DatabaseEngine `json:"provider_engine,omitempty"`
+
+	// The platform the database is hosted on.
+	Platform DatabasePlatform `json:"platform,omitempty"`
+
+	// The port of the database. The engine's default port is used if not set.
+	Port *int32 `json:"port,omitempty"`
+
+	// The endpoint of the database's read write instance.
+	//
+	// This member is required.
+	ReadWriteEndpoint *string `json:"read_write_endpoint,omitempty"`
+
+	// The endpoint of the database's read only instance.
+	ReadOnlyEndpoint *string `json:"read_only_endpoint,omitempty"`
+
+	// The region of the database.
+	Region *string `json:"region,omitempty"`
+
+	// The name of the network the database is reachable from, served by the
+	// network's connectors.
+	NetworkName *string `json:"network_name,omitempty"`
+
+	// The method DPA authenticates users to the database with.
+	AuthMethod DatabaseAuthMethod `json:"configured_auth_method_type,omitempty"`
+
+	// The identifier of the strong account's secret bound to the database.
+	SecretID *string `json:"secret_id,omitempty"`
+
+	// The services of the database, (e.g. the Oracle service names).
+	Services []string `json:"services,omitempty"`
+
+	// The domain of the database, for databases authenticating Active
+	// Directory users.
+	Domain *string `json:"domain,omitempty"`
+
+	// Whether the database's certificate is validated.
+	EnableCertificateValidation *bool `json:"enable_certificate_validation,omitempty"`
+
+	// The tags of the database.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// EphemeralUser are the options of the ephemeral users provisioned for RDP
+// connections.
+type EphemeralUser struct {
+
+	// The groups the ephemeral user is assigned to.
+	AssignGroups []string `json:"assignGroups,omitempty"`
+
+	// Whether a disconnected user reconnects with the same ephemeral user.
+	EnableEphemeralUserReconnect *bool `json:"enableEphemeralUserReconnect,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// FQDNIPProviderData are the rules matching the FQDN and IP targets a policy
+// grants access to.
+type FQDNIPProviderData struct {
+
+	// The rules matching targets by FQDN.
+	FQDNRules []FQDNRule `json:"fqdnRules,omitempty"`
+
+	// The rules matching targets by IP address.
+	IPRules []IPRule `json:"ipRules,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// FQDNRule matches targets by FQDN.
+type FQDNRule struct {
+
+	// The operator the computer name pattern is matched with.
+	Operator RuleOperator `json:"operator,omitempty"`
+
+	// The computer name pattern of the targets.
+	ComputernamePattern *string `json:"computernamePattern,omitempty"`
+
+	// The domain of the targets.
+	Domain *string `json:"domain,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// GCPProviderData are the conditions matching the GCP instances a policy
+// grants access to.
+type GCPProviderData struct {
+
+	// The regions of the instances, (e.g. us-central1).
+	Regions []string `json:"regions,omitempty"`
+
+	// The labels of the instances.
+	Tags []Tag `json:"tags,omitempty"`
+
+	// The network of the instances.
+	Network *string `json:"network,omitempty"`
+
+	// The identifiers of the projects of the instances.
+	Projects []string `json:"projects,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// IPRule matches targets by IP address.
+type IPRule struct {
+
+	// The operator the IP addresses are matched with.
+	Operator RuleOperator `json:"operator,omitempty"`
+
+	// The IP addresses, or CIDR ranges, of the targets.
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// The logical name of the targets.
+	LogicalName *string `json:"logicalName,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// LDAPAuth assigns LDAP groups to the ephemeral users of databases.
+type LDAPAuth struct {
+
+	// The LDAP groups assigned to the ephemeral users.
+	AssignGroups []string `json:"assignGroups,omitempty"`
+
+	// The databases the groups are assigned on.
+	ApplyTo []DBResource `json:"applyTo,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// MFACachingSettings are the tenant settings of MFA caching, allowing users
+// to connect without authenticating again with the short-lived credentials
+// issued while their MFA is cached.
+type MFACachingSettings struct {
+
+	// Whether MFA caching is enabled.
+	Enabled *bool `json:"is_mfa_caching_enabled,omitempty"`
+
+	// The duration short-lived credentials issued with a cached MFA are valid
+	// for, in seconds.
+	KeyExpirationTime *int32 `json:"key_expiration_time_sec,omitempty"`
+
+	// Whether short-lived credentials may only be used from the IP address
+	// they were issued to.
+	ClientIPEnforced *bool `json:"client_ip_enforced,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// Policy is a DPA access policy, granting the principals of its access rules
+// access to the targets matched by its providers data. A VM policy matches
+// AWS, Azure, GCP, and FQDN/IP targets, and a DB policy matches databases.
+type Policy struct {
+
+	// The identifier of the policy. Set by DPA when the policy is added.
+	PolicyID *string `json:"policyId,omitempty"`
+
+	// The name of the policy.
+	//
+	// This member is required.
+	PolicyName *string `json:"policyName,omitempty"`
+
+	// The description of the policy.
+	Description *string `json:"description,omitempty"`
+
+	// The status of the policy.
+	Status PolicyStatus `json:"status,omitempty"`
+
+	// The date the policy grants access from, in the YYYY-MM-DD format.
+	StartDate *string `json:"startDate,omitempty"`
+
+	// The date the policy grants access until, in the YYYY-MM-DD format.
+	EndDate *string `json:"endDate,omitempty"`
+
+	// The conditions matching the targets the policy grants access to.
+	//
+	// This member is required.
+	ProvidersData *ProvidersData `json:"providersData,omitempty"`
+
+	// The rules granting principals access to the policy's targets.
+	//
+	// This member is required.
+	UserAccessRules []UserAccessRule `json:"userAccessRules,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// Principal is a user, group, or role granted access by an access rule.
+type Principal struct {
+
+	// The name of the principal.
+	Name *string `json:"name,omitempty"`
+
+	// The directory the principal is sourced from, (e.g. CyberArk Cloud
+	// Directory).
+	Source *string `json:"source,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// ProviderConnectAs is the identity users connect to a provider's targets as.
+type ProviderConnectAs struct {
+
+	// The user SSH connections are made as.
+	SSH *string `json:"ssh,omitempty"`
+
+	// The ephemeral users RDP connections are made as.
+	RDP *RDPConnectAs `json:"rdp,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// ProvidersData are the conditions matching the targets of a policy, by
+// provider. VM policies set the AWS, Azure, GCP, and FQDNIP members, and DB
+// policies set the database engine members.
+type ProvidersData struct {
+
+	// The conditions matching AWS instances.
+	AWS *AWSProviderData `json:"AWS,omitempty"`
+
+	// The conditions matching Azure virtual machines.
+	Azure *AzureProviderData `json:"Azure,omitempty"`
+
+	// The conditions matching GCP instances.
+	GCP *GCPProviderData `json:"GCP,omitempty"`
+
+	// The rules matching FQDN and IP targets.
+	FQDNIP *FQDNIPProviderData `json:"FQDN/IP,omitempty"`
+
+	// The MSSQL databases.
+	MSSQL *DBProviderData `json:"mssql,omitempty"`
+
+	// The MySQL databases.
+	MySQL *DBProviderData `json:"mysql,omitempty"`
+
+	// The MariaDB databases.
+	MariaDB *DBProviderData `json:"mariadb,omitempty"`
+
+	// The PostgreSQL databases.
+	Postgres *DBProviderData `json:"postgres,omitempty"`
+
+	// The Oracle databases.
+	Oracle *DBProviderData `json:"oracle,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// RDPConnectAs are the ephemeral users RDP connections are made as.
+type RDPConnectAs struct {
+
+	// The options of the local ephemeral users.
+	LocalEphemeralUser *EphemeralUser `json:"localEphemeralUser,omitempty"`
+
+	// The options of the domain ephemeral users.
+	DomainEphemeralUser *EphemeralUser `json:"domainEphemeralUser,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// RDPRecordingSettings are the tenant settings of the recording of RDP
+// sessions.
+type RDPRecordingSettings struct {
+
+	// Whether RDP sessions are recorded.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// SSHCAVersion is a version of the SSH certificate authority, signing the
+// certificates DPA connects to SSH targets with.
+type SSHCAVersion struct {
+
+	// The version of the certificate authority.
+	Version *int32 `json:"version,omitempty"`
+
+	// The OpenSSH encoded public key of the certificate authority.
+	PublicKey *string `json:"public_key,omitempty"`
+
+	// The status of the version.
+	Status SSHCAStatus `json:"status,omitempty"`
+
+	// The time the version was created.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// SSHCommandAuditSettings are the tenant settings of the audit of the commands
+// of SSH sessions.
+type SSHCommandAuditSettings struct {
+
+	// Whether the commands of SSH sessions are parsed for audit.
+	Enabled *bool `json:"is_command_parsing_for_audit_enabled,omitempty"`
+
+	// The regular expression matching the shell prompt of SSH sessions, used
+	// to parse commands.
+	ShellPrompt *string `json:"shell_prompt_for_audit,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// SSOCredentialsMetadata describes the lifetime of short-lived credentials.
+type SSOCredentialsMetadata struct {
+
+	// The time the credentials were created.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// The duration the credentials are valid for from their creation, in
+	// seconds.
+	ExpiresIn *int32 `json:"expires_in,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// Tag is a tag, or label, matching targets with the key and one of the values.
+type Tag struct {
+
+	// The key of the tag.
+	Key *string `json:"key,omitempty"`
+
+	// The values of the tag.
+	Value []string `json:"value,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// TargetSet is a set of targets DPA users can connect to, with the secret
+// used to provision ephemeral users on the targets.
+type TargetSet struct {
+
+	// The name of the target set, (e.g. the domain, DNS suffix, or target's
+	// FQDN or IP address).
+	//
+	// This member is required.
+	Name *string `json:"name,omitempty"`
+
+	// The description of the target set.
+	Description *string `json:"description,omitempty"`
+
+	// The format of the ephemeral user names provisioned on the target set's
+	// targets, (e.g. {prefix}{username}).
+	ProvisionFormat *string `json:"provision_format,omitempty"`
+
+	// Whether the certificates of the target set's targets are validated.
+	EnableCertificateValidation *bool `json:"enable_certificate_validation,omitempty"`
+
+	// The type of the secret associated with the target set.
+	SecretType SecretType `json:"secret_type,omitempty"`
+
+	// The identifier of the secret associated with the target set.
+	SecretID *string `json:"secret_id,omitempty"`
+
+	// The type of the targets matched by the target set.
+	//
+	// This member is required.
+	Type TargetSetType `json:"type,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// TargetSetMapping maps the target sets added in bulk to the strong account
+// associated with them.
+type TargetSetMapping struct {
+
+	// The identifier of the strong account associated with the target sets.
+	//
+	// This member is required.
+	StrongAccountID *string `json:"strong_account_id,omitempty"`
+
+	// The target sets associated with the strong account.
+	//
+	// This member is required.
+	TargetSets []TargetSet `json:"target_sets"`
+
+	noSmithyDocumentSerde
+}
+
+// TargetSetOperationResult is the result of adding, or deleting, a target set
+// in a bulk operation.
+type TargetSetOperationResult struct {
+
+	// The identifier of the strong account the target set is associated with.
+	StrongAccountID *string `json:"strong_account_id,omitempty"`
+
+	// The name of the target set.
+	TargetSetName *string `json:"target_set_name,omitempty"`
+
+	// Whether the operation succeeded for the target set.
+	Success *bool `json:"success,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// TenantSettings are the DPA settings of the tenant. Settings not set are
+// left unmodified when the settings are updated.
+type TenantSettings struct {
+
+	// The settings of MFA caching.
+	MFACaching *MFACachingSettings `json:"mfa_caching,omitempty"`
+
+	// The settings of the audit of SSH commands.
+	SSHCommandAudit *SSHCommandAuditSettings `json:"ssh_command_audit,omitempty"`
+
+	// The settings of the validation of targets' certificates.
+	CertificateValidation *CertificateValidationSettings `json:"certificate_validation,omitempty"`
+
+	// The settings of the recording of RDP sessions.
+	RDPRecording *RDPRecordingSettings `json:"rdp_recording,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// UserAccessRule grants principals access to a policy's targets.
+type UserAccessRule struct {
+
+	// The name of the rule.
+	//
+	// This member is required.
+	RuleName *string `json:"ruleName,omitempty"`
+
+	// The principals granted access.
+	//
+	// This member is required.
+	UserData *UserData `json:"userData,omitempty"`
+
+	// How, and when, the principals connect to the policy's targets.
+	//
+	// This member is required.
+	ConnectionInformation *ConnectionInformation `json:"connectionInformation,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// UserData are the principals granted access by an access rule.
+type UserData struct {
+
+	// The roles granted access.
+	Roles []Principal `json:"roles,omitempty"`
+
+	// The groups granted access.
+	Groups []Principal `json:"groups,omitempty"`
+
+	// The users granted access.
+	Users []Principal `json:"users,omitempty"`
+
+	noSmithyDocumentSerde
+}
@@ -0,0 +1,548 @@
+package dpa
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/service/dpa/types"
+)
+
+// validateOpInput is the OperationInputValidation middleware, validating the
+// operation's input parameters before the request is serialized.
+type validateOpInput struct {
+	validate func(input interface{}) error
+}
+
+// ID returns the middleware identifier.
+func (*validateOpInput) ID() string {
+	return "OperationInputValidation"
+}
+
+// HandleInitialize validates the operation's input parameters.
+func (m *validateOpInput) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	if err := m.validate(in.Parameters); err != nil {
+		return out, metadata, err
+	}
+	return next.HandleInitialize(ctx, in)
+}
+
+func addOpInputValidationMiddleware(stack *middleware.Stack, validate func(input interface{}) error) error {
+	return stack.Initialize.Add(&validateOpInput{validate: validate}, middleware.After)
+}
+
+func validateOpAddTargetSetInput(input interface{}) error {
+	v, ok := input.(*AddTargetSetInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "AddTargetSetInput"}
+	if v.TargetSet == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("TargetSet"))
+	} else if err := validateTargetSet(v.TargetSet); err != nil {
+		invalidParams.AddNested("TargetSet", *err)
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpGetTargetSetInput(input interface{}) error {
+	v, ok := input.(*GetTargetSetInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "GetTargetSetInput"}
+	if v.Name == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Name"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpUpdateTargetSetInput(input interface{}) error {
+	v, ok := input.(*UpdateTargetSetInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "UpdateTargetSetInput"}
+	if v.Name == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Name"))
+	}
+	if v.TargetSet == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("TargetSet"))
+	} else if err := validateTargetSet(v.TargetSet); err != nil {
+		invalidParams.AddNested("TargetSet", *err)
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpAddTargetSetsBulkInput(input interface{}) error {
+	v, ok := input.(*AddTargetSetsBulkInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "AddTargetSetsBulkInput"}
+	if v.TargetSetsMapping == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("TargetSetsMapping"))
+	}
+	for i := range v.TargetSetsMapping {
+		if err := validateTargetSetMapping(&v.TargetSetsMapping[i]); err != nil {
+			invalidParams.AddNested(fmt.Sprintf("TargetSetsMapping[%d]", i), *err)
+		}
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpDeleteTargetSetsBulkInput(input interface{}) error {
+	v, ok := input.(*DeleteTargetSetsBulkInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "DeleteTargetSetsBulkInput"}
+	if v.Names == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Names"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpAddPolicyInput(input interface{}) error {
+	v, ok := input.(*AddPolicyInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "AddPolicyInput"}
+	if v.Policy == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Policy"))
+	} else if err := validatePolicy(v.Policy); err != nil {
+		invalidParams.AddNested("Policy", *err)
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpGetPolicyInput(input interface{}) error {
+	v, ok := input.(*GetPolicyInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "GetPolicyInput"}
+	if v.PolicyID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("PolicyID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpUpdatePolicyInput(input interface{}) error {
+	v, ok := input.(*UpdatePolicyInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "UpdatePolicyInput"}
+	if v.PolicyID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("PolicyID"))
+	}
+	if v.Policy == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Policy"))
+	} else if err := validatePolicy(v.Policy); err != nil {
+		invalidParams.AddNested("Policy", *err)
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpDeletePolicyInput(input interface{}) error {
+	v, ok := input.(*DeletePolicyInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "DeletePolicyInput"}
+	if v.PolicyID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("PolicyID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpGetSSORDPFileInput(input interface{}) error {
+	v, ok := input.(*GetSSORDPFileInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "GetSSORDPFileInput"}
+	if v.TargetAddress == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("TargetAddress"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpGetSSODBTokenInput(input interface{}) error {
+	v, ok := input.(*GetSSODBTokenInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "GetSSODBTokenInput"}
+	if len(v.Engine) == 0 {
+		invalidParams.Add(cybr.NewErrParamRequired("Engine"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpAddDBSecretInput(input interface{}) error {
+	v, ok := input.(*AddDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "AddDBSecretInput"}
+	if v.SecretName == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("SecretName"))
+	}
+	if len(v.SecretType) == 0 {
+		invalidParams.Add(cybr.NewErrParamRequired("SecretType"))
+	}
+	if v.SecretData == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("SecretData"))
+	} else if err := validateDBSecretData(v.SecretType, v.SecretData); err != nil {
+		invalidParams.AddNested("SecretData", *err)
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpGetDBSecretInput(input interface{}) error {
+	v, ok := input.(*GetDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "GetDBSecretInput"}
+	if v.SecretID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("SecretID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpUpdateDBSecretInput(input interface{}) error {
+	v, ok := input.(*UpdateDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "UpdateDBSecretInput"}
+	if v.SecretID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("SecretID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpEnableDBSecretInput(input interface{}) error {
+	v, ok := input.(*EnableDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "EnableDBSecretInput"}
+	if v.SecretID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("SecretID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpDisableDBSecretInput(input interface{}) error {
+	v, ok := input.(*DisableDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "DisableDBSecretInput"}
+	if v.SecretID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("SecretID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpDeleteDBSecretInput(input interface{}) error {
+	v, ok := input.(*DeleteDBSecretInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "DeleteDBSecretInput"}
+	if v.SecretID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("SecretID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpAddDatabaseInput(input interface{}) error {
+	v, ok := input.(*AddDatabaseInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "AddDatabaseInput"}
+	if v.Database == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Database"))
+	} else if err := validateDatabase(v.Database); err != nil {
+		invalidParams.AddNested("Database", *err)
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpGetDatabaseInput(input interface{}) error {
+	v, ok := input.(*GetDatabaseInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "GetDatabaseInput"}
+	if v.ID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("ID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpUpdateDatabaseInput(input interface{}) error {
+	v, ok := input.(*UpdateDatabaseInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "UpdateDatabaseInput"}
+	if v.ID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("ID"))
+	}
+	if v.Database == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Database"))
+	} else if err := validateDatabase(v.Database); err != nil {
+		invalidParams.AddNested("Database", *err)
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpDeleteDatabaseInput(input interface{}) error {
+	v, ok := input.(*DeleteDatabaseInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "DeleteDatabaseInput"}
+	if v.ID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("ID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpGenerateConnectorSetupScriptInput(input interface{}) error {
+	v, ok := input.(*GenerateConnectorSetupScriptInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "GenerateConnectorSetupScriptInput"}
+	if len(v.ConnectorType) == 0 {
+		invalidParams.Add(cybr.NewErrParamRequired("ConnectorType"))
+	}
+	if len(v.ConnectorOS) == 0 {
+		invalidParams.Add(cybr.NewErrParamRequired("ConnectorOS"))
+	}
+	if v.ConnectorPoolID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("ConnectorPoolID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpUpdateTenantSettingsInput(input interface{}) error {
+	v, ok := input.(*UpdateTenantSettingsInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "UpdateTenantSettingsInput"}
+	if v.Settings == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Settings"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateDBSecretData(secretType types.DBSecretType, v *types.DBSecretData) *cybr.InvalidParamsError {
+	invalidParams := cybr.InvalidParamsError{Context: "DBSecretData"}
+	switch secretType {
+	case types.DBSecretTypeUsernamePassword:
+		if v.Username == nil {
+			invalidParams.Add(cybr.NewErrParamRequired("Username"))
+		}
+		if v.Password == nil {
+			invalidParams.Add(cybr.NewErrParamRequired("Password"))
+		}
+	case types.DBSecretTypeIAMUser:
+		if v.Account == nil {
+			invalidParams.Add(cybr.NewErrParamRequired("Account"))
+		}
+		if v.Username == nil {
+			invalidParams.Add(cybr.NewErrParamRequired("Username"))
+		}
+		if v.IAMAccessKeyID == nil {
+			invalidParams.Add(cybr.NewErrParamRequired("IAMAccessKeyID"))
+		}
+		if v.IAMSecretAccessKey == nil {
+			invalidParams.Add(cybr.NewErrParamRequired("IAMSecretAccessKey"))
+		}
+	case types.DBSecretTypeAtlasAccessKeys:
+		if v.PublicKey == nil {
+			invalidParams.Add(cybr.NewErrParamRequired("PublicKey"))
+		}
+		if v.PrivateKey == nil {
+			invalidParams.Add(cybr.NewErrParamRequired("PrivateKey"))
+		}
+	}
+	if invalidParams.Len() > 0 {
+		return &invalidParams
+	}
+	return nil
+}
+
+func validateDatabase(v *types.Database) *cybr.InvalidParamsError {
+	invalidParams := cybr.InvalidParamsError{Context: "Database"}
+	if v.Name == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Name"))
+	}
+	if len(v.Engine) == 0 {
+		invalidParams.Add(cybr.NewErrParamRequired("Engine"))
+	}
+	if v.ReadWriteEndpoint == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("ReadWriteEndpoint"))
+	}
+	if invalidParams.Len() > 0 {
+		return &invalidParams
+	}
+	return nil
+}
+
+func validatePolicy(v *types.Policy) *cybr.InvalidParamsError {
+	invalidParams := cybr.InvalidParamsError{Context: "Policy"}
+	if v.PolicyName == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("PolicyName"))
+	}
+	if v.ProvidersData == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("ProvidersData"))
+	}
+	if v.UserAccessRules == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("UserAccessRules"))
+	}
+	for i := range v.UserAccessRules {
+		if err := validateUserAccessRule(&v.UserAccessRules[i]); err != nil {
+			invalidParams.AddNested(fmt.Sprintf("UserAccessRules[%d]", i), *err)
+		}
+	}
+	if invalidParams.Len() > 0 {
+		return &invalidParams
+	}
+	return nil
+}
+
+func validateTargetSet(v *types.TargetSet) *cybr.InvalidParamsError {
+	invalidParams := cybr.InvalidParamsError{Context: "TargetSet"}
+	if v.Name == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Name"))
+	}
+	if len(v.Type) == 0 {
+		invalidParams.Add(cybr.NewErrParamRequired("Type"))
+	}
+	if invalidParams.Len() > 0 {
+		return &invalidParams
+	}
+	return nil
+}
+
+func validateTargetSetMapping(v *types.TargetSetMapping) *cybr.InvalidParamsError {
+	invalidParams := cybr.InvalidParamsError{Context: "TargetSetMapping"}
+	if v.StrongAccountID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("StrongAccountID"))
+	}
+	if v.TargetSets == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("TargetSets"))
+	}
+	for i := range v.TargetSets {
+		if err := validateTargetSet(&v.TargetSets[i]); err != nil {
+			invalidParams.AddNested(fmt.Sprintf("TargetSets[%d]", i), *err)
+		}
+	}
+	if invalidParams.Len() > 0 {
+		return &invalidParams
+	}
+	return nil
+}
+
+func validateUserAccessRule(v *types.UserAccessRule) *cybr.InvalidParamsError {
+	invalidParams := cybr.InvalidParamsError{Context: "UserAccessRule"}
+	if v.RuleName == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("RuleName"))
+	}
+	if v.UserData == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("UserData"))
+	}
+	if v.ConnectionInformation == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("ConnectionInformation"))
+	} else if v.ConnectionInformation.ConnectAs == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("ConnectionInformation.ConnectAs"))
+	}
+	if invalidParams.Len() > 0 {
+		return &invalidParams
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package pcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/discovery"
+	"github.com/strick-j/cybr-sdk-go/cybr/retry"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, optFns ...func(*Options)) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	options := Options{
+		BaseEndpoint: cybr.String(server.URL),
+		Credentials: cybr.CredentialsProviderFunc(func(context.Context) (cybr.Credentials, error) {
+			return cybr.Credentials{BearerToken: "token"}, nil
+		}),
+		Retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+			o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+				return 0, nil
+			})
+		}),
+	}
+
+	return New(options, optFns...)
+}
+
+func TestClient_InvokeOperationError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := "api/pcloud#"+goModuleVersion, r.Header.Get("X-Cybr-User-Agent")+r.Header.Get("User-Agent"); !strings.Contains(a, e) {
+			t.Errorf("expect user agent to contain %v, got %v", e, a)
+		}
+
+		w.Header().Set("X-Request-Id", "request-id")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"ErrorCode":"PASWS165E","ErrorMessage":"The account was not found."}`)
+	})
+
+	_, err := client.GetAccount(context.Background(), &GetAccountInput{AccountID: cybr.String("12_34")})
+	if err == nil {
+		t.Fatalf("expect error, got none")
+	}
+
+	var respErr *cybr.ResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("expect %T error, got %v", respErr, err)
+	}
+	if e, a := "request-id", respErr.ServiceRequestID(); e != a {
+		t.Errorf("expect %v request ID, got %v", e, a)
+	}
+
+	var apiErr *cybr.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expect %T error, got %v", apiErr, err)
+	}
+	if e, a := "PASWS165E", apiErr.Code; e != a {
+		t.Errorf("expect %v code, got %v", e, a)
+	}
+	if e, a := "The account was not found.", apiErr.Message; e != a {
+		t.Errorf("expect %v message, got %v", e, a)
+	}
+}
+
+func TestClient_ResolveEndpoint(t *testing.T) {
+	var discoveryRequests int
+	discoveryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		discoveryRequests++
+		switch r.URL.Path {
+		case "/api/v2/services/subdomain/example":
+			fmt.Fprint(w, `{
+				"identity_administration": {"api": "https://aa1234.id.cyberark.cloud", "region": "eu-central-1"},
+				"pcloud": {"api": "https://example.privilegecloud.cyberark.cloud.eu/api"}
+			}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer discoveryServer.Close()
+
+	discoveryClient := discovery.New(func(o *discovery.Options) {
+		o.Endpoint = discoveryServer.URL
+	})
+
+	cases := map[string]struct {
+		Options                 Options
+		ExpectURL               string
+		ExpectDiscoveryRequests int
+	}{
+		"tenant": {
+			Options:   Options{TenantName: "Example", DisableEndpointDiscovery: true},
+			ExpectURL: "https://example.privilegecloud.cyberark.cloud/PasswordVault/API/Accounts/12_34",
+		},
+		"discovered tenant": {
+			Options:                 Options{TenantName: "Example", DiscoveryClient: discoveryClient},
+			ExpectURL:               "https://example.privilegecloud.cyberark.cloud.eu/PasswordVault/API/Accounts/12_34",
+			ExpectDiscoveryRequests: 1,
+		},
+		"discovery fallback": {
+			Options:                 Options{TenantName: "Unknown", DiscoveryClient: discoveryClient},
+			ExpectURL:               "https://unknown.privilegecloud.cyberark.cloud/PasswordVault/API/Accounts/12_34",
+			ExpectDiscoveryRequests: 1,
+		},
+		"base endpoint": {
+			Options: Options{
+				TenantName:      "example",
+				BaseEndpoint:    cybr.String("https://pvwa.example.com"),
+				DiscoveryClient: discoveryClient,
+			},
+			ExpectURL: "https://pvwa.example.com/PasswordVault/API/Accounts/12_34",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			discoveryRequests = 0
+			discoveryClient.Invalidate("example")
+
+			var actualURL string
+			client := New(c.Options, WithAPIOptions(func(stack *middleware.Stack) error {
+				return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("captureURL",
+					func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+						middleware.FinalizeOutput, middleware.Metadata, error,
+					) {
+						actualURL = in.Request.(*smithyhttp.Request).URL.String()
+						return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("stop")
+					}), middleware.After)
+			}))
+
+			client.GetAccount(context.Background(), &GetAccountInput{AccountID: cybr.String("12_34")},
+				func(o *Options) {
+					o.Retryer = cybr.NopRetryer{}
+				})
+
+			if e, a := c.ExpectURL, actualURL; e != a {
+				t.Errorf("expect %v URL, got %v", e, a)
+			}
+			if e, a := c.ExpectDiscoveryRequests, discoveryRequests; e != a {
+				t.Errorf("expect %v discovery requests, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestNewFromConfig_EndpointResolverWithOptions(t *testing.T) {
+	cases := map[string]struct {
+		Err       error
+		ExpectURL string
+		ExpectErr bool
+	}{
+		"custom endpoint": {
+			ExpectURL: "https://pvwa.example.com",
+		},
+		"fallback on not found": {
+			Err:       &cybr.EndpointNotFoundError{Err: fmt.Errorf("not found")},
+			ExpectURL: "https://example.privilegecloud.cyberark.cloud",
+		},
+		"resolver error": {
+			Err:       fmt.Errorf("resolver failed"),
+			ExpectErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := cybr.Config{
+				TenantName: "example",
+				EndpointResolverWithOptions: cybr.EndpointResolverWithOptionsFunc(
+					func(service string, options ...interface{}) (cybr.Endpoint, error) {
+						if e, a := ServiceID, service; e != a {
+							t.Errorf("expect %v service, got %v", e, a)
+						}
+						if c.Err != nil {
+							return cybr.Endpoint{}, c.Err
+						}
+						return cybr.Endpoint{URL: "https://pvwa.example.com", Source: cybr.EndpointSourceCustom}, nil
+					}),
+			}
+
+			options := NewFromConfig(cfg).Options()
+			endpoint, err := options.EndpointResolver.ResolveEndpoint(EndpointResolverOptions{
+				TenantName: options.TenantName,
+			})
+			if (err != nil) != c.ExpectErr {
+				t.Fatalf("expect error %v, got %v", c.ExpectErr, err)
+			}
+			if e, a := c.ExpectURL, endpoint.URL; e != a {
+				t.Errorf("expect %v URL, got %v", e, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,388 @@
+package pcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/service/pcloud/types"
+)
+
+const testAccountJSON = `{
+	"id": "12_34",
+	"name": "Operating System-UnixSSH-10.0.0.10-root",
+	"address": "10.0.0.10",
+	"userName": "root",
+	"platformId": "UnixSSH",
+	"safeName": "Linux-Prod",
+	"secretType": "password",
+	"platformAccountProperties": {"Port": "22"},
+	"secretManagement": {"automaticManagementEnabled": true, "status": "success", "lastModifiedTime": 1714557600},
+	"remoteMachinesAccess": {"remoteMachines": "10.0.0.11;10.0.0.12", "accessRestrictedToRemoteMachines": true},
+	"createdTime": 1714554000
+}`
+
+var testAccount = &types.Account{
+	ID:                        cybr.String("12_34"),
+	Name:                      cybr.String("Operating System-UnixSSH-10.0.0.10-root"),
+	Address:                   cybr.String("10.0.0.10"),
+	UserName:                  cybr.String("root"),
+	PlatformID:                cybr.String("UnixSSH"),
+	SafeName:                  cybr.String("Linux-Prod"),
+	SecretType:                types.SecretTypePassword,
+	PlatformAccountProperties: map[string]string{"Port": "22"},
+	SecretManagement: &types.SecretManagement{
+		AutomaticManagementEnabled: cybr.Bool(true),
+		Status:                     cybr.String("success"),
+		LastModifiedTime:           cybr.Int64(1714557600),
+	},
+	RemoteMachinesAccess: &types.RemoteMachinesAccess{
+		RemoteMachines:                   cybr.String("10.0.0.11;10.0.0.12"),
+		AccessRestrictedToRemoteMachines: cybr.Bool(true),
+	},
+	CreatedTime: cybr.Int64(1714554000),
+}
+
+func TestClient_ListAccounts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if e, a := http.MethodGet, r.Method; e != a {
+			t.Errorf("expect %v method, got %v", e, a)
+		}
+		if e, a := "/PasswordVault/API/Accounts", r.URL.Path; e != a {
+			t.Errorf("expect %v path, got %v", e, a)
+		}
+
+		expectQuery := map[string]string{
+			"search":     "root 10.0.0.10",
+			"searchType": "startswith",
+			"filter":     "safeName eq Linux-Prod AND modificationTime gte 1714554000",
+			"sort":       "userName desc,address",
+			"offset":     "10",
+			"limit":      "5",
+		}
+		for k, e := range expectQuery {
+			if a := r.URL.Query().Get(k); e != a {
+				t.Errorf("expect %v %v query, got %v", e, k, a)
+			}
+		}
+
+		fmt.Fprint(w, `{"value":[`+testAccountJSON+`],"count":1}`)
+	})
+
+	out, err := client.ListAccounts(context.Background(), &ListAccountsInput{
+		Search:           cybr.String("root 10.0.0.10"),
+		SearchType:       types.SearchTypeStartsWith,
+		SafeName:         cybr.String("Linux-Prod"),
+		ModificationTime: cybr.Time(time.Unix(1714554000, 0)),
+		Sort:             []string{"userName desc", "address"},
+		Offset:           cybr.Int32(10),
+		Limit:            cybr.Int32(5),
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if e, a := []types.Account{*testAccount}, out.Value; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %#v, got %#v", e, a)
+	}
+	if e, a := int32(1), cybr.ToInt32(out.Count); e != a {
+		t.Errorf("expect %v count, got %v", e, a)
+	}
+	if out.NextLink != nil {
+		t.Errorf("expect no next link, got %v", *out.NextLink)
+	}
+}
+
+func TestClient_AccountOperations(t *testing.T) {
+	cases := map[string]struct {
+		Invoke            func(context.Context, *Client) (interface{}, error)
+		ExpectMethod      string
+		ExpectPath        string
+		ExpectContentType string
+		ExpectBody        string
+		ResponseBody      string
+		Expect            interface{}
+	}{
+		"GetAccount": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.GetAccount(ctx, &GetAccountInput{AccountID: cybr.String("12_34")})
+				if err != nil {
+					return nil, err
+				}
+				return out.Account, nil
+			},
+			ExpectMethod: http.MethodGet,
+			ExpectPath:   "/PasswordVault/API/Accounts/12_34",
+			ResponseBody: testAccountJSON,
+			Expect:       testAccount,
+		},
+		"AddAccount": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.AddAccount(ctx, &AddAccountInput{
+					SafeName:                  cybr.String("Linux-Prod"),
+					PlatformID:                cybr.String("UnixSSH"),
+					Address:                   cybr.String("10.0.0.10"),
+					UserName:                  cybr.String("root"),
+					SecretType:                types.SecretTypePassword,
+					Secret:                    cybr.String("password"),
+					PlatformAccountProperties: map[string]string{"Port": "22"},
+					SecretManagement: &types.SecretManagement{
+						AutomaticManagementEnabled: cybr.Bool(true),
+					},
+					RemoteMachinesAccess: &types.RemoteMachinesAccess{
+						RemoteMachines:                   cybr.String("10.0.0.11;10.0.0.12"),
+						AccessRestrictedToRemoteMachines: cybr.Bool(true),
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.Account, nil
+			},
+			ExpectMethod:      http.MethodPost,
+			ExpectPath:        "/PasswordVault/API/Accounts",
+			ExpectContentType: "application/json",
+			ExpectBody: `{
+				"safeName": "Linux-Prod",
+				"platformId": "UnixSSH",
+				"address": "10.0.0.10",
+				"userName": "root",
+				"secretType": "password",
+				"secret": "password",
+				"platformAccountProperties": {"Port": "22"},
+				"secretManagement": {"automaticManagementEnabled": true},
+				"remoteMachinesAccess": {"remoteMachines": "10.0.0.11;10.0.0.12", "accessRestrictedToRemoteMachines": true}
+			}`,
+			ResponseBody: testAccountJSON,
+			Expect:       testAccount,
+		},
+		"UpdateAccount": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				out, err := c.UpdateAccount(ctx, &UpdateAccountInput{
+					AccountID: cybr.String("12_34"),
+					Operations: []types.PatchOperation{
+						{Op: types.PatchOperationTypeReplace, Path: cybr.String("/address"), Value: "10.0.0.10"},
+						{Op: types.PatchOperationTypeReplace, Path: cybr.String("/secretManagement/automaticManagementEnabled"), Value: false},
+						{Op: types.PatchOperationTypeRemove, Path: cybr.String("/platformAccountProperties/Location")},
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return out.Account, nil
+			},
+			ExpectMethod:      http.MethodPatch,
+			ExpectPath:        "/PasswordVault/API/Accounts/12_34",
+			ExpectContentType: "application/json-patch+json",
+			ExpectBody: `[
+				{"op": "replace", "path": "/address", "value": "10.0.0.10"},
+				{"op": "replace", "path": "/secretManagement/automaticManagementEnabled", "value": false},
+				{"op": "remove", "path": "/platformAccountProperties/Location"}
+			]`,
+			ResponseBody: testAccountJSON,
+			Expect:       testAccount,
+		},
+		"DeleteAccount": {
+			Invoke: func(ctx context.Context, c *Client) (interface{}, error) {
+				_, err := c.DeleteAccount(ctx, &DeleteAccountInput{AccountID: cybr.String("12_34")})
+				return nil, err
+			},
+			ExpectMethod: http.MethodDelete,
+			ExpectPath:   "/PasswordVault/API/Accounts/12_34",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if e, a := c.ExpectMethod, r.Method; e != a {
+					t.Errorf("expect %v method, got %v", e, a)
+				}
+				if e, a := c.ExpectPath, r.URL.Path; e != a {
+					t.Errorf("expect %v path, got %v", e, a)
+				}
+				if e, a := c.ExpectContentType, r.Header.Get("Content-Type"); e != a {
+					t.Errorf("expect %v content type, got %v", e, a)
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("expect no error reading body, got %v", err)
+				}
+				if len(c.ExpectBody) != 0 {
+					assertJSONEqual(t, c.ExpectBody, string(body))
+				} else if len(body) != 0 {
+					t.Errorf("expect no body, got %s", body)
+				}
+
+				if len(c.ResponseBody) == 0 {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				fmt.Fprint(w, c.ResponseBody)
+			})
+
+			actual, err := c.Invoke(context.Background(), client)
+			if err != nil {
+				t.Fatalf("expect no error, got %v", err)
+			}
+			if c.Expect == nil {
+				return
+			}
+			if !reflect.DeepEqual(c.Expect, actual) {
+				t.Errorf("expect %#v, got %#v", c.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestClient_AccountOperationsValidation(t *testing.T) {
+	cases := map[string]struct {
+		Invoke       func(context.Context, *Client) error
+		ExpectFields []string
+	}{
+		"AddAccount": {
+			Invoke: func(ctx context.Context, c *Client) error {
+				_, err := c.AddAccount(ctx, nil)
+				return err
+			},
+			ExpectFields: []string{"AddAccountInput.SafeName", "AddAccountInput.PlatformID"},
+		},
+		"GetAccount": {
+			Invoke: func(ctx context.Context, c *Client) error {
+				_, err := c.GetAccount(ctx, nil)
+				return err
+			},
+			ExpectFields: []string{"GetAccountInput.AccountID"},
+		},
+		"UpdateAccount": {
+			Invoke: func(ctx context.Context, c *Client) error {
+				_, err := c.UpdateAccount(ctx, &UpdateAccountInput{
+					AccountID:  cybr.String("12_34"),
+					Operations: []types.PatchOperation{{Path: cybr.String("/address")}},
+				})
+				return err
+			},
+			ExpectFields: []string{"UpdateAccountInput.Operations[0].Op"},
+		},
+		"DeleteAccount": {
+			Invoke: func(ctx context.Context, c *Client) error {
+				_, err := c.DeleteAccount(ctx, nil)
+				return err
+			},
+			ExpectFields: []string{"DeleteAccountInput.AccountID"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("expect no request to be sent")
+			})
+
+			err := c.Invoke(context.Background(), client)
+			if err == nil {
+				t.Fatalf("expect error, got none")
+			}
+
+			var invalidParams cybr.InvalidParamsError
+			if !errors.As(err, &invalidParams) {
+				t.Fatalf("expect %T error, got %v", invalidParams, err)
+			}
+
+			var fields []string
+			for _, paramErr := range invalidParams.Errs() {
+				fields = append(fields, paramErr.(cybr.InvalidParamError).Field())
+			}
+			if e, a := c.ExpectFields, fields; !reflect.DeepEqual(e, a) {
+				t.Errorf("expect %v fields, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestListAccountsPaginator(t *testing.T) {
+	pages := map[string]string{
+		"":  `{"value":[{"id":"1_1"},{"id":"1_2"}],"count":3,"nextLink":"api/accounts?search=root&offset=2&limit=2"}`,
+		"2": `{"value":[{"id":"1_3"}],"count":3}`,
+	}
+
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		if e, a := "root", query.Get("search"); e != a {
+			t.Errorf("expect %v search, got %v", e, a)
+		}
+		if e, a := "2", query.Get("limit"); e != a {
+			t.Errorf("expect %v limit, got %v", e, a)
+		}
+
+		page, ok := pages[query.Get("offset")]
+		if !ok {
+			t.Fatalf("unexpected offset %v", query.Get("offset"))
+		}
+		fmt.Fprint(w, page)
+	})
+
+	paginator := NewListAccountsPaginator(client, &ListAccountsInput{
+		Search: cybr.String("root"),
+		Limit:  cybr.Int32(2),
+	})
+
+	var ids []string
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		for _, account := range page.Value {
+			ids = append(ids, cybr.ToString(account.ID))
+		}
+	}
+
+	if e, a := []string{"1_1", "1_2", "1_3"}, ids; !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v accounts, got %v", e, a)
+	}
+	if e, a := 2, requests; e != a {
+		t.Errorf("expect %v requests, got %v", e, a)
+	}
+}
+
+func TestListAccountsPaginator_InvalidNextLink(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value":[],"nextLink":"api/accounts?limit=2"}`)
+	})
+
+	paginator := NewListAccountsPaginator(client, nil)
+	if _, err := paginator.NextPage(context.Background()); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	_, err := paginator.NextPage(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "has no offset") {
+		t.Errorf("expect next link offset error, got %v", err)
+	}
+}
+
+func assertJSONEqual(t *testing.T, expect, actual string) {
+	t.Helper()
+
+	var e, a interface{}
+	if err := json.Unmarshal([]byte(expect), &e); err != nil {
+		t.Fatalf("expect valid JSON %v, got %v", expect, err)
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(actual)), &a); err != nil {
+		t.Fatalf("expect valid JSON body, got %v, %v", actual, err)
+	}
+	if !reflect.DeepEqual(e, a) {
+		t.Errorf("expect %v JSON, got %v", expect, actual)
+	}
+}
@@ -0,0 +1,100 @@
+package pcloud
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/pcloud/types"
+)
+
+// Adds an account to a safe. The account's secret is managed by the CPM, as
+// configured by the account's platform, unless automatic management is
+// disabled.
+func (c *Client) AddAccount(ctx context.Context, params *AddAccountInput, optFns ...func(*Options)) (*AddAccountOutput, error) {
+	if params == nil {
+		params = &AddAccountInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "AddAccount", params, optFns, c.addOperationAddAccountMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*AddAccountOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type AddAccountInput struct {
+	// The name of the safe the account is stored in.
+	//
+	// This member is required.
+	SafeName *string `json:"safeName,omitempty"`
+
+	// The identifier of the platform managing the account.
+	//
+	// This member is required.
+	PlatformID *string `json:"platformId,omitempty"`
+
+	// The name of the account. Generated from the account's properties if not
+	// set.
+	Name *string `json:"name,omitempty"`
+
+	// The address of the machine the account is used on.
+	Address *string `json:"address,omitempty"`
+
+	// The username of the account.
+	UserName *string `json:"userName,omitempty"`
+
+	// The type of the account's secret.
+	SecretType types.SecretType `json:"secretType,omitempty"`
+
+	// The account's secret, (e.g. the password, or SSH private key).
+	Secret *string `json:"secret,omitempty"`
+
+	// The values of the platform's account properties, by property name.
+	PlatformAccountProperties map[string]string `json:"platformAccountProperties,omitempty"`
+
+	// The management of the account's secret by the CPM.
+	SecretManagement *types.SecretManagement `json:"secretManagement,omitempty"`
+
+	// The machines the account is restricted to.
+	RemoteMachinesAccess *types.RemoteMachinesAccess `json:"remoteMachinesAccess,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+type AddAccountOutput struct {
+	// The account added.
+	Account *types.Account
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationAddAccountMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method: http.MethodPost,
+		URI:    "/PasswordVault/API/Accounts",
+		Body:   restjson_serializeOpDocumentAddAccountInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpAddAccount,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "AddAccount"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpAddAccountInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package pcloud
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Deletes the account with the identifier.
+func (c *Client) DeleteAccount(ctx context.Context, params *DeleteAccountInput, optFns ...func(*Options)) (*DeleteAccountOutput, error) {
+	if params == nil {
+		params = &DeleteAccountInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "DeleteAccount", params, optFns, c.addOperationDeleteAccountMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*DeleteAccountOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type DeleteAccountInput struct {
+	// The identifier of the account.
+	//
+	// This member is required.
+	AccountID *string `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+type DeleteAccountOutput struct {
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationDeleteAccountMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodDelete,
+		URI:       "/PasswordVault/API/Accounts/{accountId}",
+		BindInput: restjson_serializeOpHttpBindingsDeleteAccountInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpDeleteAccount,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "DeleteAccount"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpDeleteAccountInput); err != nil {
+		return err
+	}
+	return nil
+}
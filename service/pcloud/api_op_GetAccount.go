@@ -0,0 +1,68 @@
+package pcloud
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/pcloud/types"
+)
+
+// Returns the account with the identifier.
+func (c *Client) GetAccount(ctx context.Context, params *GetAccountInput, optFns ...func(*Options)) (*GetAccountOutput, error) {
+	if params == nil {
+		params = &GetAccountInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "GetAccount", params, optFns, c.addOperationGetAccountMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*GetAccountOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type GetAccountInput struct {
+	// The identifier of the account.
+	//
+	// This member is required.
+	AccountID *string `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+type GetAccountOutput struct {
+	// The account.
+	Account *types.Account
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationGetAccountMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodGet,
+		URI:       "/PasswordVault/API/Accounts/{accountId}",
+		BindInput: restjson_serializeOpHttpBindingsGetAccountInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpGetAccount,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "GetAccount"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpGetAccountInput); err != nil {
+		return err
+	}
+	return nil
+}
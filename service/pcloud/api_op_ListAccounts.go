@@ -0,0 +1,208 @@
+package pcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/pcloud/types"
+)
+
+// Lists the accounts the caller has access to, matching the search and filters.
+// The accounts are returned in pages, use the ListAccountsPaginator to follow
+// the next link of each page.
+func (c *Client) ListAccounts(ctx context.Context, params *ListAccountsInput, optFns ...func(*Options)) (*ListAccountsOutput, error) {
+	if params == nil {
+		params = &ListAccountsInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "ListAccounts", params, optFns, c.addOperationListAccountsMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*ListAccountsOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type ListAccountsInput struct {
+	// The keywords matched with the accounts' properties, separated by spaces.
+	Search *string
+
+	// How the search keywords are matched. Defaults to contains if not set.
+	SearchType types.SearchType
+
+	// Filters the accounts stored in the safe.
+	SafeName *string
+
+	// Filters the accounts modified at, or after, the time.
+	ModificationTime *time.Time
+
+	// The properties the accounts are sorted by, each optionally followed by
+	// the asc, or desc, order, (e.g. "userName desc").
+	Sort []string
+
+	// The number of accounts skipped before the first account returned.
+	Offset *int32
+
+	// The maximum number of accounts to return.
+	Limit *int32
+
+	noSmithyDocumentSerde
+}
+
+type ListAccountsOutput struct {
+	// The accounts of the page.
+	Value []types.Account `json:"value"`
+
+	// The total number of accounts matching the search and filters.
+	Count *int32 `json:"count"`
+
+	// The link of the next page, or nil if there are no more pages.
+	NextLink *string `json:"nextLink"`
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationListAccountsMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:    http.MethodGet,
+		URI:       "/PasswordVault/API/Accounts",
+		BindInput: restjson_serializeOpHttpBindingsListAccountsInput,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpListAccounts,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "ListAccounts"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListAccountsAPIClient is a client that implements the ListAccounts operation.
+type ListAccountsAPIClient interface {
+	ListAccounts(context.Context, *ListAccountsInput, ...func(*Options)) (*ListAccountsOutput, error)
+}
+
+var _ ListAccountsAPIClient = (*Client)(nil)
+
+// ListAccountsPaginatorOptions is the paginator options for ListAccounts
+type ListAccountsPaginatorOptions struct {
+	// The maximum number of accounts to return in a page.
+	Limit int32
+
+	// Set to true if pagination should stop if the service returns a next
+	// link that matches the most recent next link followed.
+	StopOnDuplicateToken bool
+}
+
+// ListAccountsPaginator is a paginator for ListAccounts. The paginator follows
+// the next link returned with each page, until no next link is returned.
+type ListAccountsPaginator struct {
+	options   ListAccountsPaginatorOptions
+	client    ListAccountsAPIClient
+	params    *ListAccountsInput
+	nextLink  *string
+	firstPage bool
+}
+
+// NewListAccountsPaginator returns a new ListAccountsPaginator
+func NewListAccountsPaginator(client ListAccountsAPIClient, params *ListAccountsInput, optFns ...func(*ListAccountsPaginatorOptions)) *ListAccountsPaginator {
+	if params == nil {
+		params = &ListAccountsInput{}
+	}
+
+	options := ListAccountsPaginatorOptions{}
+	if params.Limit != nil {
+		options.Limit = *params.Limit
+	}
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	return &ListAccountsPaginator{
+		options:   options,
+		client:    client,
+		params:    params,
+		firstPage: true,
+	}
+}
+
+// HasMorePages returns a boolean indicating whether more pages are available
+func (p *ListAccountsPaginator) HasMorePages() bool {
+	return p.firstPage || (p.nextLink != nil && len(*p.nextLink) != 0)
+}
+
+// NextPage retrieves the next ListAccounts page.
+func (p *ListAccountsPaginator) NextPage(ctx context.Context, optFns ...func(*Options)) (*ListAccountsOutput, error) {
+	if !p.HasMorePages() {
+		return nil, fmt.Errorf("no more pages available")
+	}
+
+	params := *p.params
+	if !p.firstPage {
+		offset, err := nextLinkOffset(*p.nextLink)
+		if err != nil {
+			return nil, err
+		}
+		params.Offset = &offset
+	}
+
+	var limit *int32
+	if p.options.Limit > 0 {
+		limit = &p.options.Limit
+	}
+	params.Limit = limit
+
+	result, err := p.client.ListAccounts(ctx, &params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	p.firstPage = false
+
+	prevLink := p.nextLink
+	p.nextLink = result.NextLink
+
+	if p.options.StopOnDuplicateToken &&
+		prevLink != nil &&
+		p.nextLink != nil &&
+		*prevLink == *p.nextLink {
+		p.nextLink = nil
+	}
+
+	return result, nil
+}
+
+// nextLinkOffset returns the offset of the page the next link refers to.
+func nextLinkOffset(nextLink string) (int32, error) {
+	u, err := url.Parse(nextLink)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse next link %q, %w", nextLink, err)
+	}
+
+	v := u.Query().Get("offset")
+	if len(v) == 0 {
+		return 0, fmt.Errorf("next link %q has no offset", nextLink)
+	}
+	offset, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse next link %q offset, %w", nextLink, err)
+	}
+
+	return int32(offset), nil
+}
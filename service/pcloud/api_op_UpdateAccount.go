@@ -0,0 +1,77 @@
+package pcloud
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/service/pcloud/types"
+)
+
+// Updates the properties of the account with the identifier, with JSON Patch
+// operations. The operations are applied in order, and the account is only
+// updated if all operations succeed.
+func (c *Client) UpdateAccount(ctx context.Context, params *UpdateAccountInput, optFns ...func(*Options)) (*UpdateAccountOutput, error) {
+	if params == nil {
+		params = &UpdateAccountInput{}
+	}
+
+	result, metadata, err := c.invokeOperation(ctx, "UpdateAccount", params, optFns, c.addOperationUpdateAccountMiddlewares)
+	if err != nil {
+		return nil, err
+	}
+
+	out := result.(*UpdateAccountOutput)
+	out.ResultMetadata = metadata
+	return out, nil
+}
+
+type UpdateAccountInput struct {
+	// The identifier of the account.
+	//
+	// This member is required.
+	AccountID *string `json:"-"`
+
+	// The JSON Patch operations applied to the account.
+	//
+	// This member is required.
+	Operations []types.PatchOperation `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+type UpdateAccountOutput struct {
+	// The updated account.
+	Account *types.Account
+
+	// Metadata pertaining to the operation's result.
+	ResultMetadata middleware.Metadata `json:"-"`
+
+	noSmithyDocumentSerde
+}
+
+func (c *Client) addOperationUpdateAccountMiddlewares(stack *middleware.Stack, options Options) (err error) {
+	err = stack.Serialize.Add(&restjson_serializeOp{
+		Method:      http.MethodPatch,
+		URI:         "/PasswordVault/API/Accounts/{accountId}",
+		BindInput:   restjson_serializeOpHttpBindingsUpdateAccountInput,
+		Body:        restjson_serializeOpDocumentUpdateAccountInput,
+		ContentType: "application/json-patch+json",
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	err = stack.Deserialize.Add(&restjson_deserializeOp{
+		Output: restjson_deserializeOpUpdateAccount,
+	}, middleware.After)
+	if err != nil {
+		return err
+	}
+	if err = addOperationMiddlewares(stack, options, "UpdateAccount"); err != nil {
+		return err
+	}
+	if err = addOpInputValidationMiddleware(stack, validateOpUpdateAccountInput); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package pcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	cybrmiddleware "github.com/strick-j/cybr-sdk-go/cybr/middleware"
+	"github.com/strick-j/cybr-sdk-go/cybr/protocol/restjson"
+)
+
+// restjson_deserializeOp is the OperationDeserializer of the client's REST
+// JSON operations. Error responses are deserialized into a *cybr.APIError,
+// otherwise the response body is deserialized into the operation's output by
+// Output.
+type restjson_deserializeOp struct {
+	// Output returns the operation's output deserialized from the response
+	// body. The body is empty if the response did not include one.
+	Output func(body []byte) (interface{}, error)
+}
+
+// ID returns the middleware identifier.
+func (*restjson_deserializeOp) ID() string {
+	return "OperationDeserializer"
+}
+
+// HandleDeserialize deserializes the HTTP response into the operation's
+// output, or error.
+func (m *restjson_deserializeOp) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	out middleware.DeserializeOutput, metadata middleware.Metadata, err error,
+) {
+	out, metadata, err = next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, metadata, err
+	}
+
+	response, ok := out.RawResponse.(*smithyhttp.Response)
+	if !ok {
+		return out, metadata, &smithy.DeserializationError{Err: fmt.Errorf("unknown transport type %T", out.RawResponse)}
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return out, metadata, restjson_deserializeOpError(response, &metadata)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return out, metadata, &smithy.DeserializationError{Err: fmt.Errorf("failed to read response body, %w", err)}
+	}
+
+	out.Result, err = m.Output(body)
+	if err != nil {
+		return out, metadata, &smithy.DeserializationError{
+			Err:      fmt.Errorf("failed to decode response body, %w", err),
+			Snapshot: body,
+		}
+	}
+
+	return out, metadata, nil
+}
+
+// restjson_deserializeDocument decodes the JSON document of the response body
+// into v. An empty body leaves v unmodified.
+func restjson_deserializeDocument(body []byte, v interface{}) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, v)
+}
+
+// restjson_deserializeOpError returns the error for the operation's error
+// response, decoded from the JSON error response body.
+func restjson_deserializeOpError(response *smithyhttp.Response, metadata *middleware.Metadata) error {
+	var errorBuffer bytes.Buffer
+	if _, err := io.Copy(&errorBuffer, response.Body); err != nil {
+		return &smithy.DeserializationError{Err: fmt.Errorf("failed to copy error response body, %w", err)}
+	}
+	errorBody := bytes.NewReader(errorBuffer.Bytes())
+
+	decoder := json.NewDecoder(errorBody)
+	decoder.UseNumber()
+	info, err := restjson.DecodeErrorInfo(decoder)
+	if err != nil {
+		return &smithy.DeserializationError{
+			Err:      fmt.Errorf("failed to decode response body, %w", err),
+			Snapshot: errorBuffer.Bytes(),
+		}
+	}
+
+	// The request ID found in the body is only used if the request ID was not
+	// returned in the response headers.
+	if len(info.RequestID) != 0 {
+		if _, ok := cybrmiddleware.GetRequestIDMetadata(*metadata); !ok {
+			cybrmiddleware.SetRequestIDMetadata(metadata, info.RequestID)
+		}
+	}
+
+	errorCode := "UnknownError"
+	if len(info.Code) != 0 {
+		errorCode = info.Code
+	}
+	errorMessage := errorCode
+	if len(info.Message) != 0 {
+		errorMessage = info.Message
+	}
+
+	fault := smithy.FaultClient
+	if response.StatusCode >= 500 {
+		fault = smithy.FaultServer
+	}
+
+	return &cybr.APIError{
+		Code:    errorCode,
+		Message: errorMessage,
+		Details: info.Details,
+		Fault:   fault,
+	}
+}
+
+func restjson_deserializeOpListAccounts(body []byte) (interface{}, error) {
+	output := &ListAccountsOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
+
+func restjson_deserializeOpGetAccount(body []byte) (interface{}, error) {
+	output := &GetAccountOutput{}
+	return output, restjson_deserializeDocument(body, &output.Account)
+}
+
+func restjson_deserializeOpAddAccount(body []byte) (interface{}, error) {
+	output := &AddAccountOutput{}
+	return output, restjson_deserializeDocument(body, &output.Account)
+}
+
+func restjson_deserializeOpUpdateAccount(body []byte) (interface{}, error) {
+	output := &UpdateAccountOutput{}
+	return output, restjson_deserializeDocument(body, &output.Account)
+}
+
+func restjson_deserializeOpDeleteAccount(body []byte) (interface{}, error) {
+	output := &DeleteAccountOutput{}
+	return output, restjson_deserializeDocument(body, output)
+}
@@ -0,0 +1,179 @@
+package pcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/aws/smithy-go/logging"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/cybr/discovery"
+	internalendpoints "github.com/strick-j/cybr-sdk-go/internal/endpoints"
+)
+
+// EndpointResolverOptions is the service endpoint resolver options
+type EndpointResolverOptions = internalendpoints.Options
+
+// EndpointResolver interface for resolving service endpoints.
+type EndpointResolver interface {
+	ResolveEndpoint(options EndpointResolverOptions) (cybr.Endpoint, error)
+}
+
+// NewDefaultEndpointResolver constructs a new service endpoint resolver
+func NewDefaultEndpointResolver() EndpointResolver {
+	return EndpointResolverFunc(func(options EndpointResolverOptions) (cybr.Endpoint, error) {
+		return internalendpoints.New().ResolveEndpoint(internalendpoints.ServicePCloud, options)
+	})
+}
+
+// EndpointResolverFunc is a helper utility that wraps a function so it satisfies
+// the EndpointResolver interface. This is useful when you want to add additional
+// endpoint resolving logic, or stub out specific endpoints with custom values.
+type EndpointResolverFunc func(options EndpointResolverOptions) (cybr.Endpoint, error)
+
+// ResolveEndpoint calls the wrapped function and returns the results.
+func (fn EndpointResolverFunc) ResolveEndpoint(options EndpointResolverOptions) (endpoint cybr.Endpoint, err error) {
+	return fn(options)
+}
+
+// EndpointResolverFromURL returns an EndpointResolver that always resolves the
+// provided endpoint url, regardless of the client's tenant. The endpoint source
+// is set to EndpointSourceCustom. You can provide functional options to
+// configure endpoint values for the resolved endpoint.
+func EndpointResolverFromURL(url string, optFns ...func(*cybr.Endpoint)) EndpointResolver {
+	e := cybr.Endpoint{URL: url, Source: cybr.EndpointSourceCustom}
+	for _, fn := range optFns {
+		fn(&e)
+	}
+
+	return EndpointResolverFunc(
+		func(options EndpointResolverOptions) (cybr.Endpoint, error) {
+			return e, nil
+		},
+	)
+}
+
+// withEndpointResolver returns an EndpointResolver that first delegates
+// endpoint resolution to the cybrResolver. If the cybrResolver returns an
+// EndpointNotFoundError the endpoint is resolved with the service's default
+// endpoint metadata. The cybrResolver is called with the service's ServiceID,
+// and the client's EndpointResolverOptions.
+func withEndpointResolver(cybrResolver cybr.EndpointResolverWithOptions) EndpointResolver {
+	fallback := internalendpoints.New()
+	return EndpointResolverFunc(func(options EndpointResolverOptions) (cybr.Endpoint, error) {
+		return internalendpoints.ResolveWithFallback(cybrResolver, fallback, ServiceID, options)
+	})
+}
+
+// resolveCYBREndpointResolver sets the client's EndpointResolver from the
+// config's EndpointResolverWithOptions, if one is set.
+func resolveCYBREndpointResolver(cfg cybr.Config, o *Options) {
+	if cfg.EndpointResolverWithOptions == nil {
+		return
+	}
+	o.EndpointResolver = withEndpointResolver(cfg.EndpointResolverWithOptions)
+}
+
+// resolveDefaultEndpointConfiguration sets the default endpoint resolver if
+// one is not provided.
+func resolveDefaultEndpointConfiguration(o *Options) {
+	if o.EndpointResolver != nil {
+		return
+	}
+	o.EndpointResolver = NewDefaultEndpointResolver()
+}
+
+// resolveDiscoveryClient sets the platform discovery client if one is not
+// provided, and endpoint discovery is not disabled.
+func resolveDiscoveryClient(o *Options) {
+	if o.DiscoveryClient != nil || o.DisableEndpointDiscovery {
+		return
+	}
+	o.DiscoveryClient = discovery.New(func(do *discovery.Options) {
+		do.HTTPClient = o.HTTPClient
+	})
+}
+
+// ResolveEndpoint is a SerializeMiddleware that resolves the service
+// endpoint, and sets it as the URL of the request.
+//
+// If a DiscoveryClient is set, the tenant is discovered with the platform
+// discovery API before the endpoint is resolved, and the result is provided
+// to the Resolver. If the tenant can not be discovered the Resolver falls
+// back to the service's hostname template.
+type ResolveEndpoint struct {
+	Resolver        EndpointResolver
+	Options         EndpointResolverOptions
+	DiscoveryClient *discovery.Client
+}
+
+// ID is the middleware identifier.
+func (*ResolveEndpoint) ID() string {
+	return "ResolveEndpoint"
+}
+
+// HandleSerialize resolves the endpoint, and sets it on the request.
+func (m *ResolveEndpoint) HandleSerialize(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+	out middleware.SerializeOutput, metadata middleware.Metadata, err error,
+) {
+	req, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return out, metadata, fmt.Errorf("unknown transport type %T", in.Request)
+	}
+
+	if m.Resolver == nil {
+		return out, metadata, fmt.Errorf("expected endpoint resolver to not be nil")
+	}
+
+	endpoint, err := m.Resolver.ResolveEndpoint(m.resolveOptions(ctx))
+	if err != nil {
+		return out, metadata, fmt.Errorf("failed to resolve service endpoint, %w", err)
+	}
+
+	req.URL, err = url.Parse(endpoint.URL)
+	if err != nil {
+		return out, metadata, fmt.Errorf("failed to parse endpoint URL: %w", err)
+	}
+
+	return next.HandleSerialize(ctx, in)
+}
+
+// resolveOptions returns the endpoint resolver options, with the tenant's
+// discovery result if the tenant can be discovered.
+func (m *ResolveEndpoint) resolveOptions(ctx context.Context) EndpointResolverOptions {
+	options := m.Options
+	if m.DiscoveryClient == nil || options.Discovery != nil || len(options.TenantName) == 0 {
+		return options
+	}
+	if options.BaseEndpoint != nil && len(*options.BaseEndpoint) != 0 {
+		return options
+	}
+
+	result, err := m.DiscoveryClient.Discover(ctx, options.TenantName)
+	if err != nil {
+		middleware.GetLogger(ctx).Logf(logging.Debug,
+			"unable to discover tenant endpoints, using default endpoint, %v", err)
+		return options
+	}
+	options.Discovery = result
+	return options
+}
+
+func addResolveEndpointMiddleware(stack *middleware.Stack, o Options) error {
+	options := o.EndpointOptions
+	options.TenantName = o.TenantName
+	options.BaseEndpoint = o.BaseEndpoint
+
+	var discoveryClient *discovery.Client
+	if !o.DisableEndpointDiscovery {
+		discoveryClient = o.DiscoveryClient
+	}
+
+	return stack.Serialize.Insert(&ResolveEndpoint{
+		Resolver:        o.EndpointResolver,
+		Options:         options,
+		DiscoveryClient: discoveryClient,
+	}, "OperationSerializer", middleware.Before)
+}
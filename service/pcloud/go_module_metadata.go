@@ -0,0 +1,4 @@
+package pcloud
+
+// goModuleVersion is the tagged release for this module
+const goModuleVersion = "v1.0.0"
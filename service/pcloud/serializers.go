@@ -0,0 +1,185 @@
+package pcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/encoding/httpbinding"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// restjson_serializeOp is the OperationSerializer of the client's REST JSON
+// operations. The operation's input is bound to the request's URI labels,
+// query string, and headers by BindInput, and the document returned by Body
+// is serialized as the request's JSON body.
+type restjson_serializeOp struct {
+	// The HTTP method of the operation.
+	Method string
+
+	// The operation's URI template, joined to the endpoint's path. Labels,
+	// (e.g. {name}), are replaced by BindInput.
+	URI string
+
+	// BindInput binds the input's members to the request. Optional.
+	BindInput func(input interface{}, encoder *httpbinding.Encoder) error
+
+	// Body returns the document serialized as the request body, or nil if
+	// the request has no body. Optional.
+	Body func(input interface{}) interface{}
+
+	// ContentType is the media type of the request body. Defaults to
+	// application/json.
+	ContentType string
+}
+
+// ID returns the middleware identifier.
+func (*restjson_serializeOp) ID() string {
+	return "OperationSerializer"
+}
+
+// HandleSerialize serializes the operation's input into the HTTP request.
+func (m *restjson_serializeOp) HandleSerialize(ctx context.Context, in middleware.SerializeInput, next middleware.SerializeHandler) (
+	out middleware.SerializeOutput, metadata middleware.Metadata, err error,
+) {
+	request, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return out, metadata, &smithy.SerializationError{Err: fmt.Errorf("unknown transport type %T", in.Request)}
+	}
+
+	opPath, opQuery := httpbinding.SplitURI(m.URI)
+	request.URL.Path = smithyhttp.JoinPath(request.URL.Path, opPath)
+	request.URL.RawQuery = smithyhttp.JoinRawQuery(request.URL.RawQuery, opQuery)
+	request.Method = m.Method
+
+	var restEncoder *httpbinding.Encoder
+	if request.URL.RawPath == "" {
+		restEncoder, err = httpbinding.NewEncoder(request.URL.Path, request.URL.RawQuery, request.Header)
+	} else {
+		request.URL.RawPath = smithyhttp.JoinPath(request.URL.RawPath, opPath)
+		restEncoder, err = httpbinding.NewEncoderWithRawPath(request.URL.Path, request.URL.RawPath, request.URL.RawQuery, request.Header)
+	}
+	if err != nil {
+		return out, metadata, &smithy.SerializationError{Err: err}
+	}
+
+	if m.BindInput != nil {
+		if err := m.BindInput(in.Parameters, restEncoder); err != nil {
+			return out, metadata, &smithy.SerializationError{Err: err}
+		}
+	}
+
+	if m.Body != nil {
+		if document := m.Body(in.Parameters); document != nil {
+			contentType := m.ContentType
+			if len(contentType) == 0 {
+				contentType = "application/json"
+			}
+			restEncoder.SetHeader("Content-Type").String(contentType)
+
+			body, err := json.Marshal(document)
+			if err != nil {
+				return out, metadata, &smithy.SerializationError{Err: err}
+			}
+			if request, err = request.SetStream(bytes.NewReader(body)); err != nil {
+				return out, metadata, &smithy.SerializationError{Err: err}
+			}
+		}
+	}
+
+	if request.Request, err = restEncoder.Encode(request.Request); err != nil {
+		return out, metadata, &smithy.SerializationError{Err: err}
+	}
+	in.Request = request
+
+	return next.HandleSerialize(ctx, in)
+}
+
+// bindURILabel binds the value of the URI label. Returns an error if the value
+// is not set, as the label can not be left in the request URI.
+func bindURILabel(encoder *httpbinding.Encoder, label string, v *string) error {
+	if v == nil || len(*v) == 0 {
+		return &smithy.SerializationError{Err: fmt.Errorf("input member %s must not be empty", label)}
+	}
+	return encoder.SetURI(label).String(*v)
+}
+
+func restjson_serializeOpHttpBindingsListAccountsInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*ListAccountsInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	if v.Search != nil {
+		encoder.SetQuery("search").String(*v.Search)
+	}
+	if len(v.SearchType) != 0 {
+		encoder.SetQuery("searchType").String(string(v.SearchType))
+	}
+
+	var filters []string
+	if v.SafeName != nil {
+		filters = append(filters, "safeName eq "+*v.SafeName)
+	}
+	if v.ModificationTime != nil {
+		filters = append(filters, "modificationTime gte "+strconv.FormatInt(v.ModificationTime.Unix(), 10))
+	}
+	if len(filters) != 0 {
+		encoder.SetQuery("filter").String(strings.Join(filters, " AND "))
+	}
+
+	if len(v.Sort) != 0 {
+		encoder.SetQuery("sort").String(strings.Join(v.Sort, ","))
+	}
+	if v.Offset != nil {
+		encoder.SetQuery("offset").Integer(*v.Offset)
+	}
+	if v.Limit != nil {
+		encoder.SetQuery("limit").Integer(*v.Limit)
+	}
+	return nil
+}
+
+func restjson_serializeOpHttpBindingsGetAccountInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*GetAccountInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "accountId", v.AccountID)
+}
+
+func restjson_serializeOpDocumentAddAccountInput(input interface{}) interface{} {
+	v, ok := input.(*AddAccountInput)
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func restjson_serializeOpHttpBindingsUpdateAccountInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*UpdateAccountInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "accountId", v.AccountID)
+}
+
+func restjson_serializeOpDocumentUpdateAccountInput(input interface{}) interface{} {
+	v, ok := input.(*UpdateAccountInput)
+	if !ok {
+		return nil
+	}
+	return v.Operations
+}
+
+func restjson_serializeOpHttpBindingsDeleteAccountInput(input interface{}, encoder *httpbinding.Encoder) error {
+	v, ok := input.(*DeleteAccountInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	return bindURILabel(encoder, "accountId", v.AccountID)
+}
@@ -0,0 +1,66 @@
+package types
+
+// SecretType is the type of an account's secret.
+type SecretType string
+
+// Enum values for SecretType
+const (
+	// A password.
+	SecretTypePassword SecretType = "password"
+
+	// An SSH private key.
+	SecretTypeKey SecretType = "key"
+)
+
+// Values returns all known values for SecretType. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (SecretType) Values() []SecretType {
+	return []SecretType{
+		"password",
+		"key",
+	}
+}
+
+// SearchType is how the search keywords of an accounts search are matched.
+type SearchType string
+
+// Enum values for SearchType
+const (
+	// Matches accounts with properties containing the keywords.
+	SearchTypeContains SearchType = "contains"
+
+	// Matches accounts with properties starting with the keywords.
+	SearchTypeStartsWith SearchType = "startswith"
+)
+
+// Values returns all known values for SearchType. Note that this can be
+// expanded in the future, and so it is only as up to date as the client. The
+// ordering of this slice is not guaranteed to be stable across updates.
+func (SearchType) Values() []SearchType {
+	return []SearchType{
+		"contains",
+		"startswith",
+	}
+}
+
+// PatchOperationType is the operation of a JSON Patch operation.
+type PatchOperationType string
+
+// Enum values for PatchOperationType
+const (
+	PatchOperationTypeAdd     PatchOperationType = "add"
+	PatchOperationTypeRemove  PatchOperationType = "remove"
+	PatchOperationTypeReplace PatchOperationType = "replace"
+)
+
+// Values returns all known values for PatchOperationType. Note that this can
+// be expanded in the future, and so it is only as up to date as the client.
+// The ordering of this slice is not guaranteed to be stable across updates.
+func (PatchOperationType) Values() []PatchOperationType {
+	return []PatchOperationType{
+		"add",
+		"remove",
+		"replace",
+	}
+}
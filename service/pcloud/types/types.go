@@ -0,0 +1,110 @@
+package types
+
+import (
+	smithydocument "github.com/aws/smithy-go/document"
+)
+
+type noSmithyDocumentSerde = smithydocument.NoSerde
+
+// Account is a privileged account stored in a Privilege Cloud safe. The
+// account's secret is never returned.
+type Account struct {
+
+	// The identifier of the account, (e.g. 12_34).
+	ID *string `json:"id,omitempty"`
+
+	// The name of the account.
+	Name *string `json:"name,omitempty"`
+
+	// The address of the machine the account is used on.
+	Address *string `json:"address,omitempty"`
+
+	// The username of the account.
+	UserName *string `json:"userName,omitempty"`
+
+	// The identifier of the platform managing the account.
+	PlatformID *string `json:"platformId,omitempty"`
+
+	// The name of the safe the account is stored in.
+	SafeName *string `json:"safeName,omitempty"`
+
+	// The type of the account's secret.
+	SecretType SecretType `json:"secretType,omitempty"`
+
+	// The values of the platform's account properties, by property name.
+	PlatformAccountProperties map[string]string `json:"platformAccountProperties,omitempty"`
+
+	// The management of the account's secret by the CPM.
+	SecretManagement *SecretManagement `json:"secretManagement,omitempty"`
+
+	// The machines the account is restricted to.
+	RemoteMachinesAccess *RemoteMachinesAccess `json:"remoteMachinesAccess,omitempty"`
+
+	// The time the account was created, in Unix epoch seconds.
+	CreatedTime *int64 `json:"createdTime,omitempty"`
+
+	// The time the account's properties were last modified, in Unix epoch
+	// seconds.
+	CategoryModificationTime *int64 `json:"categoryModificationTime,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// PatchOperation is a JSON Patch (RFC 6902) operation, modifying a property
+// of an account.
+type PatchOperation struct {
+
+	// The operation.
+	//
+	// This member is required.
+	Op PatchOperationType `json:"op,omitempty"`
+
+	// The JSON Pointer of the modified property, (e.g. /address, or
+	// /platformAccountProperties/Port).
+	//
+	// This member is required.
+	Path *string `json:"path,omitempty"`
+
+	// The value of the property. Not set for remove operations.
+	Value interface{} `json:"value,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// RemoteMachinesAccess restricts the machines an account may be used on.
+type RemoteMachinesAccess struct {
+
+	// The semicolon separated addresses of the machines the account may be
+	// used on.
+	RemoteMachines *string `json:"remoteMachines,omitempty"`
+
+	// Whether the account may only be used on the remote machines.
+	AccessRestrictedToRemoteMachines *bool `json:"accessRestrictedToRemoteMachines,omitempty"`
+
+	noSmithyDocumentSerde
+}
+
+// SecretManagement is the management of an account's secret by the Central
+// Policy Manager (CPM).
+type SecretManagement struct {
+
+	// Whether the CPM manages the secret automatically.
+	AutomaticManagementEnabled *bool `json:"automaticManagementEnabled,omitempty"`
+
+	// The reason the secret is managed manually.
+	ManualManagementReason *string `json:"manualManagementReason,omitempty"`
+
+	// The status of the last management of the secret, (e.g. success).
+	Status *string `json:"status,omitempty"`
+
+	// The time the secret was last modified, in Unix epoch seconds.
+	LastModifiedTime *int64 `json:"lastModifiedTime,omitempty"`
+
+	// The time the secret was last reconciled, in Unix epoch seconds.
+	LastReconciledTime *int64 `json:"lastReconciledTime,omitempty"`
+
+	// The time the secret was last verified, in Unix epoch seconds.
+	LastVerifiedTime *int64 `json:"lastVerifiedTime,omitempty"`
+
+	noSmithyDocumentSerde
+}
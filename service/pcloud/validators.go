@@ -0,0 +1,120 @@
+package pcloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/smithy-go/middleware"
+	"github.com/strick-j/cybr-sdk-go/cybr"
+	"github.com/strick-j/cybr-sdk-go/service/pcloud/types"
+)
+
+// validateOpInput is the OperationInputValidation middleware, validating the
+// operation's input parameters before the request is serialized.
+type validateOpInput struct {
+	validate func(input interface{}) error
+}
+
+// ID returns the middleware identifier.
+func (*validateOpInput) ID() string {
+	return "OperationInputValidation"
+}
+
+// HandleInitialize validates the operation's input parameters.
+func (m *validateOpInput) HandleInitialize(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	out middleware.InitializeOutput, metadata middleware.Metadata, err error,
+) {
+	if err := m.validate(in.Parameters); err != nil {
+		return out, metadata, err
+	}
+	return next.HandleInitialize(ctx, in)
+}
+
+func addOpInputValidationMiddleware(stack *middleware.Stack, validate func(input interface{}) error) error {
+	return stack.Initialize.Add(&validateOpInput{validate: validate}, middleware.After)
+}
+
+func validateOpAddAccountInput(input interface{}) error {
+	v, ok := input.(*AddAccountInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "AddAccountInput"}
+	if v.SafeName == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("SafeName"))
+	}
+	if v.PlatformID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("PlatformID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpGetAccountInput(input interface{}) error {
+	v, ok := input.(*GetAccountInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "GetAccountInput"}
+	if v.AccountID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("AccountID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpUpdateAccountInput(input interface{}) error {
+	v, ok := input.(*UpdateAccountInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "UpdateAccountInput"}
+	if v.AccountID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("AccountID"))
+	}
+	if v.Operations == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Operations"))
+	}
+	for i := range v.Operations {
+		if err := validatePatchOperation(&v.Operations[i]); err != nil {
+			invalidParams.AddNested(fmt.Sprintf("Operations[%d]", i), *err)
+		}
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validateOpDeleteAccountInput(input interface{}) error {
+	v, ok := input.(*DeleteAccountInput)
+	if !ok {
+		return fmt.Errorf("unknown input parameters type %T", input)
+	}
+	invalidParams := cybr.InvalidParamsError{Context: "DeleteAccountInput"}
+	if v.AccountID == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("AccountID"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+func validatePatchOperation(v *types.PatchOperation) *cybr.InvalidParamsError {
+	invalidParams := cybr.InvalidParamsError{Context: "PatchOperation"}
+	if len(v.Op) == 0 {
+		invalidParams.Add(cybr.NewErrParamRequired("Op"))
+	}
+	if v.Path == nil {
+		invalidParams.Add(cybr.NewErrParamRequired("Path"))
+	}
+	if invalidParams.Len() > 0 {
+		return &invalidParams
+	}
+	return nil
+}